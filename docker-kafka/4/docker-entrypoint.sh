@@ -60,12 +60,17 @@ export KAFKA_OPTS="${KAFKA_OPTS} -javaagent:/opt/kafka/libs/jmx_prometheus_javaa
 if [[ "$KRAFT_ENABLED" == "true" ]]; then
   export CONF_KAFKA_PROCESS_ROLES=${PROCESS_ROLES}
   export CONF_KAFKA_CONTROLLER_LISTENER_NAMES=CONTROLLER
-  export CONF_KAFKA_CONTROLLER_QUORUM_VOTERS=${VOTERS}
   export CONF_KAFKA_NODE_ID=${BROKER_ID}
-  # For Kraft remove quorum-state file so that we won't enter voter not match error after scaling up/down https://issues.apache.org/jira/browse/KAFKA-14094
-  if [ -f "/var/opt/kafka/data/$BROKER_ID/__cluster_metadata-0/quorum-state" ]; then
-    echo "Removing quorum-state file"
-    rm -f "/var/opt/kafka/data/$BROKER_ID/__cluster_metadata-0/quorum-state"
+  if [[ -n "$QUORUM_BOOTSTRAP_SERVERS" ]]; then
+    # Dynamic quorum, voters are stored in metadata log and changed with kafka-metadata-quorum.sh
+    export CONF_KAFKA_CONTROLLER_QUORUM_BOOTSTRAP_SERVERS=${QUORUM_BOOTSTRAP_SERVERS}
+  else
+    export CONF_KAFKA_CONTROLLER_QUORUM_VOTERS=${VOTERS}
+    # For Kraft remove quorum-state file so that we won't enter voter not match error after scaling up/down https://issues.apache.org/jira/browse/KAFKA-14094
+    if [ -f "/var/opt/kafka/data/$BROKER_ID/__cluster_metadata-0/quorum-state" ]; then
+      echo "Removing quorum-state file"
+      rm -f "/var/opt/kafka/data/$BROKER_ID/__cluster_metadata-0/quorum-state"
+    fi
   fi
 fi
 
@@ -78,7 +83,8 @@ export CONF_KAFKA_GROUP_INITIAL_REBALANCE_DELAY_MS=${CONF_KAFKA_GROUP_INITIAL_RE
 export CONF_KAFKA_DELETE_TOPIC_ENABLE=true
 export CONF_KAFKA_ZOOKEEPER_CONNECT=${ZOOKEEPER_CONNECT}
 export CONF_KAFKA_BROKER_ID=${BROKER_ID}
-export CONF_KAFKA_LOG_DIRS="$KAFKA_DATA_DIRS/$BROKER_ID"
+# LOG_DIRS contains comma separated list of log directories if broker has several data volumes (JBOD)
+export CONF_KAFKA_LOG_DIRS=${LOG_DIRS:-"$KAFKA_DATA_DIRS/$BROKER_ID"}
 
 if [[ -z "$CONF_KAFKA_DEFAULT_REPLICATION_FACTOR" ]]; then
   # REPLICATION_FACTOR = 3 is a recommended setting for a cluster of more than 3 brokers
@@ -93,7 +99,7 @@ fi
 export CONF_KAFKA_OFFSETS_TOPIC_REPLICATION_FACTOR=${REPLICATION_FACTOR}
 export CONF_KAFKA_DEFAULT_REPLICATION_FACTOR=${REPLICATION_FACTOR}
 export CONF_KAFKA_TRANSACTION_STATE_LOG_REPLICATION_FACTOR=${REPLICATION_FACTOR}
-mkdir -p ${CONF_KAFKA_LOG_DIRS}
+mkdir -p ${CONF_KAFKA_LOG_DIRS//,/ }
 
 MIN_ISR=$(( ($REPLICATION_FACTOR / 2) + 1 ))
 # Kafka doesn't have arbiter node, so min insync replicas should be specified to 1
@@ -145,35 +151,47 @@ if [[ -n "$EXTERNAL_HOST_NAME" ]]; then
 fi
 
 if [[ -z "$LISTENERS" ]]; then
-  LISTENERS=INTERNAL://0.0.0.0:${INTERNAL_PORT},INTER_BROKER://0.0.0.0:${INTER_BROKER_PORT}
-  if [[ "$KRAFT_ENABLED" == "true" && "$MIGRATED_BROKER" != "true" ]]; then
+  if [[ -n "$CLIENT_LISTENERS" ]]; then
+    LISTENERS=${CLIENT_LISTENERS},INTER_BROKER://0.0.0.0:${INTER_BROKER_PORT}
+  else
+    LISTENERS=INTERNAL://0.0.0.0:${INTERNAL_PORT},INTER_BROKER://0.0.0.0:${INTER_BROKER_PORT}
+  fi
+  if [[ "$KRAFT_ENABLED" == "true" && "$MIGRATED_BROKER" != "true" && "$PROCESS_ROLES" == *controller* ]]; then
     LISTENERS=${LISTENERS},CONTROLLER://0.0.0.0:9096
   fi
   if [[ "$ENABLE_EXTERNAL_LISTENER" == true ]]; then
     LISTENERS=${LISTENERS},EXTERNAL://0.0.0.0:${LISTENER_EXTERNAL_PORT}
   fi
-  if [[ "${ENABLE_SSL}" == "true" && "${ALLOW_NONENCRYPTED_ACCESS}" == "true" ]]; then
+  if [[ -z "$CLIENT_LISTENERS" && "${ENABLE_SSL}" == "true" && "${ALLOW_NONENCRYPTED_ACCESS}" == "true" ]]; then
     LISTENERS=${LISTENERS},NONENCRYPTED://0.0.0.0:${NONENCRYPTED_PORT}
   fi
 fi
 if [[ -z "$ADVERTISED_LISTENERS" && "$MIGRATION_CONTROLLER" != "true" && "$MIGRATED_CONTROLLER" != "true" ]]; then
-  ADVERTISED_LISTENERS=INTERNAL://${INTERNAL_HOST_NAME}:${INTERNAL_PORT},INTER_BROKER://${INTER_BROKER_HOST_NAME}:${INTER_BROKER_PORT}
+  if [[ -n "$CLIENT_ADVERTISED_LISTENERS" ]]; then
+    ADVERTISED_LISTENERS=${CLIENT_ADVERTISED_LISTENERS},INTER_BROKER://${INTER_BROKER_HOST_NAME}:${INTER_BROKER_PORT}
+  else
+    ADVERTISED_LISTENERS=INTERNAL://${INTERNAL_HOST_NAME}:${INTERNAL_PORT},INTER_BROKER://${INTER_BROKER_HOST_NAME}:${INTER_BROKER_PORT}
+  fi
   if [[ "$ENABLE_EXTERNAL_LISTENER" == true ]]; then
     ADVERTISED_LISTENERS=${ADVERTISED_LISTENERS},EXTERNAL://${EXTERNAL_HOST_NAME}:${EXTERNAL_PORT}
   fi
-  if [[ "${ENABLE_SSL}" == "true" && "${ALLOW_NONENCRYPTED_ACCESS}" == "true" ]]; then
+  if [[ -z "$CLIENT_ADVERTISED_LISTENERS" && "${ENABLE_SSL}" == "true" && "${ALLOW_NONENCRYPTED_ACCESS}" == "true" ]]; then
     ADVERTISED_LISTENERS=${ADVERTISED_LISTENERS},NONENCRYPTED://${INTERNAL_HOST_NAME}:${NONENCRYPTED_PORT}
   fi
 fi
 if [[ -z "$LISTENER_SECURITY_PROTOCOL_MAP" ]]; then
-  LISTENER_SECURITY_PROTOCOL_MAP=INTERNAL:${SECURITY_PROTOCOL},INTER_BROKER:${SECURITY_PROTOCOL}
+  if [[ -n "$CLIENT_LISTENER_SECURITY_PROTOCOL_MAP" ]]; then
+    LISTENER_SECURITY_PROTOCOL_MAP=${CLIENT_LISTENER_SECURITY_PROTOCOL_MAP},INTER_BROKER:${SECURITY_PROTOCOL}
+  else
+    LISTENER_SECURITY_PROTOCOL_MAP=INTERNAL:${SECURITY_PROTOCOL},INTER_BROKER:${SECURITY_PROTOCOL}
+  fi
   if [[ "$KRAFT_ENABLED" == "true" ]]; then
     LISTENER_SECURITY_PROTOCOL_MAP=${LISTENER_SECURITY_PROTOCOL_MAP},CONTROLLER:${SECURITY_PROTOCOL}
   fi
   if [[ "$ENABLE_EXTERNAL_LISTENER" == true ]]; then
     LISTENER_SECURITY_PROTOCOL_MAP=${LISTENER_SECURITY_PROTOCOL_MAP},EXTERNAL:${SECURITY_PROTOCOL}
   fi
-  if [[ "${ENABLE_SSL}" == "true" && "${ALLOW_NONENCRYPTED_ACCESS}" == "true" ]]; then
+  if [[ -z "$CLIENT_LISTENER_SECURITY_PROTOCOL_MAP" && "${ENABLE_SSL}" == "true" && "${ALLOW_NONENCRYPTED_ACCESS}" == "true" ]]; then
     LISTENER_SECURITY_PROTOCOL_MAP=${LISTENER_SECURITY_PROTOCOL_MAP},NONENCRYPTED:${NONENCRYPTED_SECURITY_PROTOCOL}
   fi
 fi
@@ -222,6 +240,9 @@ unset EXTERNAL_HOST_NAME
 unset LISTENERS
 unset ADVERTISED_LISTENERS
 unset LISTENER_SECURITY_PROTOCOL_MAP
+unset CLIENT_LISTENERS
+unset CLIENT_ADVERTISED_LISTENERS
+unset CLIENT_LISTENER_SECURITY_PROTOCOL_MAP
 unset INTER_BROKER_LISTENER_NAME
 echo "Using CONF_KAFKA_LISTENERS=$CONF_KAFKA_LISTENERS"
 echo "Using CONF_KAFKA_ADVERTISED_LISTENERS=$CONF_KAFKA_ADVERTISED_LISTENERS"
@@ -292,6 +313,15 @@ if [[ "${ENABLE_SSL}" == "true" ]]; then
     keytool -importkeystore -destkeystore ${SSL_KEYSTORE_LOCATION} -deststorepass changeit -srcstoretype PKCS12 -srckeystore ${kafka_tls_ks_dir}/kafka.keystore.p12 -srcstorepass changeit
     keytool -import -trustcacerts -keystore ${SSL_KEYSTORE_LOCATION} -storepass changeit -noprompt -alias ca-cert -file ${SSL_CA_LOCATION}
     keytool -import -trustcacerts -keystore ${SSL_TRUSTSTORE_LOCATION} -storepass changeit -noprompt -alias ca -file ${SSL_CA_LOCATION}
+    # CA file can contain several certificates, for example, current and previous CA during CA rotation
+    awk -v dir="${kafka_tls_ks_dir}" '/-----BEGIN CERTIFICATE-----/{n++} n>1{print > (dir "/ca-" n ".crt")}' ${SSL_CA_LOCATION}
+    for ca_file in ${kafka_tls_ks_dir}/ca-*.crt; do
+      [[ -f ${ca_file} ]] || continue
+      ca_alias=$(basename ${ca_file} .crt)
+      keytool -import -trustcacerts -keystore ${SSL_TRUSTSTORE_LOCATION} -storepass changeit -noprompt -alias ${ca_alias} -file ${ca_file}
+    done
+    # snapshot of trusted CAs allows to distinguish certificate-only renewal which is reloaded without restart
+    cp ${SSL_CA_LOCATION} ${kafka_tls_ks_dir}/trusted-ca.crt
 
     export CONF_KAFKA_SSL_KEYSTORE_LOCATION=${SSL_KEYSTORE_LOCATION}
     export CONF_KAFKA_SSL_KEYSTORE_PASSWORD=changeit
@@ -440,7 +470,7 @@ function set_security_parameters() {
 
   if [[ "${ENABLE_SSL}" == "true" && "${ENABLE_2WAY_SSL}" == "true" && ${listener_name} != "nonencrypted" ]]; then
     env_name=CONF_KAFKA_LISTENER_NAME_${listener_name}_SSL_CLIENT_AUTH
-    export ${env_name}=required
+    export ${env_name}=${!env_name:-required}
     echo "Using ${env_name}=${!env_name}"
   fi
 }
@@ -696,6 +726,24 @@ if [[ "$METRIC_COLLECTOR_ENABLED" == "true" ]]; then
 
 fi
 
+# Prepare Tiered Storage remote log metadata manager configuration
+
+if [[ "$CONF_KAFKA_REMOTE_LOG_STORAGE_SYSTEM_ENABLE" == "true" ]]; then
+  if [[ "$DISABLE_SECURITY" == false ]]; then
+    export CONF_KAFKA_RLMM_CONFIG_REMOTE_LOG_METADATA_COMMON_CLIENT_SASL_JAAS_CONFIG="org.apache.kafka.common.security.scram.ScramLoginModule required username=\"${ADMIN_USERNAME}\" password=\"${ADMIN_PASSWORD}\";"
+    export CONF_KAFKA_RLMM_CONFIG_REMOTE_LOG_METADATA_COMMON_CLIENT_SASL_MECHANISM=SCRAM-SHA-512
+  fi
+  if [[ "${ENABLE_SSL}" == "true" ]]; then
+    export CONF_KAFKA_RLMM_CONFIG_REMOTE_LOG_METADATA_COMMON_CLIENT_SSL_TRUSTSTORE_LOCATION=${SSL_TRUSTSTORE_LOCATION}
+    export CONF_KAFKA_RLMM_CONFIG_REMOTE_LOG_METADATA_COMMON_CLIENT_SSL_TRUSTSTORE_PASSWORD=changeit
+    if [[ "${ENABLE_2WAY_SSL}" == "true" ]]; then
+      export CONF_KAFKA_RLMM_CONFIG_REMOTE_LOG_METADATA_COMMON_CLIENT_SSL_KEYSTORE_LOCATION=${SSL_KEYSTORE_LOCATION}
+      export CONF_KAFKA_RLMM_CONFIG_REMOTE_LOG_METADATA_COMMON_CLIENT_SSL_KEYSTORE_PASSWORD=changeit
+      export CONF_KAFKA_RLMM_CONFIG_REMOTE_LOG_METADATA_COMMON_CLIENT_SSL_KEY_PASSWORD=changeit
+    fi
+  fi
+fi
+
 # Prepare config files to use them in scripts
 rm -rf \
   ${KAFKA_HOME}/bin/adminclient.properties \
@@ -709,13 +757,16 @@ fi
 rm -rf "${KAFKA_HOME}/bin/kafkacat.properties"
 cp "${KAFKA_HOME}/bin/kcat.properties" "${KAFKA_HOME}/bin/kafkacat.properties"
 
-if [[ -f ${CONF_KAFKA_LOG_DIRS}/.lock ]]; then
-    echo "WARNING: There is FS lock file from previous Kafka pod. Removing it."
-    rm "${CONF_KAFKA_LOG_DIRS}/.lock"
-fi
+for log_dir in ${CONF_KAFKA_LOG_DIRS//,/ }; do
+  if [[ -f ${log_dir}/.lock ]]; then
+      echo "WARNING: There is FS lock file from previous Kafka pod in ${log_dir}. Removing it."
+      rm "${log_dir}/.lock"
+  fi
+done
 
 # WA for https://issues.apache.org/jira/browse/KAFKA-9444
-if [[ -f ${CONF_KAFKA_LOG_DIRS}/meta.properties ]]; then
+# Directory ID from meta.properties identifies voter in dynamic quorum and log directory in JBOD mode, so it must be kept
+if [[ -f ${CONF_KAFKA_LOG_DIRS}/meta.properties && -z "$QUORUM_BOOTSTRAP_SERVERS" && -z "$LOG_DIRS" ]]; then
     echo "WARNING: There is meta.properties file. Removing it."
     rm "${CONF_KAFKA_LOG_DIRS}/meta.properties"
 fi
@@ -778,7 +829,23 @@ case $1 in
       fi
     done
     if [[ "$KRAFT_ENABLED" == "true" ]]; then
-      ${KAFKA_HOME}/bin/kafka-storage.sh format -t "${KRAFT_CLUSTER_ID}" -c "${KAFKA_HOME}/config/server.properties" ${KAFKA_CREDENTIALS}
+      KRAFT_FORMAT_OPTIONS=""
+      if [[ -n "$LOG_DIRS" ]]; then
+        # Only newly added log directories are formatted
+        KRAFT_FORMAT_OPTIONS="--ignore-formatted"
+      fi
+      if [[ -n "$QUORUM_BOOTSTRAP_SERVERS" ]]; then
+        KRAFT_FORMAT_OPTIONS="--ignore-formatted"
+        if [[ "$PROCESS_ROLES" == "controller" ]]; then
+          # The first controller bootstraps quorum alone, others join it as observers and are added by operator
+          if [[ "$STANDALONE_CONTROLLER" == "true" ]]; then
+            KRAFT_FORMAT_OPTIONS="${KRAFT_FORMAT_OPTIONS} --standalone"
+          else
+            KRAFT_FORMAT_OPTIONS="${KRAFT_FORMAT_OPTIONS} --no-initial-controllers"
+          fi
+        fi
+      fi
+      ${KAFKA_HOME}/bin/kafka-storage.sh format -t "${KRAFT_CLUSTER_ID}" -c "${KAFKA_HOME}/config/server.properties" ${KAFKA_CREDENTIALS} ${KRAFT_FORMAT_OPTIONS}
     fi
     exec ${KAFKA_HOME}/bin/kafka-server-start.sh ${KAFKA_HOME}/config/server.properties
     ;;
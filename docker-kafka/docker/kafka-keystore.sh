@@ -0,0 +1,52 @@
+#!/usr/bin/env bash
+
+# Rebuilds keystore of the current broker from renewed certificate in mounted TLS secret,
+# so that it can be reloaded by broker dynamically without restart. It is called by the operator only for
+# dedicated broker certificates issued by cert-manager or operator CA, not for the common TLS secret.
+#
+# Usage:
+#   kafka-keystore.sh rebuild <suffix> <tls-crt-sha256>  - creates new keystore and prints its location,
+#                                                           prints "PENDING" if mounted certificate is not updated yet
+
+if [[ "$DEBUG" == true ]]; then
+  set -x
+fi
+
+kafka_tls_dir=${KAFKA_HOME}/tls
+kafka_tls_ks_dir=${KAFKA_HOME}/tls-ks
+
+case $1 in
+  rebuild)
+    if [[ ! -f ${kafka_tls_ks_dir}/trusted-ca.crt ]]; then
+      echo "Trusted CA snapshot is not found, broker restart is required"
+      exit 1
+    fi
+    if [[ "$(sha256sum ${kafka_tls_dir}/tls.crt | cut -d ' ' -f 1)" != "$3" ]]; then
+      echo "PENDING"
+      exit 0
+    fi
+    if ! cmp -s ${kafka_tls_dir}/ca.crt ${kafka_tls_ks_dir}/trusted-ca.crt; then
+      echo "CA certificate is changed, broker restart is required"
+      exit 1
+    fi
+    keystore=${kafka_tls_ks_dir}/kafka.keystore-$2.jks
+    rm -f ${keystore} ${kafka_tls_ks_dir}/kafka.keystore-$2.p12
+    openssl pkcs12 -export -in ${kafka_tls_dir}/tls.crt -inkey ${kafka_tls_dir}/tls.key \
+      -out ${kafka_tls_ks_dir}/kafka.keystore-$2.p12 -passout pass:changeit >/dev/null 2>&1 &&
+    keytool -importkeystore -destkeystore ${keystore} -deststorepass changeit -srcstoretype PKCS12 \
+      -srckeystore ${kafka_tls_ks_dir}/kafka.keystore-$2.p12 -srcstorepass changeit >/dev/null 2>&1 &&
+    keytool -import -trustcacerts -keystore ${keystore} -storepass changeit -noprompt -alias ca-cert \
+      -file ${kafka_tls_dir}/ca.crt >/dev/null 2>&1
+    code=$?
+    rm -f ${kafka_tls_ks_dir}/kafka.keystore-$2.p12
+    if [[ ${code} -ne 0 ]]; then
+      echo "Unable to create keystore ${keystore}"
+      exit ${code}
+    fi
+    echo "${keystore}"
+    ;;
+  *)
+    echo "Unknown command: $1"
+    exit 1
+    ;;
+esac
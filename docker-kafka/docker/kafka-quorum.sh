@@ -0,0 +1,65 @@
+#!/usr/bin/env bash
+
+# Describes and changes KRaft controller quorum via local controller listener.
+# Tool warnings are redirected to stdout, so that only exit code reports failure.
+#
+# Usage:
+#   kafka-quorum.sh status                                 - prints quorum status
+#   kafka-quorum.sh replication                            - prints replication state of voters and observers
+#   kafka-quorum.sh add                                    - adds current controller to quorum voters
+#   kafka-quorum.sh remove <controller-id> <directory-id>  - removes controller from quorum voters
+
+if [[ "$DEBUG" == true ]]; then
+  set -x
+fi
+
+: ${QUORUM_CONTROLLER_ADDRESS:="localhost:9092"}
+
+QUORUM_CLIENT_CONFIG=${KAFKA_HOME}/bin/quorumclient.properties
+if [[ ! -f ${QUORUM_CLIENT_CONFIG} ]]; then
+  grep -v -E "^(sasl.mechanism|sasl.jaas.config)=" ${KAFKA_HOME}/bin/adminclient.properties > ${QUORUM_CLIENT_CONFIG}
+  if [[ "$DISABLE_SECURITY" == false ]]; then
+    cat >> ${QUORUM_CLIENT_CONFIG} << EOL
+sasl.mechanism=PLAIN
+sasl.jaas.config=org.apache.kafka.common.security.plain.PlainLoginModule required username="${ADMIN_USERNAME}" password="${ADMIN_PASSWORD}";
+EOL
+  fi
+fi
+
+case $1 in
+  status)
+    ${KAFKA_HOME}/bin/kafka-metadata-quorum.sh \
+      --bootstrap-controller "${QUORUM_CONTROLLER_ADDRESS}" \
+      --command-config ${QUORUM_CLIENT_CONFIG} \
+      describe --status 2>&1
+    ;;
+  replication)
+    ${KAFKA_HOME}/bin/kafka-metadata-quorum.sh \
+      --bootstrap-controller "${QUORUM_CONTROLLER_ADDRESS}" \
+      --command-config ${QUORUM_CLIENT_CONFIG} \
+      describe --replication 2>&1
+    ;;
+  add)
+    # add-controller reads node id and metadata directory of the new voter from server configuration
+    cat ${KAFKA_HOME}/config/server.properties ${QUORUM_CLIENT_CONFIG} > ${KAFKA_HOME}/bin/quorum-add-controller.properties
+    ${KAFKA_HOME}/bin/kafka-metadata-quorum.sh \
+      --bootstrap-controller "${QUORUM_CONTROLLER_ADDRESS}" \
+      --command-config ${KAFKA_HOME}/bin/quorum-add-controller.properties \
+      add-controller 2>&1
+    code=$?
+    rm -f ${KAFKA_HOME}/bin/quorum-add-controller.properties
+    exit ${code}
+    ;;
+  remove)
+    ${KAFKA_HOME}/bin/kafka-metadata-quorum.sh \
+      --bootstrap-controller "${QUORUM_CONTROLLER_ADDRESS}" \
+      --command-config ${QUORUM_CLIENT_CONFIG} \
+      remove-controller \
+      --controller-id "$2" \
+      --controller-directory-id "$3" 2>&1
+    ;;
+  *)
+    echo "Unknown command: $1"
+    exit 1
+    ;;
+esac
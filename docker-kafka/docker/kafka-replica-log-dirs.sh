@@ -0,0 +1,31 @@
+#!/usr/bin/env bash
+
+# Moves partition replicas between log directories of the current broker (AlterReplicaLogDirs)
+# with reassignment tool. Tool warnings are redirected to stdout, so that only exit code reports failure.
+#
+# Usage:
+#   kafka-replica-log-dirs.sh execute <reassignment-json>  - starts movement of replicas to log directories from plan
+
+if [[ "$DEBUG" == true ]]; then
+  set -x
+fi
+
+REASSIGNMENT_FILE=${KAFKA_HOME}/bin/replica-log-dirs.json
+
+case $1 in
+  execute)
+    echo "$2" > ${REASSIGNMENT_FILE}
+    ${KAFKA_HOME}/bin/kafka-reassign-partitions.sh \
+      --bootstrap-server "localhost:9093" \
+      --command-config ${KAFKA_HOME}/bin/adminclient.properties \
+      --reassignment-json-file ${REASSIGNMENT_FILE} \
+      --execute 2>&1
+    code=$?
+    rm -f ${REASSIGNMENT_FILE}
+    exit ${code}
+    ;;
+  *)
+    echo "Unknown command: $1"
+    exit 1
+    ;;
+esac
@@ -206,6 +206,37 @@ def _collect_compatibility_metric(admin_client: KafkaAdminClient):
               f' version_compatible={version_compatible}i'
     return message
 
+# Return message with 'remote.storage.enable' status of each topic
+def _build_tiered_storage_message(topic_configs: list) -> str:
+    lines = []
+    for topic in sorted(topic_configs, key=lambda t: t['resource_name']):
+        configs = {c['config_names']: c['config_value'] for c in topic['config_entries']}
+        remote_storage_enabled = 1 if configs.get('remote.storage.enable') == 'true' else 0
+        line = f'kafka_topic_tiered_storage,' \
+               f'namespace={OS_PROJECT},' \
+               f'topic={topic["resource_name"]}' \
+               f' remote_storage_enabled={remote_storage_enabled}i'
+        local_retention_ms = configs.get('local.retention.ms')
+        if local_retention_ms is not None:
+            line = f'{line},local_retention_ms={local_retention_ms}i'
+        lines.append(line)
+    return '\n'.join(lines)
+
+
+# Collects tiered storage status of topics if remote log storage is enabled on brokers
+def _collect_tiered_storage_metric(admin_client: KafkaAdminClient, broker_id) -> str:
+    broker_configs = _get_broker_configs(admin_client, str(broker_id))
+    if broker_configs.get('remote.log.storage.system.enable') != 'true':
+        return ''
+    topics = [topic for topic in admin_client.list_topics() if not topic.startswith('__')]
+    if not topics:
+        return ''
+    responses = admin_client.describe_configs([ConfigResource(ConfigResourceType.TOPIC, topic) for topic in topics])
+    topic_configs = []
+    for response in responses:
+        topic_configs.extend(response.to_object()['resources'])
+    return _build_tiered_storage_message(topic_configs)
+
 def _is_kraft(admin_client, broker_id):
     config_resource = ConfigResource(ConfigResourceType.BROKER, str(broker_id))
     configs = admin_client.describe_configs([config_resource])
@@ -264,6 +295,9 @@ def _collect_metrics():
     if admin_client is not None:
         compatibility_message = _collect_compatibility_metric(admin_client)
         message = f'{message}\n{compatibility_message}'
+        tiered_storage_message = _collect_tiered_storage_metric(admin_client, broker_ids[0])
+        if tiered_storage_message:
+            message = f'{message}\n{tiered_storage_message}'
     return message
 
 
@@ -21,7 +21,8 @@ import kafka_metric
 from kafka_metric import \
     _collect_metrics, \
     _is_version_compatible, \
-    _check_config_consistency
+    _check_config_consistency, \
+    _build_tiered_storage_message
 
 
 def partition(partition_number: int, leader: int, replicas: list, isr: list) -> dict:
@@ -358,6 +359,19 @@ class TestKafkaMetric(unittest.TestCase):
         self.assertTrue(_is_version_compatible("3.2.x", "0.0.0", "x.x.x"))
         self.assertTrue(_is_version_compatible("4.0.x", "0.0.0", "x.x.x"))
 
+    def test_build_tiered_storage_message(self):
+        kafka_metric.OS_PROJECT = "kafka-cluster"
+        remote_topic = topic_configs('remote_topic')
+        remote_topic['config_entries'].extend([topic_config('remote.storage.enable', 'true'),
+                                               topic_config('local.retention.ms', '3600000')])
+        local_topic = topic_configs('local_topic')
+        local_topic['config_entries'].append(topic_config('remote.storage.enable', 'false'))
+        message = _build_tiered_storage_message([remote_topic, local_topic])
+        self.assertEqual('kafka_topic_tiered_storage,namespace=kafka-cluster,topic=local_topic'
+                         ' remote_storage_enabled=0i\n'
+                         'kafka_topic_tiered_storage,namespace=kafka-cluster,topic=remote_topic'
+                         ' remote_storage_enabled=1i,local_retention_ms=3600000i', message)
+
 
 if __name__ == '__main__':
     unittest.main()
@@ -0,0 +1,116 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// IssuerKind is the kind of namespaced cert-manager issuer
+	IssuerKind = "Issuer"
+	// ClusterIssuerKind is the kind of cluster-scoped cert-manager issuer
+	ClusterIssuerKind = "ClusterIssuer"
+	// CertificateConditionReady indicates that certificate is issued and up to date
+	CertificateConditionReady = "Ready"
+)
+
+// KeyUsage specifies valid usage contexts for keys
+type KeyUsage string
+
+const (
+	UsageDigitalSignature KeyUsage = "digital signature"
+	UsageKeyEncipherment  KeyUsage = "key encipherment"
+	UsageServerAuth       KeyUsage = "server auth"
+	UsageClientAuth       KeyUsage = "client auth"
+)
+
+// ObjectReference is a reference to cert-manager issuer
+type ObjectReference struct {
+	Name  string `json:"name"`
+	Kind  string `json:"kind,omitempty"`
+	Group string `json:"group,omitempty"`
+}
+
+// CertificateSecretTemplate defines labels and annotations copied to certificate secret
+type CertificateSecretTemplate struct {
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+}
+
+// CertificatePrivateKey defines private key options of certificate
+type CertificatePrivateKey struct {
+	RotationPolicy string `json:"rotationPolicy,omitempty"`
+	Encoding       string `json:"encoding,omitempty"`
+	Algorithm      string `json:"algorithm,omitempty"`
+	Size           int    `json:"size,omitempty"`
+}
+
+// CertificateSpec defines the desired state of Certificate
+type CertificateSpec struct {
+	CommonName     string                     `json:"commonName,omitempty"`
+	Duration       *metav1.Duration           `json:"duration,omitempty"`
+	RenewBefore    *metav1.Duration           `json:"renewBefore,omitempty"`
+	DNSNames       []string                   `json:"dnsNames,omitempty"`
+	IPAddresses    []string                   `json:"ipAddresses,omitempty"`
+	SecretName     string                     `json:"secretName"`
+	SecretTemplate *CertificateSecretTemplate `json:"secretTemplate,omitempty"`
+	IssuerRef      ObjectReference            `json:"issuerRef"`
+	IsCA           bool                       `json:"isCA,omitempty"`
+	Usages         []KeyUsage                 `json:"usages,omitempty"`
+	PrivateKey     *CertificatePrivateKey     `json:"privateKey,omitempty"`
+}
+
+// CertificateCondition contains condition information for Certificate
+type CertificateCondition struct {
+	Type               string                 `json:"type"`
+	Status             metav1.ConditionStatus `json:"status"`
+	LastTransitionTime *metav1.Time           `json:"lastTransitionTime,omitempty"`
+	Reason             string                 `json:"reason,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+}
+
+// CertificateStatus defines the observed state of Certificate
+type CertificateStatus struct {
+	Conditions  []CertificateCondition `json:"conditions,omitempty"`
+	NotBefore   *metav1.Time           `json:"notBefore,omitempty"`
+	NotAfter    *metav1.Time           `json:"notAfter,omitempty"`
+	RenewalTime *metav1.Time           `json:"renewalTime,omitempty"`
+	Revision    *int                   `json:"revision,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// Certificate is a cert-manager resource which describes certificate issued by referenced issuer
+type Certificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CertificateSpec   `json:"spec,omitempty"`
+	Status CertificateStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CertificateList contains a list of Certificate
+type CertificateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Certificate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Certificate{}, &CertificateList{})
+}
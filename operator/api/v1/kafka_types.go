@@ -17,6 +17,7 @@ package v1
 import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -24,51 +25,78 @@ import (
 
 // KafkaSpec defines the desired state of Kafka
 type KafkaSpec struct {
-	WaitForPodsReady        bool                    `json:"waitForPodsReady"`
-	PodsReadyTimeout        int                     `json:"podReadinessTimeout"`
-	Affinity                v1.Affinity             `json:"affinity,omitempty"`
-	Tolerations             []v1.Toleration         `json:"tolerations,omitempty"`
-	PriorityClassName       string                  `json:"priorityClassName,omitempty"`
-	DisableSecurity         *bool                   `json:"disableSecurity,omitempty"`
-	DockerImage             string                  `json:"dockerImage"`
-	HeapSize                int                     `json:"heapSize"`
-	Oauth                   OAuth                   `json:"oauth,omitempty"`
-	Ssl                     Ssl                     `json:"ssl,omitempty"`
-	Replicas                int                     `json:"replicas"`
-	Scaling                 Scaling                 `json:"scaling,omitempty"`
-	Resources               v1.ResourceRequirements `json:"resources,omitempty"`
-	SecretName              string                  `json:"secretName"`
-	SecurityContext         v1.PodSecurityContext   `json:"securityContext,omitempty"`
-	Storage                 Storage                 `json:"storage"`
-	GetRacksFromNodeLabels  *bool                   `json:"getRacksFromNodeLabels,omitempty"`
-	NodeLabelNameForRack    string                  `json:"nodeLabelNameForRack,omitempty"`
-	Racks                   []string                `json:"racks,omitempty"`
-	TerminationGracePeriod  *int64                  `json:"terminationGracePeriod"`
-	ZookeeperConnect        string                  `json:"zookeeperConnect,omitempty"`
-	ZookeeperEnableSsl      bool                    `json:"zookeeperEnableSsl,omitempty"`
-	ZookeeperSslSecretName  string                  `json:"zookeeperSslSecretName,omitempty"`
-	ZookeeperSetACL         *bool                   `json:"zookeeperSetACL,omitempty"`
-	ExternalHostNames       []string                `json:"externalHostNames,omitempty"`
-	ExternalPorts           []int                   `json:"externalPorts,omitempty"`
-	EnvironmentVariables    []string                `json:"environmentVariables,omitempty"`
-	RollbackTimeout         *int32                  `json:"rollbackTimeout,omitempty"`
-	HealthCheckTimeout      *int32                  `json:"healthCheckTimeout,omitempty"`
-	EnableAuditLogs         *bool                   `json:"enableAuditLogs,omitempty"`
-	TokenRolesPath          string                  `json:"tokenRolesPath,omitempty"`
-	EnableAuthorization     *bool                   `json:"enableAuthorization,omitempty"`
-	DiscoveryEnabled        bool                    `json:"consulDiscovery,omitempty"`
-	RegisteredServiceName   string                  `json:"registeredServiceName,omitempty"`
-	KafkaDiscoveryMeta      map[string]string       `json:"kafkaDiscoveryMeta,omitempty"`
-	KafkaDiscoveryTags      []string                `json:"kafkaDiscoveryTags,omitempty"`
-	ConsulAclEnabled        bool                    `json:"consulAclEnabled,omitempty"`
-	ConsulAuthMethod        string                  `json:"consulAuthMethod,omitempty"`
-	RollingUpdate           bool                    `json:"rollingUpdate,omitempty"`
-	CustomLabels            map[string]string       `json:"customLabels,omitempty"`
-	DefaultLabels           map[string]string       `json:"defaultLabels,omitempty"`
-	DebugContainer          bool                    `json:"debugContainer,omitempty"`
-	CCMetricReporterEnabled bool                    `json:"ccMetricReporterEnabled,omitempty"`
-	Kraft                   Kraft                   `json:"kraft,omitempty"`
-	MigrationController     MigrationController     `json:"migrationController,omitempty"`
+	WaitForPodsReady          bool                          `json:"waitForPodsReady"`
+	PodsReadyTimeout          int                           `json:"podReadinessTimeout"`
+	Affinity                  v1.Affinity                   `json:"affinity,omitempty"`
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	PodDisruptionBudget       PodDisruptionBudget           `json:"podDisruptionBudget,omitempty"`
+	Tolerations               []v1.Toleration               `json:"tolerations,omitempty"`
+	PriorityClassName         string                        `json:"priorityClassName,omitempty"`
+	DisableSecurity           *bool                         `json:"disableSecurity,omitempty"`
+	DockerImage               string                        `json:"dockerImage"`
+	HeapSize                  int                           `json:"heapSize"`
+	Oauth                     OAuth                         `json:"oauth,omitempty"`
+	Ssl                       Ssl                           `json:"ssl,omitempty"`
+	Replicas                  int                           `json:"replicas"`
+	Scaling                   Scaling                       `json:"scaling,omitempty"`
+	Resources                 v1.ResourceRequirements       `json:"resources,omitempty"`
+	SecretName                string                        `json:"secretName"`
+	SecurityContext           v1.PodSecurityContext         `json:"securityContext,omitempty"`
+	Storage                   Storage                       `json:"storage"`
+	GetRacksFromNodeLabels    *bool                         `json:"getRacksFromNodeLabels,omitempty"`
+	NodeLabelNameForRack      string                        `json:"nodeLabelNameForRack,omitempty"`
+	Racks                     []string                      `json:"racks,omitempty"`
+	TerminationGracePeriod    *int64                        `json:"terminationGracePeriod"`
+	ZookeeperConnect          string                        `json:"zookeeperConnect,omitempty"`
+	ZookeeperEnableSsl        bool                          `json:"zookeeperEnableSsl,omitempty"`
+	ZookeeperSslSecretName    string                        `json:"zookeeperSslSecretName,omitempty"`
+	ZookeeperSetACL           *bool                         `json:"zookeeperSetACL,omitempty"`
+	ExternalHostNames         []string                      `json:"externalHostNames,omitempty"`
+	ExternalPorts             []int                         `json:"externalPorts,omitempty"`
+	EnvironmentVariables      []string                      `json:"environmentVariables,omitempty"`
+	RollbackTimeout           *int32                        `json:"rollbackTimeout,omitempty"`
+	HealthCheckTimeout        *int32                        `json:"healthCheckTimeout,omitempty"`
+	EnableAuditLogs           *bool                         `json:"enableAuditLogs,omitempty"`
+	TokenRolesPath            string                        `json:"tokenRolesPath,omitempty"`
+	EnableAuthorization       *bool                         `json:"enableAuthorization,omitempty"`
+	DiscoveryEnabled          bool                          `json:"consulDiscovery,omitempty"`
+	RegisteredServiceName     string                        `json:"registeredServiceName,omitempty"`
+	KafkaDiscoveryMeta        map[string]string             `json:"kafkaDiscoveryMeta,omitempty"`
+	KafkaDiscoveryTags        []string                      `json:"kafkaDiscoveryTags,omitempty"`
+	ConsulAclEnabled          bool                          `json:"consulAclEnabled,omitempty"`
+	ConsulAuthMethod          string                        `json:"consulAuthMethod,omitempty"`
+	RollingUpdate             bool                          `json:"rollingUpdate,omitempty"`
+	CustomLabels              map[string]string             `json:"customLabels,omitempty"`
+	DefaultLabels             map[string]string             `json:"defaultLabels,omitempty"`
+	DebugContainer            bool                          `json:"debugContainer,omitempty"`
+	CCMetricReporterEnabled   bool                          `json:"ccMetricReporterEnabled,omitempty"`
+	Kraft                     Kraft                         `json:"kraft,omitempty"`
+	MigrationController       MigrationController           `json:"migrationController,omitempty"`
+	Controllers               Controllers                   `json:"controllers,omitempty"`
+	TieredStorage             TieredStorage                 `json:"tieredStorage,omitempty"`
+	ExternalAccess            ExternalAccess                `json:"externalAccess,omitempty"`
+	Listeners                 []Listener                    `json:"listeners,omitempty"`
+	// NodePools defines groups of brokers with their own resources, storage and placement.
+	// If node pools are specified, the sum of their replicas must be equal to Replicas.
+	NodePools []NodePool `json:"nodePools,omitempty"`
+	// PodManagement defines whether brokers are managed with one Deployment per broker or with one StatefulSet.
+	// Existing broker deployments are migrated to StatefulSet in place keeping their volumes and IDs.
+	// +kubebuilder:validation:Enum=deployment;statefulset
+	PodManagement string `json:"podManagement,omitempty"`
+	// Config defines Kafka broker properties. Dynamic properties are applied to running brokers without restart,
+	// other properties are passed to brokers on restart.
+	Config map[string]string `json:"config,omitempty"`
+	// MaintenanceWindows defines periods when disruptive operations (rolling restarts, partitions reassignment,
+	// scaling in and Kraft migration) are allowed. If windows are not specified, such operations start immediately.
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+}
+
+// MaintenanceWindow defines a period which starts at times matched by cron Schedule in UTC and lasts for Duration
+type MaintenanceWindow struct {
+	// Schedule is a cron expression with five fields, for example, "0 2 * * 6"
+	Schedule string `json:"schedule"`
+	// Duration is a duration of the window, for example, "2h"
+	Duration string `json:"duration"`
 }
 
 // Kraft defines Kafka parameters for Kraft
@@ -80,20 +108,114 @@ type Kraft struct {
 
 // MigrationController defines Kafka parameters for Kraft
 type MigrationController struct {
+	Affinity            v1.Affinity             `json:"affinity,omitempty"`
+	PodDisruptionBudget PodDisruptionBudget     `json:"podDisruptionBudget,omitempty"`
+	Tolerations         []v1.Toleration         `json:"tolerations,omitempty"`
+	PriorityClassName   string                  `json:"priorityClassName,omitempty"`
+	HeapSize            int                     `json:"heapSize"`
+	Resources           v1.ResourceRequirements `json:"resources,omitempty"`
+	Storage             Storage                 `json:"storage"`
+}
+
+// Controllers defines parameters of dedicated Kraft controller quorum
+type Controllers struct {
+	Replicas          int                     `json:"replicas,omitempty"`
+	DynamicQuorum     bool                    `json:"dynamicQuorum,omitempty"`
 	Affinity          v1.Affinity             `json:"affinity,omitempty"`
 	Tolerations       []v1.Toleration         `json:"tolerations,omitempty"`
 	PriorityClassName string                  `json:"priorityClassName,omitempty"`
-	HeapSize          int                     `json:"heapSize"`
+	HeapSize          int                     `json:"heapSize,omitempty"`
 	Resources         v1.ResourceRequirements `json:"resources,omitempty"`
-	Storage           Storage                 `json:"storage"`
+	Storage           Storage                 `json:"storage,omitempty"`
+}
+
+// NodePool defines group of Kafka brokers with own resources, storage and placement.
+// Brokers of the pool have IDs from FirstBrokerId to FirstBrokerId+Replicas-1, so IDs of brokers do not change
+// when other pools are scaled. Unspecified parameters are taken from Kafka specification.
+type NodePool struct {
+	Name          string                  `json:"name"`
+	Replicas      int                     `json:"replicas"`
+	FirstBrokerId int                     `json:"firstBrokerId"`
+	HeapSize      int                     `json:"heapSize,omitempty"`
+	Resources     v1.ResourceRequirements `json:"resources,omitempty"`
+	Storage       *Storage                `json:"storage,omitempty"`
+	Affinity      *v1.Affinity            `json:"affinity,omitempty"`
+	Racks         []string                `json:"racks,omitempty"`
+}
+
+// PodDisruptionBudget defines PodDisruptionBudget created by operator for pods of component
+type PodDisruptionBudget struct {
+	// Enabled defines whether PodDisruptionBudget is created, true by default
+	Enabled *bool `json:"enabled,omitempty"`
+	// MaxUnavailable is the maximum number of unavailable pods during voluntary disruptions, 1 by default
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// MinAvailable is the minimum number of available pods, it cannot be specified together with MaxUnavailable
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
 }
 
 // Scaling defines Kafka parameters for scaling out
 type Scaling struct {
-	ReassignPartitions              *bool `json:"reassignPartitions,omitempty"`
-	BrokerDeploymentScaleInEnabled  *bool `json:"brokerDeploymentScaleInEnabled,omitempty"`
-	AllBrokersStartTimeoutSeconds   *int  `json:"allBrokersStartTimeoutSeconds,omitempty"`
-	TopicReassignmentTimeoutSeconds *int  `json:"topicReassignmentTimeoutSeconds,omitempty"`
+	ReassignPartitions             *bool `json:"reassignPartitions,omitempty"`
+	BrokerDeploymentScaleInEnabled *bool `json:"brokerDeploymentScaleInEnabled,omitempty"`
+	AllBrokersStartTimeoutSeconds  *int  `json:"allBrokersStartTimeoutSeconds,omitempty"`
+	// TopicReassignmentTimeoutSeconds is the time to wait for each moved partition, partitions which are not moved
+	// in time are reported and awaited until Kafka finishes them or reassignment is cancelled
+	TopicReassignmentTimeoutSeconds *int `json:"topicReassignmentTimeoutSeconds,omitempty"`
+	// MaxConcurrentPartitionMoves limits the number of partitions moved at the same time across all topics
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentPartitionMoves *int `json:"maxConcurrentPartitionMoves,omitempty"`
+	// MaxBytesInFlight limits the size of data copied to new replicas of partitions moved at the same time,
+	// 0 means that the size is not limited. A partition exceeding the limit is moved alone.
+	// +kubebuilder:validation:Minimum=0
+	MaxBytesInFlight *int64 `json:"maxBytesInFlight,omitempty"`
+	// ReplicationThrottleBytesPerSec limits the rate of replication between brokers during partitions reassignment
+	// +kubebuilder:validation:Minimum=0
+	ReplicationThrottleBytesPerSec *int64 `json:"replicationThrottleBytesPerSec,omitempty"`
+	// Strategy defines how partitions are balanced between brokers: "leader-skew" balances the number of leaders,
+	// "replica-count" balances the number of replicas, "rack-strict" spreads replicas of each partition across racks
+	// and balances the number of replicas, "disk-weighted" balances replicas weighted by their size on disk
+	// +kubebuilder:validation:Enum=leader-skew;replica-count;rack-strict;disk-weighted
+	Strategy string `json:"strategy,omitempty"`
+	// MaxBrokerDiskUsagePercent is the share of broker storage which must not be exceeded by "disk-weighted" balancing
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	MaxBrokerDiskUsagePercent *int `json:"maxBrokerDiskUsagePercent,omitempty"`
+	// DryRun computes partitions reassignment and stores it as a proposal in config map without moving replicas
+	DryRun bool `json:"dryRun,omitempty"`
+	// LeaderBalancing defines periodic preferred replica elections for partitions whose leadership drifted
+	LeaderBalancing LeaderBalancing `json:"leaderBalancing,omitempty"`
+	// RackAudit defines periodic checks of placement of partition replicas across racks
+	RackAudit RackAudit `json:"rackAudit,omitempty"`
+}
+
+// LeaderBalancing defines periodic checks of leader skew of brokers which elect preferred replicas as leaders
+// of partitions when the skew exceeds the threshold. Checks are performed only during maintenance windows.
+type LeaderBalancing struct {
+	Enabled bool `json:"enabled"`
+	// IntervalSeconds is the period of leader skew checks, 300 seconds by default
+	// +kubebuilder:validation:Minimum=30
+	IntervalSeconds int `json:"intervalSeconds,omitempty"`
+	// SkewThresholdPercent is the deviation of leaders number of broker from the average which triggers elections,
+	// 10 percent by default
+	// +kubebuilder:validation:Minimum=0
+	SkewThresholdPercent *int `json:"skewThresholdPercent,omitempty"`
+	// MaxElectionsPerCheck limits the number of partitions whose leaders or replicas order are changed by one check,
+	// 100 by default
+	// +kubebuilder:validation:Minimum=1
+	MaxElectionsPerCheck int `json:"maxElectionsPerCheck,omitempty"`
+	// ReorderReplicas changes the order of replicas without moving them, so preferred leaders are evenly distributed
+	ReorderReplicas bool `json:"reorderReplicas,omitempty"`
+}
+
+// RackAudit defines periodic checks of partitions whose replicas share a rack while other racks of the node pool
+// have no replicas of the partition. Checks are performed only if racks of brokers are configured.
+type RackAudit struct {
+	Enabled bool `json:"enabled"`
+	// IntervalSeconds is the period of rack checks, 3600 seconds by default
+	// +kubebuilder:validation:Minimum=60
+	IntervalSeconds int `json:"intervalSeconds,omitempty"`
+	// Repair moves replicas sharing a rack to other racks with partitions reassignment during maintenance windows
+	Repair bool `json:"repair,omitempty"`
 }
 
 // OAuth defines OAuth Kafka settings
@@ -107,40 +229,258 @@ type OAuth struct {
 
 // Ssl defines Ssl Kafka settings
 type Ssl struct {
-	Enabled                 bool     `json:"enabled"`
-	SecretName              string   `json:"secretName,omitempty"`
-	CipherSuites            []string `json:"cipherSuites,omitempty"`
-	EnableTwoWaySsl         bool     `json:"enableTwoWaySsl,omitempty"`
-	AllowNonencryptedAccess bool     `json:"allowNonencryptedAccess,omitempty"`
+	Enabled                 bool                 `json:"enabled"`
+	SecretName              string               `json:"secretName,omitempty"`
+	CipherSuites            []string             `json:"cipherSuites,omitempty"`
+	EnableTwoWaySsl         bool                 `json:"enableTwoWaySsl,omitempty"`
+	AllowNonencryptedAccess bool                 `json:"allowNonencryptedAccess,omitempty"`
+	CertManager             CertManager          `json:"certManager,omitempty"`
+	CertificateAuthority    CertificateAuthority `json:"certificateAuthority,omitempty"`
+	// SubjectAlternativeName defines additional names of certificates issued for brokers by cert-manager or operator
+	SubjectAlternativeName SubjectAlternativeName `json:"subjectAlternativeName,omitempty"`
+}
+
+// SubjectAlternativeName defines additional DNS names and IP addresses of broker certificates
+type SubjectAlternativeName struct {
+	AdditionalDnsNames    []string `json:"additionalDnsNames,omitempty"`
+	AdditionalIpAddresses []string `json:"additionalIpAddresses,omitempty"`
+}
+
+// CertificateAuthority defines cluster and clients certificate authorities managed by operator
+// to issue certificates of brokers without cert-manager
+type CertificateAuthority struct {
+	Enabled bool `json:"enabled"`
+	// CaValidityDays is the validity period of CA certificates, 1825 days by default
+	CaValidityDays int `json:"caValidityDays,omitempty"`
+	// ValidityDays is the validity period of broker and client certificates, 365 days by default
+	ValidityDays int `json:"validityDays,omitempty"`
+	// RenewalDays is the number of days before expiry when certificates are renewed, 30 days by default
+	RenewalDays int `json:"renewalDays,omitempty"`
+}
+
+// CertManager defines certificates of Kafka brokers issued by cert-manager on behalf of operator
+type CertManager struct {
+	Enabled   bool                 `json:"enabled"`
+	IssuerRef CertManagerIssuerRef `json:"issuerRef,omitempty"`
+	// Duration is the validity period of certificates in Go duration format, for example, 8760h
+	Duration string `json:"duration,omitempty"`
+	// RenewBefore is the period before expiry when cert-manager renews certificates
+	RenewBefore string `json:"renewBefore,omitempty"`
+}
+
+// CertManagerIssuerRef is a reference to cert-manager issuer of broker certificates
+type CertManagerIssuerRef struct {
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	Kind  string `json:"kind,omitempty"`
+	Group string `json:"group,omitempty"`
 }
 
 // Storage defines volumes of Kafka
 type Storage struct {
-	ClassName []string `json:"className,omitempty"`
-	Size      string   `json:"size"`
-	Volumes   []string `json:"volumes,omitempty"`
-	Nodes     []string `json:"nodes,omitempty"`
-	Labels    []string `json:"labels,omitempty"`
+	ClassName   []string     `json:"className,omitempty"`
+	Size        string       `json:"size"`
+	Volumes     []string     `json:"volumes,omitempty"`
+	Nodes       []string     `json:"nodes,omitempty"`
+	Labels      []string     `json:"labels,omitempty"`
+	DataVolumes []DataVolume `json:"dataVolumes,omitempty"`
+}
+
+// DataVolume describes additional persistent volume of each Kafka broker used as separate log directory (JBOD)
+type DataVolume struct {
+	Name      string `json:"name"`
+	Size      string `json:"size"`
+	ClassName string `json:"className,omitempty"`
+}
+
+// TieredStorage defines parameters of Kafka tiered storage which offloads closed log segments to remote object storage
+type TieredStorage struct {
+	Enabled                       bool              `json:"enabled,omitempty"`
+	RemoteStorageManagerClassName string            `json:"remoteStorageManagerClassName,omitempty"`
+	RemoteStorageManagerClassPath string            `json:"remoteStorageManagerClassPath,omitempty"`
+	Bucket                        string            `json:"bucket,omitempty"`
+	Region                        string            `json:"region,omitempty"`
+	Endpoint                      string            `json:"endpoint,omitempty"`
+	SecretName                    string            `json:"secretName,omitempty"`
+	LocalRetentionMs              *int64            `json:"localRetentionMs,omitempty"`
+	LocalRetentionBytes           *int64            `json:"localRetentionBytes,omitempty"`
+	Config                        map[string]string `json:"config,omitempty"`
+}
+
+// ExternalAccess defines how Kafka brokers are exposed to clients outside of Kubernetes cluster
+type ExternalAccess struct {
+	// +kubebuilder:validation:Enum=loadbalancer;nodeport;ingress
+	Type                  string            `json:"type,omitempty"`
+	Host                  string            `json:"host,omitempty"`
+	IngressClassName      string            `json:"ingressClassName,omitempty"`
+	ExternalTrafficPolicy string            `json:"externalTrafficPolicy,omitempty"`
+	Annotations           map[string]string `json:"annotations,omitempty"`
+}
+
+// Listener defines client listener of Kafka brokers
+type Listener struct {
+	Name string `json:"name"`
+	Port int32  `json:"port"`
+	TLS  bool   `json:"tls,omitempty"`
+	// +kubebuilder:validation:Enum=scram;mtls;oauth;none
+	Authentication string `json:"authentication,omitempty"`
 }
 
 type KafkaBrokerStatus struct {
-	Brokers []string `json:"brokers,omitempty"`
+	Brokers   []string         `json:"brokers,omitempty"`
+	NodePools []NodePoolStatus `json:"nodePools,omitempty"`
+}
+
+// NodePoolStatus shows IDs of brokers which belong to node pool
+type NodePoolStatus struct {
+	Name      string `json:"name"`
+	BrokerIds []int  `json:"brokerIds,omitempty"`
 }
 
 type PartitionsReassignmentStatus struct {
 	Status string `json:"status,omitempty"`
+	// Brokers contains distribution of partitions between brokers after the last reassignment
+	Brokers []BrokerBalanceStatus `json:"brokers,omitempty"`
+	// Proposal summarizes the last partitions reassignment computed in dry-run mode
+	Proposal *ReassignmentProposalStatus `json:"proposal,omitempty"`
+	// Progress describes topics of the current or the last partitions reassignment
+	Progress *ReassignmentProgressStatus `json:"progress,omitempty"`
+}
+
+// ReassignmentProgressStatus counts topics of partitions reassignment by result. Failed topics include topics
+// skipped because their assignment was changed after planning, remaining topics are pending or being reassigned.
+type ReassignmentProgressStatus struct {
+	CompletedTopics int      `json:"completedTopics"`
+	FailedTopics    int      `json:"failedTopics"`
+	RemainingTopics int      `json:"remainingTopics"`
+	CancelledTopics []string `json:"cancelledTopics,omitempty"`
+	// TimedOutPartitions are partitions in format "topic-partition" which are not moved in time and are still being moved
+	TimedOutPartitions []string `json:"timedOutPartitions,omitempty"`
+}
+
+// ReassignmentProposalStatus summarizes partitions reassignment proposal, the full proposal is stored in config map
+type ReassignmentProposalStatus struct {
+	ConfigMapName string `json:"configMapName"`
+	Topics        int    `json:"topics"`
+	Partitions    int    `json:"partitions"`
+	BytesToMove   int64  `json:"bytesToMove"`
+	// MaxSkewBefore and MaxSkewAfter are the maximum absolute skews of brokers before and after reassignment in percent
+	MaxSkewBefore int32 `json:"maxSkewBefore"`
+	MaxSkewAfter  int32 `json:"maxSkewAfter"`
+}
+
+// BrokerBalanceStatus describes partitions and disk usage of broker
+type BrokerBalanceStatus struct {
+	BrokerId       int32  `json:"brokerId"`
+	Pool           string `json:"pool,omitempty"`
+	Replicas       int    `json:"replicas"`
+	Leaders        int    `json:"leaders"`
+	DiskUsageBytes int64  `json:"diskUsageBytes"`
+	// Skew is deviation of broker load from the average load of brokers in the same pool in percent,
+	// load is measured by the balancing strategy
+	Skew int32 `json:"skew"`
+}
+
+// LeaderBalancingStatus describes the last check of leader skew of brokers
+type LeaderBalancingStatus struct {
+	LastCheckTime string `json:"lastCheckTime,omitempty"`
+	// MaxLeaderSkew is the maximum absolute deviation of leaders number of broker from the average in percent
+	MaxLeaderSkew       int32 `json:"maxLeaderSkew"`
+	ElectedPartitions   int   `json:"electedPartitions"`
+	ReorderedPartitions int   `json:"reorderedPartitions"`
+}
+
+// RackAuditStatus describes the last check of placement of partition replicas across racks
+type RackAuditStatus struct {
+	LastCheckTime string `json:"lastCheckTime,omitempty"`
+	// ViolatingPartitions is the number of partitions whose replicas are not spread across available racks
+	ViolatingPartitions int `json:"violatingPartitions"`
+	// RepairedPartitions is the number of partitions planned to be moved to other racks by the last check
+	RepairedPartitions int `json:"repairedPartitions"`
 }
 
 type KraftMigrationStatus struct {
 	Status string `json:"status,omitempty"`
 }
 
+type KraftQuorumStatus struct {
+	Voters        []string            `json:"voters,omitempty"`
+	Controllers   []string            `json:"controllers,omitempty"`
+	LeaderId      int                 `json:"leaderId,omitempty"`
+	HighWatermark int64               `json:"highWatermark,omitempty"`
+	Members       []KraftQuorumMember `json:"members,omitempty"`
+}
+
+// KraftQuorumMember describes replication state of Kraft quorum voter
+type KraftQuorumMember struct {
+	NodeId       int    `json:"nodeId"`
+	DirectoryId  string `json:"directoryId,omitempty"`
+	LogEndOffset int64  `json:"logEndOffset"`
+	Lag          int64  `json:"lag"`
+	Status       string `json:"status,omitempty"`
+}
+
+// KafkaStorageStatus describes capacity of brokers persistent volumes
+type KafkaStorageStatus struct {
+	Brokers []BrokerStorageStatus `json:"brokers,omitempty"`
+}
+
+// BrokerStorageStatus describes requested and actual capacity of broker persistent volume claim
+type BrokerStorageStatus struct {
+	Broker                string `json:"broker"`
+	PersistentVolumeClaim string `json:"persistentVolumeClaim"`
+	Requested             string `json:"requested,omitempty"`
+	Capacity              string `json:"capacity,omitempty"`
+	Status                string `json:"status,omitempty"`
+}
+
+// ExternalAccessStatus describes addresses assigned to brokers for external access
+type ExternalAccessStatus struct {
+	Type      string                  `json:"type,omitempty"`
+	Bootstrap string                  `json:"bootstrap,omitempty"`
+	Brokers   []BrokerExternalAddress `json:"brokers,omitempty"`
+}
+
+// CertificatesStatus describes expiry dates of certificate authorities and certificates issued by operator
+type CertificatesStatus struct {
+	ClusterCaNotAfter string              `json:"clusterCaNotAfter,omitempty"`
+	ClientsCaNotAfter string              `json:"clientsCaNotAfter,omitempty"`
+	Certificates      []CertificateExpiry `json:"certificates,omitempty"`
+}
+
+// CertificateExpiry describes expiry date of certificate stored in secret
+type CertificateExpiry struct {
+	SecretName string `json:"secretName"`
+	NotAfter   string `json:"notAfter"`
+}
+
+// BrokerConfigStatus describes properties of Kafka.Spec.Config applied to brokers dynamically
+// and static properties which are not applied until brokers restart
+type BrokerConfigStatus struct {
+	Applied        []string `json:"applied,omitempty"`
+	PendingRestart []string `json:"pendingRestart,omitempty"`
+}
+
+// BrokerExternalAddress describes address advertised by broker for external clients
+type BrokerExternalAddress struct {
+	BrokerId int    `json:"brokerId"`
+	Host     string `json:"host,omitempty"`
+	Port     int32  `json:"port,omitempty"`
+}
+
 // KafkaStatus defines the observed state of Kafka
 type KafkaStatus struct {
 	KafkaBrokerStatus            KafkaBrokerStatus            `json:"kafkaBrokerStatus,omitempty"`
 	PartitionsReassignmentStatus PartitionsReassignmentStatus `json:"partitionsReassignmentStatus,omitempty"`
 	Conditions                   []StatusCondition            `json:"conditions,omitempty"`
 	KraftMigrationStatus         KraftMigrationStatus         `json:"kraftMigrationStatus,omitempty"`
+	KraftQuorumStatus            KraftQuorumStatus            `json:"kraftQuorumStatus,omitempty"`
+	StorageStatus                KafkaStorageStatus           `json:"storageStatus,omitempty"`
+	ExternalAccessStatus         ExternalAccessStatus         `json:"externalAccessStatus,omitempty"`
+	CertificatesStatus           CertificatesStatus           `json:"certificatesStatus,omitempty"`
+	ConfigStatus                 BrokerConfigStatus           `json:"configStatus,omitempty"`
+	LeaderBalancingStatus        LeaderBalancingStatus        `json:"leaderBalancingStatus,omitempty"`
+	RackAuditStatus              RackAuditStatus              `json:"rackAuditStatus,omitempty"`
 }
 
 //+kubebuilder:object:root=true
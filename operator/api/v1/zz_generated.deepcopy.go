@@ -23,6 +23,7 @@ import (
 	"github.com/Netcracker/qubership-kafka/operator/api/kmm"
 	corev1 "k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -197,6 +198,157 @@ func (in *AuthorizationStatus) DeepCopy() *AuthorizationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerBalanceStatus) DeepCopyInto(out *BrokerBalanceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerBalanceStatus.
+func (in *BrokerBalanceStatus) DeepCopy() *BrokerBalanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerBalanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerConfigStatus) DeepCopyInto(out *BrokerConfigStatus) {
+	*out = *in
+	if in.Applied != nil {
+		in, out := &in.Applied, &out.Applied
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingRestart != nil {
+		in, out := &in.PendingRestart, &out.PendingRestart
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerConfigStatus.
+func (in *BrokerConfigStatus) DeepCopy() *BrokerConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerExternalAddress) DeepCopyInto(out *BrokerExternalAddress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerExternalAddress.
+func (in *BrokerExternalAddress) DeepCopy() *BrokerExternalAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerExternalAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerStorageStatus) DeepCopyInto(out *BrokerStorageStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerStorageStatus.
+func (in *BrokerStorageStatus) DeepCopy() *BrokerStorageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerStorageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManager) DeepCopyInto(out *CertManager) {
+	*out = *in
+	out.IssuerRef = in.IssuerRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManager.
+func (in *CertManager) DeepCopy() *CertManager {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuerRef) DeepCopyInto(out *CertManagerIssuerRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerRef.
+func (in *CertManagerIssuerRef) DeepCopy() *CertManagerIssuerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateAuthority) DeepCopyInto(out *CertificateAuthority) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateAuthority.
+func (in *CertificateAuthority) DeepCopy() *CertificateAuthority {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateAuthority)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateExpiry) DeepCopyInto(out *CertificateExpiry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateExpiry.
+func (in *CertificateExpiry) DeepCopy() *CertificateExpiry {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateExpiry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificatesStatus) DeepCopyInto(out *CertificatesStatus) {
+	*out = *in
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]CertificateExpiry, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesStatus.
+func (in *CertificatesStatus) DeepCopy() *CertificatesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificatesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Config) DeepCopyInto(out *Config) {
 	*out = *in
@@ -212,6 +364,88 @@ func (in *Config) DeepCopy() *Config {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Controllers) DeepCopyInto(out *Controllers) {
+	*out = *in
+	in.Affinity.DeepCopyInto(&out.Affinity)
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	in.Storage.DeepCopyInto(&out.Storage)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Controllers.
+func (in *Controllers) DeepCopy() *Controllers {
+	if in == nil {
+		return nil
+	}
+	out := new(Controllers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolume) DeepCopyInto(out *DataVolume) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolume.
+func (in *DataVolume) DeepCopy() *DataVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(DataVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalAccess) DeepCopyInto(out *ExternalAccess) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalAccess.
+func (in *ExternalAccess) DeepCopy() *ExternalAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalAccessStatus) DeepCopyInto(out *ExternalAccessStatus) {
+	*out = *in
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]BrokerExternalAddress, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalAccessStatus.
+func (in *ExternalAccessStatus) DeepCopy() *ExternalAccessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalAccessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Kafka) DeepCopyInto(out *Kafka) {
 	*out = *in
@@ -247,6 +481,13 @@ func (in *KafkaBrokerStatus) DeepCopyInto(out *KafkaBrokerStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.NodePools != nil {
+		in, out := &in.NodePools, &out.NodePools
+		*out = make([]NodePoolStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaBrokerStatus.
@@ -295,6 +536,14 @@ func (in *KafkaList) DeepCopyObject() runtime.Object {
 func (in *KafkaSpec) DeepCopyInto(out *KafkaSpec) {
 	*out = *in
 	in.Affinity.DeepCopyInto(&out.Affinity)
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.PodDisruptionBudget.DeepCopyInto(&out.PodDisruptionBudget)
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
 		*out = make([]corev1.Toleration, len(*in))
@@ -368,6 +617,18 @@ func (in *KafkaSpec) DeepCopyInto(out *KafkaSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.KafkaDiscoveryMeta != nil {
+		in, out := &in.KafkaDiscoveryMeta, &out.KafkaDiscoveryMeta
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KafkaDiscoveryTags != nil {
+		in, out := &in.KafkaDiscoveryTags, &out.KafkaDiscoveryTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.CustomLabels != nil {
 		in, out := &in.CustomLabels, &out.CustomLabels
 		*out = make(map[string]string, len(*in))
@@ -384,6 +645,33 @@ func (in *KafkaSpec) DeepCopyInto(out *KafkaSpec) {
 	}
 	out.Kraft = in.Kraft
 	in.MigrationController.DeepCopyInto(&out.MigrationController)
+	in.Controllers.DeepCopyInto(&out.Controllers)
+	in.TieredStorage.DeepCopyInto(&out.TieredStorage)
+	in.ExternalAccess.DeepCopyInto(&out.ExternalAccess)
+	if in.Listeners != nil {
+		in, out := &in.Listeners, &out.Listeners
+		*out = make([]Listener, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodePools != nil {
+		in, out := &in.NodePools, &out.NodePools
+		*out = make([]NodePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaSpec.
@@ -400,13 +688,20 @@ func (in *KafkaSpec) DeepCopy() *KafkaSpec {
 func (in *KafkaStatus) DeepCopyInto(out *KafkaStatus) {
 	*out = *in
 	in.KafkaBrokerStatus.DeepCopyInto(&out.KafkaBrokerStatus)
-	out.PartitionsReassignmentStatus = in.PartitionsReassignmentStatus
+	in.PartitionsReassignmentStatus.DeepCopyInto(&out.PartitionsReassignmentStatus)
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]StatusCondition, len(*in))
 		copy(*out, *in)
 	}
 	out.KraftMigrationStatus = in.KraftMigrationStatus
+	in.KraftQuorumStatus.DeepCopyInto(&out.KraftQuorumStatus)
+	in.StorageStatus.DeepCopyInto(&out.StorageStatus)
+	in.ExternalAccessStatus.DeepCopyInto(&out.ExternalAccessStatus)
+	in.CertificatesStatus.DeepCopyInto(&out.CertificatesStatus)
+	in.ConfigStatus.DeepCopyInto(&out.ConfigStatus)
+	out.LeaderBalancingStatus = in.LeaderBalancingStatus
+	out.RackAuditStatus = in.RackAuditStatus
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaStatus.
@@ -419,6 +714,26 @@ func (in *KafkaStatus) DeepCopy() *KafkaStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KafkaStorageStatus) DeepCopyInto(out *KafkaStorageStatus) {
+	*out = *in
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]BrokerStorageStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaStorageStatus.
+func (in *KafkaStorageStatus) DeepCopy() *KafkaStorageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KafkaStorageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaUser) DeepCopyInto(out *KafkaUser) {
 	*out = *in
@@ -661,10 +976,121 @@ func (in *KraftMigrationStatus) DeepCopy() *KraftMigrationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KraftQuorumMember) DeepCopyInto(out *KraftQuorumMember) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KraftQuorumMember.
+func (in *KraftQuorumMember) DeepCopy() *KraftQuorumMember {
+	if in == nil {
+		return nil
+	}
+	out := new(KraftQuorumMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KraftQuorumStatus) DeepCopyInto(out *KraftQuorumStatus) {
+	*out = *in
+	if in.Voters != nil {
+		in, out := &in.Voters, &out.Voters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]KraftQuorumMember, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KraftQuorumStatus.
+func (in *KraftQuorumStatus) DeepCopy() *KraftQuorumStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KraftQuorumStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeaderBalancing) DeepCopyInto(out *LeaderBalancing) {
+	*out = *in
+	if in.SkewThresholdPercent != nil {
+		in, out := &in.SkewThresholdPercent, &out.SkewThresholdPercent
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderBalancing.
+func (in *LeaderBalancing) DeepCopy() *LeaderBalancing {
+	if in == nil {
+		return nil
+	}
+	out := new(LeaderBalancing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeaderBalancingStatus) DeepCopyInto(out *LeaderBalancingStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderBalancingStatus.
+func (in *LeaderBalancingStatus) DeepCopy() *LeaderBalancingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LeaderBalancingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Listener) DeepCopyInto(out *Listener) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Listener.
+func (in *Listener) DeepCopy() *Listener {
+	if in == nil {
+		return nil
+	}
+	out := new(Listener)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MigrationController) DeepCopyInto(out *MigrationController) {
 	*out = *in
 	in.Affinity.DeepCopyInto(&out.Affinity)
+	in.PodDisruptionBudget.DeepCopyInto(&out.PodDisruptionBudget)
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
 		*out = make([]corev1.Toleration, len(*in))
@@ -686,6 +1112,57 @@ func (in *MigrationController) DeepCopy() *MigrationController {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePool) DeepCopyInto(out *NodePool) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(Storage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Racks != nil {
+		in, out := &in.Racks, &out.Racks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePool.
+func (in *NodePool) DeepCopy() *NodePool {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolStatus) DeepCopyInto(out *NodePoolStatus) {
+	*out = *in
+	if in.BrokerIds != nil {
+		in, out := &in.BrokerIds, &out.BrokerIds
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolStatus.
+func (in *NodePoolStatus) DeepCopy() *NodePoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OAuth) DeepCopyInto(out *OAuth) {
 	*out = *in
@@ -729,6 +1206,21 @@ func (in *OAuth) DeepCopy() *OAuth {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PartitionsReassignmentStatus) DeepCopyInto(out *PartitionsReassignmentStatus) {
 	*out = *in
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]BrokerBalanceStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Proposal != nil {
+		in, out := &in.Proposal, &out.Proposal
+		*out = new(ReassignmentProposalStatus)
+		**out = **in
+	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(ReassignmentProgressStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionsReassignmentStatus.
@@ -741,6 +1233,106 @@ func (in *PartitionsReassignmentStatus) DeepCopy() *PartitionsReassignmentStatus
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudget) DeepCopyInto(out *PodDisruptionBudget) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudget.
+func (in *PodDisruptionBudget) DeepCopy() *PodDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RackAudit) DeepCopyInto(out *RackAudit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RackAudit.
+func (in *RackAudit) DeepCopy() *RackAudit {
+	if in == nil {
+		return nil
+	}
+	out := new(RackAudit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RackAuditStatus) DeepCopyInto(out *RackAuditStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RackAuditStatus.
+func (in *RackAuditStatus) DeepCopy() *RackAuditStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RackAuditStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReassignmentProgressStatus) DeepCopyInto(out *ReassignmentProgressStatus) {
+	*out = *in
+	if in.CancelledTopics != nil {
+		in, out := &in.CancelledTopics, &out.CancelledTopics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimedOutPartitions != nil {
+		in, out := &in.TimedOutPartitions, &out.TimedOutPartitions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReassignmentProgressStatus.
+func (in *ReassignmentProgressStatus) DeepCopy() *ReassignmentProgressStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReassignmentProgressStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReassignmentProposalStatus) DeepCopyInto(out *ReassignmentProposalStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReassignmentProposalStatus.
+func (in *ReassignmentProposalStatus) DeepCopy() *ReassignmentProposalStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReassignmentProposalStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Scaling) DeepCopyInto(out *Scaling) {
 	*out = *in
@@ -764,6 +1356,28 @@ func (in *Scaling) DeepCopyInto(out *Scaling) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.MaxConcurrentPartitionMoves != nil {
+		in, out := &in.MaxConcurrentPartitionMoves, &out.MaxConcurrentPartitionMoves
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxBytesInFlight != nil {
+		in, out := &in.MaxBytesInFlight, &out.MaxBytesInFlight
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ReplicationThrottleBytesPerSec != nil {
+		in, out := &in.ReplicationThrottleBytesPerSec, &out.ReplicationThrottleBytesPerSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxBrokerDiskUsagePercent != nil {
+		in, out := &in.MaxBrokerDiskUsagePercent, &out.MaxBrokerDiskUsagePercent
+		*out = new(int)
+		**out = **in
+	}
+	in.LeaderBalancing.DeepCopyInto(&out.LeaderBalancing)
+	out.RackAudit = in.RackAudit
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Scaling.
@@ -814,6 +1428,9 @@ func (in *Ssl) DeepCopyInto(out *Ssl) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	out.CertManager = in.CertManager
+	out.CertificateAuthority = in.CertificateAuthority
+	in.SubjectAlternativeName.DeepCopyInto(&out.SubjectAlternativeName)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ssl.
@@ -864,6 +1481,11 @@ func (in *Storage) DeepCopyInto(out *Storage) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DataVolumes != nil {
+		in, out := &in.DataVolumes, &out.DataVolumes
+		*out = make([]DataVolume, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Storage.
@@ -875,3 +1497,60 @@ func (in *Storage) DeepCopy() *Storage {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubjectAlternativeName) DeepCopyInto(out *SubjectAlternativeName) {
+	*out = *in
+	if in.AdditionalDnsNames != nil {
+		in, out := &in.AdditionalDnsNames, &out.AdditionalDnsNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalIpAddresses != nil {
+		in, out := &in.AdditionalIpAddresses, &out.AdditionalIpAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectAlternativeName.
+func (in *SubjectAlternativeName) DeepCopy() *SubjectAlternativeName {
+	if in == nil {
+		return nil
+	}
+	out := new(SubjectAlternativeName)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TieredStorage) DeepCopyInto(out *TieredStorage) {
+	*out = *in
+	if in.LocalRetentionMs != nil {
+		in, out := &in.LocalRetentionMs, &out.LocalRetentionMs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LocalRetentionBytes != nil {
+		in, out := &in.LocalRetentionBytes, &out.LocalRetentionBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TieredStorage.
+func (in *TieredStorage) DeepCopy() *TieredStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(TieredStorage)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/Netcracker/qubership-kafka/operator/api/kmm"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // Global shows configuration of parameters that are used by all services
@@ -29,6 +30,17 @@ type Global struct {
 	KafkaSaslMechanism string            `json:"kafkaSaslMechanism,omitempty"`
 	KafkaSsl           KafkaSsl          `json:"kafkaSsl,omitempty"`
 	Kraft              Kraft             `json:"kraft,omitempty"`
+	// MaintenanceWindows defines periods when disruptive changes of Mirror Maker are applied.
+	// If windows are not specified, such changes are applied immediately.
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+}
+
+// MaintenanceWindow defines a period which starts at times matched by cron Schedule in UTC and lasts for Duration
+type MaintenanceWindow struct {
+	// Schedule is a cron expression with five fields, for example, "0 2 * * 6"
+	Schedule string `json:"schedule"`
+	// Duration is a duration of the window, for example, "2h"
+	Duration string `json:"duration"`
 }
 
 // Kraft defines Kafka parameters for Kraft
@@ -97,75 +109,81 @@ type Kafka struct {
 
 // Akhq shows AKHQ configuration
 type Akhq struct {
-	DockerImage          string                  `json:"dockerImage"`
-	Affinity             v1.Affinity             `json:"affinity,omitempty"`
-	Tolerations          []v1.Toleration         `json:"tolerations,omitempty"`
-	PriorityClassName    string                  `json:"priorityClassName,omitempty"`
-	Resources            v1.ResourceRequirements `json:"resources,omitempty"`
-	SecurityContext      v1.PodSecurityContext   `json:"securityContext,omitempty"`
-	KafkaPollTimeout     *int64                  `json:"kafkaPollTimeout,omitempty"`
-	EnableAccessLog      *bool                   `json:"enableAccessLog,omitempty"`
-	BootstrapServers     string                  `json:"bootstrapServers,omitempty"`
-	KafkaEnableSsl       bool                    `json:"kafkaEnableSsl,omitempty"` // for backwards compatibility, may be removed in the future
-	CustomLabels         map[string]string       `json:"customLabels,omitempty"`
-	EnvironmentVariables []string                `json:"environmentVariables,omitempty"`
-	HeapSize             *int                    `json:"heapSize,omitempty"`
-	SchemaRegistryUrl    string                  `json:"schemaRegistryUrl,omitempty"`
-	SchemaRegistryType   string                  `json:"schemaRegistryType,omitempty"`
-	Ldap                 *LdapConfig             `json:"ldap,omitempty"`
+	DockerImage               string                        `json:"dockerImage"`
+	Affinity                  v1.Affinity                   `json:"affinity,omitempty"`
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	PodDisruptionBudget       PodDisruptionBudget           `json:"podDisruptionBudget,omitempty"`
+	Tolerations               []v1.Toleration               `json:"tolerations,omitempty"`
+	PriorityClassName         string                        `json:"priorityClassName,omitempty"`
+	Resources                 v1.ResourceRequirements       `json:"resources,omitempty"`
+	SecurityContext           v1.PodSecurityContext         `json:"securityContext,omitempty"`
+	KafkaPollTimeout          *int64                        `json:"kafkaPollTimeout,omitempty"`
+	EnableAccessLog           *bool                         `json:"enableAccessLog,omitempty"`
+	BootstrapServers          string                        `json:"bootstrapServers,omitempty"`
+	KafkaEnableSsl            bool                          `json:"kafkaEnableSsl,omitempty"` // for backwards compatibility, may be removed in the future
+	CustomLabels              map[string]string             `json:"customLabels,omitempty"`
+	EnvironmentVariables      []string                      `json:"environmentVariables,omitempty"`
+	HeapSize                  *int                          `json:"heapSize,omitempty"`
+	SchemaRegistryUrl         string                        `json:"schemaRegistryUrl,omitempty"`
+	SchemaRegistryType        string                        `json:"schemaRegistryType,omitempty"`
+	Ldap                      *LdapConfig                   `json:"ldap,omitempty"`
 }
 
 // Monitoring shows Kafka Monitoring configuration
 type Monitoring struct {
-	DockerImage                string                  `json:"dockerImage"`
-	Affinity                   v1.Affinity             `json:"affinity,omitempty"`
-	Tolerations                []v1.Toleration         `json:"tolerations,omitempty"`
-	PriorityClassName          string                  `json:"priorityClassName,omitempty"`
-	Resources                  v1.ResourceRequirements `json:"resources,omitempty"`
-	SecurityContext            v1.PodSecurityContext   `json:"securityContext,omitempty"`
-	MonitoringType             string                  `json:"monitoringType"`
-	SmDbHost                   string                  `json:"smDbHost,omitempty"`
-	SmDbName                   string                  `json:"smDbName,omitempty"`
-	KafkaMeasurementPrefixName string                  `json:"kafkaMeasurementPrefixName,omitempty"`
-	DataCollectionInterval     string                  `json:"dataCollectionInterval,omitempty"`
-	KafkaExecPluginTimeout     string                  `json:"kafkaExecPluginTimeout,omitempty"`
-	SecretName                 string                  `json:"secretName"`
-	MinVersion                 string                  `json:"minVersion,omitempty"`
-	MaxVersion                 string                  `json:"maxVersion,omitempty"`
-	BootstrapServers           string                  `json:"bootstrapServers,omitempty"`
-	KafkaEnableSsl             bool                    `json:"kafkaEnableSsl,omitempty"` // for backwards compatibility, may be removed in the future
-	KafkaTotalBrokerCount      int                     `json:"kafkaTotalBrokerCount"`
-	LagExporter                *LagExporter            `json:"lagExporter,omitempty"`
-	CustomLabels               map[string]string       `json:"customLabels,omitempty"`
+	DockerImage                string                        `json:"dockerImage"`
+	Affinity                   v1.Affinity                   `json:"affinity,omitempty"`
+	TopologySpreadConstraints  []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	PodDisruptionBudget        PodDisruptionBudget           `json:"podDisruptionBudget,omitempty"`
+	Tolerations                []v1.Toleration               `json:"tolerations,omitempty"`
+	PriorityClassName          string                        `json:"priorityClassName,omitempty"`
+	Resources                  v1.ResourceRequirements       `json:"resources,omitempty"`
+	SecurityContext            v1.PodSecurityContext         `json:"securityContext,omitempty"`
+	MonitoringType             string                        `json:"monitoringType"`
+	SmDbHost                   string                        `json:"smDbHost,omitempty"`
+	SmDbName                   string                        `json:"smDbName,omitempty"`
+	KafkaMeasurementPrefixName string                        `json:"kafkaMeasurementPrefixName,omitempty"`
+	DataCollectionInterval     string                        `json:"dataCollectionInterval,omitempty"`
+	KafkaExecPluginTimeout     string                        `json:"kafkaExecPluginTimeout,omitempty"`
+	SecretName                 string                        `json:"secretName"`
+	MinVersion                 string                        `json:"minVersion,omitempty"`
+	MaxVersion                 string                        `json:"maxVersion,omitempty"`
+	BootstrapServers           string                        `json:"bootstrapServers,omitempty"`
+	KafkaEnableSsl             bool                          `json:"kafkaEnableSsl,omitempty"` // for backwards compatibility, may be removed in the future
+	KafkaTotalBrokerCount      int                           `json:"kafkaTotalBrokerCount"`
+	LagExporter                *LagExporter                  `json:"lagExporter,omitempty"`
+	CustomLabels               map[string]string             `json:"customLabels,omitempty"`
 }
 
 // MirrorMaker shows Kafka Mirror Maker configuration
 type MirrorMaker struct {
-	DockerImage                  string                  `json:"dockerImage"`
-	Affinity                     v1.Affinity             `json:"affinity,omitempty"`
-	Tolerations                  []v1.Toleration         `json:"tolerations,omitempty"`
-	PriorityClassName            string                  `json:"priorityClassName,omitempty"`
-	HeapSize                     int                     `json:"heapSize"`
-	Resources                    v1.ResourceRequirements `json:"resources,omitempty"`
-	Replicas                     int                     `json:"replicas"`
-	Clusters                     []Cluster               `json:"clusters"`
-	TopicsToReplicate            string                  `json:"topicsToReplicate,omitempty"`
-	ReplicationFactor            int                     `json:"replicationFactor"`
-	ConfiguratorEnabled          bool                    `json:"configuratorEnabled,omitempty"`
-	RefreshTopicsIntervalSeconds *int32                  `json:"refreshTopicsIntervalSeconds,omitempty"`
-	RefreshGroupsIntervalSeconds *int32                  `json:"refreshGroupsIntervalSeconds,omitempty"`
-	ConfigurationName            string                  `json:"configurationName"`
-	SecretName                   string                  `json:"secretName"`
-	SecurityContext              v1.PodSecurityContext   `json:"securityContext,omitempty"`
-	EnvironmentVariables         []string                `json:"environmentVariables,omitempty"`
-	RegionName                   string                  `json:"regionName,omitempty"`
-	RepeatedReplication          *bool                   `json:"repeatedReplication,omitempty"`
-	CustomLabels                 map[string]string       `json:"customLabels,omitempty"`
-	ReplicationFlowEnabled       bool                    `json:"replicationFlowEnabled,omitempty"`
-	ReplicationPrefixEnabled     bool                    `json:"replicationPrefixEnabled,omitempty"`
-	Transformation               *kmm.Transformation     `json:"transformation,omitempty"`
-	TasksMax                     *int32                  `json:"tasksMax,omitempty"`
-	InternalRestEnabled          *bool                   `json:"internalRestEnabled,omitempty"`
+	DockerImage                  string                        `json:"dockerImage"`
+	Affinity                     v1.Affinity                   `json:"affinity,omitempty"`
+	TopologySpreadConstraints    []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	PodDisruptionBudget          PodDisruptionBudget           `json:"podDisruptionBudget,omitempty"`
+	Tolerations                  []v1.Toleration               `json:"tolerations,omitempty"`
+	PriorityClassName            string                        `json:"priorityClassName,omitempty"`
+	HeapSize                     int                           `json:"heapSize"`
+	Resources                    v1.ResourceRequirements       `json:"resources,omitempty"`
+	Replicas                     int                           `json:"replicas"`
+	Clusters                     []Cluster                     `json:"clusters"`
+	TopicsToReplicate            string                        `json:"topicsToReplicate,omitempty"`
+	ReplicationFactor            int                           `json:"replicationFactor"`
+	ConfiguratorEnabled          bool                          `json:"configuratorEnabled,omitempty"`
+	RefreshTopicsIntervalSeconds *int32                        `json:"refreshTopicsIntervalSeconds,omitempty"`
+	RefreshGroupsIntervalSeconds *int32                        `json:"refreshGroupsIntervalSeconds,omitempty"`
+	ConfigurationName            string                        `json:"configurationName"`
+	SecretName                   string                        `json:"secretName"`
+	SecurityContext              v1.PodSecurityContext         `json:"securityContext,omitempty"`
+	EnvironmentVariables         []string                      `json:"environmentVariables,omitempty"`
+	RegionName                   string                        `json:"regionName,omitempty"`
+	RepeatedReplication          *bool                         `json:"repeatedReplication,omitempty"`
+	CustomLabels                 map[string]string             `json:"customLabels,omitempty"`
+	ReplicationFlowEnabled       bool                          `json:"replicationFlowEnabled,omitempty"`
+	ReplicationPrefixEnabled     bool                          `json:"replicationPrefixEnabled,omitempty"`
+	Transformation               *kmm.Transformation           `json:"transformation,omitempty"`
+	TasksMax                     *int32                        `json:"tasksMax,omitempty"`
+	InternalRestEnabled          *bool                         `json:"internalRestEnabled,omitempty"`
 	// Deprecated: it is kept for backward compatibility.
 	JolokiaPort *int32 `json:"jolokiaPort,omitempty"`
 }
@@ -219,6 +237,16 @@ type SecretPaths struct {
 	MirrorMakerMonitoring map[string]string `json:"mirrorMakerMonitoring,omitempty"`
 }
 
+// PodDisruptionBudget defines PodDisruptionBudget created by operator for pods of component
+type PodDisruptionBudget struct {
+	// Enabled defines whether PodDisruptionBudget is created, true by default
+	Enabled *bool `json:"enabled,omitempty"`
+	// MaxUnavailable is the maximum number of unavailable pods during voluntary disruptions, 1 by default
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// MinAvailable is the minimum number of available pods, it cannot be specified together with MaxUnavailable
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+}
+
 // IntegrationTests shows Integration Tests configuration
 type IntegrationTests struct {
 	ServiceName      string `json:"serviceName"`
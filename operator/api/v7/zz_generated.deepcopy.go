@@ -23,12 +23,21 @@ import (
 	"github.com/Netcracker/qubership-kafka/operator/api/kmm"
 	"k8s.io/api/core/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Akhq) DeepCopyInto(out *Akhq) {
 	*out = *in
 	in.Affinity.DeepCopyInto(&out.Affinity)
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.PodDisruptionBudget.DeepCopyInto(&out.PodDisruptionBudget)
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
 		*out = make([]v1.Toleration, len(*in))
@@ -65,6 +74,11 @@ func (in *Akhq) DeepCopyInto(out *Akhq) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.Ldap != nil {
+		in, out := &in.Ldap, &out.Ldap
+		*out = new(LdapConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Akhq.
@@ -178,6 +192,11 @@ func (in *Global) DeepCopyInto(out *Global) {
 	}
 	out.KafkaSsl = in.KafkaSsl
 	out.Kraft = in.Kraft
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Global.
@@ -282,6 +301,18 @@ func (in *Kafka) DeepCopyInto(out *Kafka) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.KafkaDiscoveryMeta != nil {
+		in, out := &in.KafkaDiscoveryMeta, &out.KafkaDiscoveryMeta
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.KafkaDiscoveryTags != nil {
+		in, out := &in.KafkaDiscoveryTags, &out.KafkaDiscoveryTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.CustomLabels != nil {
 		in, out := &in.CustomLabels, &out.CustomLabels
 		*out = make(map[string]string, len(*in))
@@ -517,10 +548,189 @@ func (in *LagExporter) DeepCopy() *LagExporter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LdapConfig) DeepCopyInto(out *LdapConfig) {
+	*out = *in
+	if in.TrustedCerts != nil {
+		in, out := &in.TrustedCerts, &out.TrustedCerts
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.Server = in.Server
+	in.UsersConfig.DeepCopyInto(&out.UsersConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapConfig.
+func (in *LdapConfig) DeepCopy() *LdapConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LdapConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LdapGroup) DeepCopyInto(out *LdapGroup) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapGroup.
+func (in *LdapGroup) DeepCopy() *LdapGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(LdapGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LdapServer) DeepCopyInto(out *LdapServer) {
+	*out = *in
+	out.Context = in.Context
+	out.Search = in.Search
+	out.Groups = in.Groups
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapServer.
+func (in *LdapServer) DeepCopy() *LdapServer {
+	if in == nil {
+		return nil
+	}
+	out := new(LdapServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LdapServerContext) DeepCopyInto(out *LdapServerContext) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapServerContext.
+func (in *LdapServerContext) DeepCopy() *LdapServerContext {
+	if in == nil {
+		return nil
+	}
+	out := new(LdapServerContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LdapServerGroups) DeepCopyInto(out *LdapServerGroups) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapServerGroups.
+func (in *LdapServerGroups) DeepCopy() *LdapServerGroups {
+	if in == nil {
+		return nil
+	}
+	out := new(LdapServerGroups)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LdapServerSearch) DeepCopyInto(out *LdapServerSearch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapServerSearch.
+func (in *LdapServerSearch) DeepCopy() *LdapServerSearch {
+	if in == nil {
+		return nil
+	}
+	out := new(LdapServerSearch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LdapUser) DeepCopyInto(out *LdapUser) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapUser.
+func (in *LdapUser) DeepCopy() *LdapUser {
+	if in == nil {
+		return nil
+	}
+	out := new(LdapUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LdapUsersConfig) DeepCopyInto(out *LdapUsersConfig) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]LdapGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]LdapUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapUsersConfig.
+func (in *LdapUsersConfig) DeepCopy() *LdapUsersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LdapUsersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MirrorMaker) DeepCopyInto(out *MirrorMaker) {
 	*out = *in
 	in.Affinity.DeepCopyInto(&out.Affinity)
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.PodDisruptionBudget.DeepCopyInto(&out.PodDisruptionBudget)
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
 		*out = make([]v1.Toleration, len(*in))
@@ -670,6 +880,14 @@ func (in *MirrorMakerStatus) DeepCopy() *MirrorMakerStatus {
 func (in *Monitoring) DeepCopyInto(out *Monitoring) {
 	*out = *in
 	in.Affinity.DeepCopyInto(&out.Affinity)
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.PodDisruptionBudget.DeepCopyInto(&out.PodDisruptionBudget)
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
 		*out = make([]v1.Toleration, len(*in))
@@ -778,6 +996,36 @@ func (in *PartitionsReassignmentStatus) DeepCopy() *PartitionsReassignmentStatus
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudget) DeepCopyInto(out *PodDisruptionBudget) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudget.
+func (in *PodDisruptionBudget) DeepCopy() *PodDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Scaling) DeepCopyInto(out *Scaling) {
 	*out = *in
@@ -107,22 +107,56 @@ kafka replicas.
   {{- if and (ne (.Values.INFRA_KAFKA_REPLICAS | toString) "<nil>") .Values.global.cloudIntegrationEnabled -}}
     {{- .Values.INFRA_KAFKA_REPLICAS }}
   {{- else -}}
-    {{- default 3 .Values.kafka.replicas -}}
+    {{- $replicas := 0 -}}
+    {{- range .Values.kafka.nodePools -}}
+      {{- $replicas = add $replicas .replicas -}}
+    {{- end -}}
+    {{- if gt ($replicas | int) 0 -}}
+      {{- $replicas -}}
+    {{- else -}}
+      {{- default 3 .Values.kafka.replicas -}}
+    {{- end -}}
   {{- end -}}
 {{- end -}}
 
 {{/*
-Compute the maximum number of unavailable replicas for the PodDisruptionBudget. This defaults to 1.
-Add a special case for replicas=1, where it should default to 0 as well.
+Kafka broker IDs separated by ",". Each node pool has its own range of IDs starting from firstBrokerId.
 */}}
-{{- define "kafka.pdb.maxUnavailable" -}}
-{{- if eq (int (include "kafka.replicas" . )) 1 -}}
-{{ 0 }}
-{{- else if .Values.kafka.disruptionBudget.maxUnavailable -}}
-{{ .Values.kafka.disruptionBudget.maxUnavailable -}}
-{{- else -}}
-{{- 1 -}}
+{{- define "kafka.brokerIds" -}}
+  {{- $ids := list -}}
+  {{- if .Values.kafka.nodePools -}}
+    {{- range $pool := .Values.kafka.nodePools -}}
+      {{- range $i, $e := until ($pool.replicas | int) -}}
+        {{- $ids = append $ids (add $pool.firstBrokerId $i) -}}
+      {{- end -}}
+    {{- end -}}
+  {{- else -}}
+    {{- range $i, $e := until (include "kafka.replicas" . | int) -}}
+      {{- $ids = append $ids (add $i 1) -}}
+    {{- end -}}
+  {{- end -}}
+  {{- join "," $ids -}}
 {{- end -}}
+
+{{/*
+PodDisruptionBudget of Kafka brokers. Deprecated "kafka.disruptionBudget" parameters are mapped
+onto "kafka.podDisruptionBudget" if they are specified. For a single broker maxUnavailable is 0 as before.
+*/}}
+{{- define "kafka.podDisruptionBudget" -}}
+{{- $pdb := deepCopy (.Values.kafka.podDisruptionBudget | default dict) -}}
+{{- with .Values.kafka.disruptionBudget -}}
+  {{- if hasKey . "enabled" -}}
+    {{- $_ := set $pdb "enabled" .enabled -}}
+  {{- end -}}
+  {{- if and .enabled (not (hasKey $pdb "maxUnavailable")) (not (hasKey $pdb "minAvailable")) -}}
+    {{- if eq (int (include "kafka.replicas" $)) 1 -}}
+      {{- $_ := set $pdb "maxUnavailable" 0 -}}
+    {{- else if .maxUnavailable -}}
+      {{- $_ := set $pdb "maxUnavailable" .maxUnavailable -}}
+    {{- end -}}
+  {{- end -}}
+{{- end -}}
+{{- toJson $pdb -}}
 {{- end -}}
 
 {{/*
@@ -131,12 +165,11 @@ DNS names used to generate TLS certificate with "Subject Alternative Name" field
 {{- define "kafka.certDnsNames" -}}
   {{- $kafkaName := include "kafka.name" . -}}
   {{- $dnsNames := list "localhost" $kafkaName (printf "%s.%s" $kafkaName .Release.Namespace) (printf "%s.%s" $kafkaName "kafka-broker") (printf "%s.%s.%s" $kafkaName "kafka-broker" .Release.Namespace) (printf "%s.%s.svc" $kafkaName .Release.Namespace) (printf "%s-kraft-controller" $kafkaName) -}}
-  {{- $brokers := include "kafka.replicas" . -}}
   {{- $kafkaNamespace := .Release.Namespace -}}
-  {{- range $i, $e := until ($brokers | int) -}}
-    {{- $dnsNames = append $dnsNames (printf "%s-%d" $kafkaName (add $i 1)) -}}
-    {{- $dnsNames = append $dnsNames (printf "%s-%d.%s" $kafkaName (add $i 1) $kafkaNamespace) -}}
-    {{- $dnsNames = append $dnsNames (printf "%s-%d.kafka-broker.%s" $kafkaName (add $i 1) $kafkaNamespace) -}}
+  {{- range $id := splitList "," (include "kafka.brokerIds" .) -}}
+    {{- $dnsNames = append $dnsNames (printf "%s-%s" $kafkaName $id) -}}
+    {{- $dnsNames = append $dnsNames (printf "%s-%s.%s" $kafkaName $id $kafkaNamespace) -}}
+    {{- $dnsNames = append $dnsNames (printf "%s-%s.kafka-broker.%s" $kafkaName $id $kafkaNamespace) -}}
   {{- end -}}
   {{- $dnsNames = concat $dnsNames .Values.kafka.tls.subjectAlternativeName.additionalDnsNames -}}
   {{- $dnsNames | toYaml -}}
@@ -193,11 +226,10 @@ For instance, "kafka-1:9092,kafka-2:9092,kafka-3:9092"
 Configure Kafka Service deployment names in disaster recovery health check format.
 */}}
 {{- define "kafka-service.deploymentNames" -}}
-    {{- $brokers := include "kafka.replicas" . }}
     {{- $kafkaName := include "kafka.name" . }}
     {{- $lst := list }}
-    {{- range $i, $e := until ($brokers | int) }}
-        {{- $lst = append $lst (printf "deployment %s-%d" $kafkaName (add $i 1)) }}
+    {{- range $id := splitList "," (include "kafka.brokerIds" .) }}
+        {{- $lst = append $lst (printf "deployment %s-%s" $kafkaName $id) }}
     {{- end }}
     {{- join "," $lst }}
 {{- end -}}
@@ -354,6 +386,18 @@ Storage class from various places.
   {{- end -}}
 {{- end -}}
 
+{{- define "kafka.controllers.storageClassName" -}}
+  {{- if and (ne (.Values.STORAGE_RWO_CLASS | toString) "<nil>") .Values.global.cloudIntegrationEnabled -}}
+    {{- .Values.STORAGE_RWO_CLASS | toStrings }}
+  {{- else -}}
+    {{- if .Values.kafka.controllers.storage.className -}}
+        {{- .Values.kafka.controllers.storage.className -}}
+    {{- else -}}
+        {{- default "" .Values.kafka.storage.className -}}
+    {{- end -}}
+  {{- end -}}
+{{- end -}}
+
 {{/*
 Find a Kafka service operator image in various places.
 Image can be found from:
@@ -0,0 +1,331 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/IBM/sarama"
+)
+
+const (
+	LeaderSkewStrategy   = "leader-skew"
+	ReplicaCountStrategy = "replica-count"
+	RackStrictStrategy   = "rack-strict"
+	DiskWeightedStrategy = "disk-weighted"
+)
+
+// clusterSnapshot is the state of cluster which target assignment of partitions is computed for
+type clusterSnapshot struct {
+	brokers    []balancingBroker
+	partitions []*partitionReplicas
+	diskUsage  map[int32]int64
+}
+
+// balancingStrategy computes target assignment of partitions between brokers
+type balancingStrategy interface {
+	// assign returns target replicas of partitions which should be moved, the snapshot is not changed
+	assign(snapshot clusterSnapshot) []PartitionAssignment
+	// brokerLoad returns the function which measures load of broker balanced by the strategy
+	brokerLoad(snapshot clusterSnapshot) func(balance BrokerBalance) int64
+}
+
+// newBalancingStrategy returns the strategy selected by settings, leader-skew is used by default
+func newBalancingStrategy(settings BalancingSettings) balancingStrategy {
+	switch settings.Strategy {
+	case ReplicaCountStrategy:
+		return replicaCountStrategy{}
+	case RackStrictStrategy:
+		return rackStrictStrategy{}
+	case DiskWeightedStrategy:
+		return diskWeightedStrategy{maxUsagePercent: settings.MaxBrokerDiskUsagePercent}
+	default:
+		return leaderSkewStrategy{}
+	}
+}
+
+// leaderSkewStrategy evens the number of partition leaders between brokers by greedy swaps of replicas
+type leaderSkewStrategy struct{}
+
+func (leaderSkewStrategy) assign(snapshot clusterSnapshot) []PartitionAssignment {
+	kc := newTopologyClient(snapshot.brokers)
+	partitions := snapshot.copyPartitions()
+	leaders := map[int32]int64{}
+	for _, partition := range partitions {
+		leaders[partition.leader]++
+	}
+	brokersInfo := make([]*BrokerInfo, 0, len(kc.brokerIds))
+	for _, brokerId := range kc.brokerIds {
+		brokersInfo = append(brokersInfo, kc.newBrokerInfo(brokerId, leaders[brokerId]))
+	}
+	globalPartitionCount := int64(len(partitions))
+	for _, topic := range groupPartitionsByTopic(partitions) {
+		kc.calculateBrokersSkew(globalPartitionCount, brokersInfo)
+		if checkBrokersSkewIsNormal(brokersInfo) {
+			break
+		}
+		newReplicaAssignment := kc.PlanPartitionsReassignmentForTopic(topic.info, brokersInfo, globalPartitionCount)
+		for _, partition := range topic.partitions {
+			partition.replicas = newReplicaAssignment[partition.partition]
+		}
+	}
+	return changedAssignments(snapshot.partitions, partitions)
+}
+
+func (leaderSkewStrategy) brokerLoad(snapshot clusterSnapshot) func(balance BrokerBalance) int64 {
+	return func(balance BrokerBalance) int64 {
+		return int64(balance.Leaders)
+	}
+}
+
+// replicaCountStrategy evens the number of replicas between brokers of the same pool regardless of their sizes
+type replicaCountStrategy struct{}
+
+func (replicaCountStrategy) assign(snapshot clusterSnapshot) []PartitionAssignment {
+	partitions := snapshot.copyPartitions()
+	planReplicaCountAssignment(snapshot.brokers, partitions)
+	return changedAssignments(snapshot.partitions, partitions)
+}
+
+func (replicaCountStrategy) brokerLoad(snapshot clusterSnapshot) func(balance BrokerBalance) int64 {
+	return replicasLoad
+}
+
+// rackStrictStrategy places replicas of each partition in as many racks of its pool as possible
+// and then evens the number of replicas between brokers without reducing the number of racks of partitions
+type rackStrictStrategy struct{}
+
+func (rackStrictStrategy) assign(snapshot clusterSnapshot) []PartitionAssignment {
+	partitions := snapshot.copyPartitions()
+	spreadReplicasAcrossRacks(snapshot.brokers, partitions)
+	planReplicaCountAssignment(snapshot.brokers, partitions)
+	return changedAssignments(snapshot.partitions, partitions)
+}
+
+func (rackStrictStrategy) brokerLoad(snapshot clusterSnapshot) func(balance BrokerBalance) int64 {
+	return replicasLoad
+}
+
+// diskWeightedStrategy evens disk usage and the number of replicas between brokers of the same pool
+type diskWeightedStrategy struct {
+	maxUsagePercent int
+}
+
+func (s diskWeightedStrategy) assign(snapshot clusterSnapshot) []PartitionAssignment {
+	partitions := snapshot.copyPartitions()
+	planDiskWeightedAssignment(snapshot.brokers, partitions, s.maxUsagePercent)
+	return changedAssignments(snapshot.partitions, partitions)
+}
+
+func (diskWeightedStrategy) brokerLoad(snapshot clusterSnapshot) func(balance BrokerBalance) int64 {
+	unit := averagePartitionSize(snapshot.partitions)
+	return func(balance BrokerBalance) int64 {
+		return balance.DiskUsageBytes + int64(balance.Replicas)*unit
+	}
+}
+
+func replicasLoad(balance BrokerBalance) int64 {
+	return int64(balance.Replicas)
+}
+
+// planReplicaCountAssignment balances replicas as if all partitions are empty, so that each replica has the same weight
+func planReplicaCountAssignment(brokers []balancingBroker, partitions []*partitionReplicas) {
+	sizes := make([]int64, len(partitions))
+	for i, partition := range partitions {
+		sizes[i], partition.sizeBytes = partition.sizeBytes, 0
+	}
+	planDiskWeightedAssignment(brokers, partitions, 0)
+	for i, partition := range partitions {
+		partition.sizeBytes = sizes[i]
+	}
+}
+
+// spreadReplicasAcrossRacks moves replicas which share a rack with other replicas of the same partition
+// to the least loaded brokers of the same pool in racks without replicas of the partition. Followers are moved first.
+func spreadReplicasAcrossRacks(brokers []balancingBroker, partitions []*partitionReplicas) {
+	brokersById := make(map[int32]balancingBroker, len(brokers))
+	for _, broker := range brokers {
+		brokersById[broker.id] = broker
+	}
+	load := map[int32]int{}
+	for _, partition := range partitions {
+		for _, replica := range partition.replicas {
+			load[replica]++
+		}
+	}
+	for _, partition := range partitions {
+		for moved := true; moved; {
+			moved = false
+			replicasByRack := map[string]int{}
+			for _, replica := range partition.replicas {
+				if rack := brokersById[replica].rack; rack != "" {
+					replicasByRack[rack]++
+				}
+			}
+			for i := len(partition.replicas) - 1; i >= 0 && !moved; i-- {
+				source, found := brokersById[partition.replicas[i]]
+				if !found || source.rack == "" || replicasByRack[source.rack] < 2 {
+					continue
+				}
+				var target *balancingBroker
+				for j := range brokers {
+					candidate := &brokers[j]
+					if candidate.pool != source.pool || candidate.rack == "" || replicasByRack[candidate.rack] > 0 ||
+						containsInt32(partition.replicas, candidate.id) {
+						continue
+					}
+					if target == nil || load[candidate.id] < load[target.id] {
+						target = candidate
+					}
+				}
+				if target == nil {
+					continue
+				}
+				replicas := append([]int32{}, partition.replicas...)
+				replicas[i] = target.id
+				partition.replicas = replicas
+				load[source.id]--
+				load[target.id]++
+				moved = true
+			}
+		}
+	}
+}
+
+// newTopologyClient returns client with topology of brokers only, because leader-skew planning
+// is implemented by methods of KafkaClient which do not request brokers
+func newTopologyClient(brokers []balancingBroker) *KafkaClient {
+	kc := &KafkaClient{brokerPools: map[int32]string{}}
+	racks := map[int32]string{}
+	for _, broker := range brokers {
+		kc.brokerIds = append(kc.brokerIds, broker.id)
+		kc.brokerPools[broker.id] = broker.pool
+		racks[broker.id] = broker.rack
+	}
+	kc.setBrokerRacks(racks)
+	return kc
+}
+
+type topicPartitions struct {
+	info       TopicInfo
+	partitions []*partitionReplicas
+}
+
+// groupPartitionsByTopic returns partitions of each topic in order of topic names
+func groupPartitionsByTopic(partitions []*partitionReplicas) []topicPartitions {
+	byTopic := map[string]*topicPartitions{}
+	var names []string
+	for _, partition := range partitions {
+		topic, found := byTopic[partition.topic]
+		if !found {
+			topic = &topicPartitions{info: TopicInfo{topicName: partition.topic,
+				configs: sarama.TopicDetail{ReplicaAssignment: map[int32][]int32{}}}}
+			byTopic[partition.topic] = topic
+			names = append(names, partition.topic)
+		}
+		topic.partitions = append(topic.partitions, partition)
+		topic.info.configs.ReplicaAssignment[partition.partition] = partition.replicas
+		if partition.partition >= topic.info.configs.NumPartitions {
+			topic.info.configs.NumPartitions = partition.partition + 1
+		}
+	}
+	sort.Strings(names)
+	result := make([]topicPartitions, 0, len(names))
+	for _, name := range names {
+		topic := byTopic[name]
+		topic.info.configs.ReplicationFactor = int16(len(topic.partitions[0].replicas))
+		result = append(result, *topic)
+	}
+	return result
+}
+
+func (s clusterSnapshot) copyPartitions() []*partitionReplicas {
+	partitions := make([]*partitionReplicas, 0, len(s.partitions))
+	for _, partition := range s.partitions {
+		partitionCopy := *partition
+		partitionCopy.replicas = append([]int32{}, partition.replicas...)
+		partitions = append(partitions, &partitionCopy)
+	}
+	return partitions
+}
+
+// changedAssignments returns target replicas of planned partitions which differ from the original ones
+func changedAssignments(original []*partitionReplicas, planned []*partitionReplicas) []PartitionAssignment {
+	var result []PartitionAssignment
+	for i, partition := range planned {
+		if !equalReplicas(original[i].replicas, partition.replicas) {
+			result = append(result, PartitionAssignment{Topic: partition.topic, Partition: partition.partition, Replicas: partition.replicas})
+		}
+	}
+	return result
+}
+
+// describeCluster returns brokers, replicas and sizes of partitions of given topics
+func (kc *KafkaClient) describeCluster(topics []string) (clusterSnapshot, error) {
+	partitions, diskUsage, err := kc.describePartitions(topics)
+	if err != nil {
+		return clusterSnapshot{}, err
+	}
+	return clusterSnapshot{brokers: kc.balancingBrokers(), partitions: partitions, diskUsage: diskUsage}, nil
+}
+
+// planTopicReassignments computes target assignment with the configured strategy and groups it by topics
+func (kc *KafkaClient) planTopicReassignments(topics map[string]sarama.TopicDetail) ([]TopicReassignment, error) {
+	snapshot, err := kc.describeCluster(topicNames(topics))
+	if err != nil {
+		return nil, err
+	}
+	assignments := newBalancingStrategy(kc.balancingSettings).assign(snapshot)
+	if len(assignments) == 0 {
+		log.Info("Partitions are evenly distributed between all brokers.")
+		return nil, nil
+	}
+	return newTopicReassignments(topics, snapshot, assignments), nil
+}
+
+// newTopicReassignments groups target replicas of moved partitions by topics, data of new replicas is estimated
+// with sizes of partitions from the snapshot
+func newTopicReassignments(topics map[string]sarama.TopicDetail, snapshot clusterSnapshot, assignments []PartitionAssignment) []TopicReassignment {
+	movedByTopic := map[string][]PartitionAssignment{}
+	for _, assignment := range assignments {
+		movedByTopic[assignment.Topic] = append(movedByTopic[assignment.Topic], assignment)
+	}
+	sizes := map[string]int64{}
+	for _, partition := range snapshot.partitions {
+		sizes[fmt.Sprintf("%s-%d", partition.topic, partition.partition)] = partition.sizeBytes
+	}
+	var reassignments []TopicReassignment
+	for _, topic := range topicNames(topics) {
+		if len(movedByTopic[topic]) == 0 {
+			continue
+		}
+		topicWithConfig := TopicInfo{topicName: topic, configs: topics[topic]}
+		newReplicaAssignment := CopyCurrentReplicaAssignment(topicWithConfig)
+		for _, assignment := range movedByTopic[topic] {
+			newReplicaAssignment[assignment.Partition] = assignment.Replicas
+		}
+		log.Info(fmt.Sprintf("New assignment for topic %s is: %v", topic, newReplicaAssignment))
+		if reassignment, changed := newTopicReassignment(topicWithConfig, newReplicaAssignment); changed {
+			for i := range reassignment.Partitions {
+				partition := &reassignment.Partitions[i]
+				addedReplicas := missingReplicas(reassignment.Replicas[partition.Partition], reassignment.CurrentReplicas[partition.Partition])
+				partition.BytesToMove = int64(len(addedReplicas)) * sizes[fmt.Sprintf("%s-%d", topic, partition.Partition)]
+			}
+			reassignments = append(reassignments, reassignment)
+		}
+	}
+	return reassignments
+}
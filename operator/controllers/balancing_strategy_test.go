@@ -0,0 +1,337 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testStrategies = []string{LeaderSkewStrategy, ReplicaCountStrategy, RackStrictStrategy, DiskWeightedStrategy}
+
+func TestNewBalancingStrategy(t *testing.T) {
+	assert.Equal(t, leaderSkewStrategy{}, newBalancingStrategy(BalancingSettings{}))
+	assert.Equal(t, replicaCountStrategy{}, newBalancingStrategy(BalancingSettings{Strategy: ReplicaCountStrategy}))
+	assert.Equal(t, rackStrictStrategy{}, newBalancingStrategy(BalancingSettings{Strategy: RackStrictStrategy}))
+	assert.Equal(t, diskWeightedStrategy{maxUsagePercent: 85},
+		newBalancingStrategy(BalancingSettings{Strategy: DiskWeightedStrategy, MaxBrokerDiskUsagePercent: 85}))
+}
+
+func TestLeaderSkewStrategyWithNonContiguousBrokerIds(t *testing.T) {
+	snapshot := clusterSnapshot{brokers: []balancingBroker{{id: 10}, {id: 20}, {id: 30}}}
+	for i := int32(0); i < 6; i++ {
+		snapshot.partitions = append(snapshot.partitions, &partitionReplicas{topic: "test", partition: i, leader: 10, replicas: []int32{10}})
+	}
+	assignments := leaderSkewStrategy{}.assign(snapshot)
+	assert.Len(t, assignments, 4)
+	leaders := map[int32]int{}
+	for _, partition := range applyAssignments(snapshot.partitions, assignments) {
+		leaders[partition.leader]++
+	}
+	assert.Equal(t, map[int32]int{10: 2, 20: 2, 30: 2}, leaders)
+	// the snapshot is not changed by strategy
+	assert.Equal(t, []int32{10}, snapshot.partitions[5].replicas)
+}
+
+func TestReplicaCountStrategyIgnoresSizes(t *testing.T) {
+	snapshot := clusterSnapshot{brokers: []balancingBroker{{id: 1}, {id: 2}, {id: 3}}}
+	for i := int32(0); i < 6; i++ {
+		snapshot.partitions = append(snapshot.partitions, &partitionReplicas{topic: "test", partition: i, leader: 1,
+			replicas: []int32{1, 2}, sizeBytes: int64(i) * 1000})
+	}
+	partitions := applyAssignments(snapshot.partitions, replicaCountStrategy{}.assign(snapshot))
+	_, replicas := brokerLoads(partitions)
+	assert.Equal(t, map[int32]int{1: 4, 2: 4, 3: 4}, replicas)
+	assert.Equal(t, int64(5000), partitions[5].sizeBytes)
+}
+
+func TestRackStrictStrategySpreadsReplicasAcrossRacks(t *testing.T) {
+	snapshot := clusterSnapshot{brokers: []balancingBroker{
+		{id: 1, rack: "a"}, {id: 2, rack: "a"}, {id: 3, rack: "b"}, {id: 4, rack: "b"}, {id: 5, rack: "c"}, {id: 6, rack: "c"},
+	}}
+	for i := int32(0); i < 6; i++ {
+		snapshot.partitions = append(snapshot.partitions, &partitionReplicas{topic: "test", partition: i, leader: 1, replicas: []int32{1, 2, 3}})
+	}
+	partitions := applyAssignments(snapshot.partitions, rackStrictStrategy{}.assign(snapshot))
+	brokersById := map[int32]balancingBroker{}
+	for _, broker := range snapshot.brokers {
+		brokersById[broker.id] = broker
+	}
+	for _, partition := range partitions {
+		assert.Equal(t, 3, countRacks(partition.replicas, brokersById), "partition %d replicas %v", partition.partition, partition.replicas)
+	}
+	_, replicas := brokerLoads(partitions)
+	assert.Equal(t, map[int32]int{1: 3, 2: 3, 3: 3, 4: 3, 5: 3, 6: 3}, replicas)
+}
+
+// TestBalancingStrategiesProperties checks invariants of target assignment of all strategies for random clusters
+// with arbitrary broker IDs, racks, node pools and replication factors
+func TestBalancingStrategiesProperties(t *testing.T) {
+	for seed := int64(0); seed < 300; seed++ {
+		snapshot := newRandomSnapshot(rand.New(rand.NewSource(seed)))
+		for _, strategy := range testStrategies {
+			assignments := newBalancingStrategy(BalancingSettings{Strategy: strategy}).assign(snapshot)
+			assertAssignmentInvariants(t, snapshot, applyAssignments(snapshot.partitions, assignments), strategy, seed)
+		}
+	}
+}
+
+func assertAssignmentInvariants(t *testing.T, snapshot clusterSnapshot, partitions []*partitionReplicas, strategy string, seed int64) {
+	brokersById := map[int32]balancingBroker{}
+	racksByPool := map[string]map[string]bool{}
+	for _, broker := range snapshot.brokers {
+		brokersById[broker.id] = broker
+		if racksByPool[broker.pool] == nil {
+			racksByPool[broker.pool] = map[string]bool{}
+		}
+		racksByPool[broker.pool][broker.rack] = true
+	}
+	for i, partition := range partitions {
+		current := snapshot.partitions[i].replicas
+		message := fmt.Sprintf("seed %d, strategy %s, partition %s-%d, replicas %v -> %v",
+			seed, strategy, partition.topic, partition.partition, current, partition.replicas)
+		assert.Len(t, partition.replicas, len(current), message)
+		seen := map[int32]bool{}
+		for j, replica := range partition.replicas {
+			assert.False(t, seen[replica], "duplicate replica, "+message)
+			seen[replica] = true
+			if replica == current[j] {
+				continue
+			}
+			target, found := brokersById[replica]
+			assert.True(t, found, "replica is moved to unknown broker, "+message)
+			assert.Equal(t, brokersById[current[j]].pool, target.pool, "replica is moved to another pool, "+message)
+		}
+		assert.GreaterOrEqual(t, countRacks(partition.replicas, brokersById), countRacks(current, brokersById),
+			"the number of racks is reduced, "+message)
+		if strategy == RackStrictStrategy {
+			if pool, ok := singlePool(partition.replicas, brokersById); ok && !racksByPool[pool][""] {
+				expected := minInt(len(partition.replicas), len(racksByPool[pool]))
+				assert.Equal(t, expected, countRacks(partition.replicas, brokersById), "replicas are not spread, "+message)
+			}
+		}
+	}
+}
+
+// singlePool returns the pool of replicas if all of them are placed on known brokers of the same pool
+func singlePool(replicas []int32, brokersById map[int32]balancingBroker) (string, bool) {
+	pools := map[string]bool{}
+	for _, replica := range replicas {
+		broker, found := brokersById[replica]
+		if !found {
+			return "", false
+		}
+		pools[broker.pool] = true
+	}
+	for pool := range pools {
+		return pool, len(pools) == 1
+	}
+	return "", false
+}
+
+// simulationResult compares cluster before and after applying target assignment of strategy
+type simulationResult struct {
+	moves               int
+	bytesToMove         int64
+	replicasSkewBefore  int32
+	replicasSkewAfter   int32
+	leadersSkewBefore   int32
+	leadersSkewAfter    int32
+	diskUsageSkewBefore int32
+	diskUsageSkewAfter  int32
+}
+
+// simulateBalancingStrategy applies target assignment of strategy to snapshot as if reassignment is finished
+// and preferred leaders are elected. Skews are the maximum absolute deviations from the average in percent.
+func simulateBalancingStrategy(strategy balancingStrategy, snapshot clusterSnapshot) simulationResult {
+	assignments := strategy.assign(snapshot)
+	partitions := applyAssignments(snapshot.partitions, assignments)
+	result := simulationResult{}
+	for i, partition := range partitions {
+		added := missingReplicas(partition.replicas, snapshot.partitions[i].replicas)
+		result.moves += len(added)
+		result.bytesToMove += int64(len(added)) * partition.sizeBytes
+	}
+	result.replicasSkewBefore, result.leadersSkewBefore, result.diskUsageSkewBefore = simulatedSkews(snapshot.brokers, snapshot.partitions)
+	result.replicasSkewAfter, result.leadersSkewAfter, result.diskUsageSkewAfter = simulatedSkews(snapshot.brokers, partitions)
+	return result
+}
+
+func simulatedSkews(brokers []balancingBroker, partitions []*partitionReplicas) (int32, int32, int32) {
+	diskUsage, _ := brokerLoads(partitions)
+	maxSkew := func(load func(balance BrokerBalance) int64) int32 {
+		var skew int32
+		for _, balance := range calculateBrokersBalance(brokers, partitions, diskUsage, load) {
+			if Abs(balance.Skew) > skew {
+				skew = Abs(balance.Skew)
+			}
+		}
+		return skew
+	}
+	return maxSkew(replicasLoad),
+		maxSkew(func(balance BrokerBalance) int64 { return int64(balance.Leaders) }),
+		maxSkew(func(balance BrokerBalance) int64 { return balance.DiskUsageBytes })
+}
+
+// TestBalancingStrategiesSimulation runs each strategy against synthetic cluster snapshots
+// and reports the number of moves and the final skew to compare strategies
+func TestBalancingStrategiesSimulation(t *testing.T) {
+	scenarios := map[string]clusterSnapshot{
+		"scale-out":       newSyntheticSnapshot([]int32{1, 2, 3}, []int32{4, 5}, nil, 3, func(i int) int64 { return 1000 }),
+		"skewed-sizes":    newSyntheticSnapshot([]int32{1, 2, 3, 4}, nil, nil, 2, func(i int) int64 { return int64(i%7) * int64(i%7) * 1000 }),
+		"racks":           newSyntheticSnapshot([]int32{1, 2, 3, 4, 5, 6}, nil, []string{"a", "a", "b", "b", "c", "c"}, 3, func(i int) int64 { return 500 }),
+		"racks-scale-out": newSyntheticSnapshot([]int32{1, 2, 3}, []int32{4, 5, 6}, []string{"a", "b", "c", "a", "b", "c"}, 2, func(i int) int64 { return int64(i) * 100 }),
+	}
+	for _, name := range []string{"scale-out", "skewed-sizes", "racks", "racks-scale-out"} {
+		for _, strategy := range testStrategies {
+			result := simulateBalancingStrategy(newBalancingStrategy(BalancingSettings{Strategy: strategy}), scenarios[name])
+			t.Logf("%-16s %-14s moves=%-4d bytes=%-8d replicas skew %3d%% -> %3d%%, leaders skew %3d%% -> %3d%%, disk skew %3d%% -> %3d%%",
+				name, strategy, result.moves, result.bytesToMove,
+				result.replicasSkewBefore, result.replicasSkewAfter, result.leadersSkewBefore, result.leadersSkewAfter,
+				result.diskUsageSkewBefore, result.diskUsageSkewAfter)
+			switch strategy {
+			case LeaderSkewStrategy:
+				assert.LessOrEqual(t, result.leadersSkewAfter, result.leadersSkewBefore, "%s %s", name, strategy)
+			case DiskWeightedStrategy:
+				assert.LessOrEqual(t, result.diskUsageSkewAfter, result.diskUsageSkewBefore, "%s %s", name, strategy)
+			default:
+				assert.LessOrEqual(t, result.replicasSkewAfter, result.replicasSkewBefore, "%s %s", name, strategy)
+			}
+		}
+	}
+	for _, strategy := range testStrategies {
+		result := simulateBalancingStrategy(newBalancingStrategy(BalancingSettings{Strategy: strategy}), scenarios["scale-out"])
+		assert.Positive(t, result.moves, "new brokers must get replicas with %s strategy", strategy)
+	}
+}
+
+// newSyntheticSnapshot returns cluster with round-robin assignment of partitions between old brokers
+// and new brokers without replicas, racks are assigned to old and new brokers in order
+func newSyntheticSnapshot(oldBrokerIds []int32, newBrokerIds []int32, racks []string, replicationFactor int,
+	partitionSize func(i int) int64) clusterSnapshot {
+	snapshot := clusterSnapshot{}
+	for i, brokerId := range append(append([]int32{}, oldBrokerIds...), newBrokerIds...) {
+		broker := balancingBroker{id: brokerId}
+		if racks != nil {
+			broker.rack = racks[i]
+		}
+		snapshot.brokers = append(snapshot.brokers, broker)
+	}
+	for i := 0; i < 24; i++ {
+		replicas := make([]int32, replicationFactor)
+		for j := range replicas {
+			replicas[j] = oldBrokerIds[(i+j)%len(oldBrokerIds)]
+		}
+		snapshot.partitions = append(snapshot.partitions, &partitionReplicas{topic: fmt.Sprintf("topic-%d", i%3),
+			partition: int32(i / 3), leader: replicas[0], replicas: replicas, sizeBytes: partitionSize(i)})
+	}
+	sort.Slice(snapshot.partitions, func(i, j int) bool {
+		if snapshot.partitions[i].topic != snapshot.partitions[j].topic {
+			return snapshot.partitions[i].topic < snapshot.partitions[j].topic
+		}
+		return snapshot.partitions[i].partition < snapshot.partitions[j].partition
+	})
+	return snapshot
+}
+
+// newRandomSnapshot returns cluster with arbitrary broker IDs, racks, node pools and replication factors.
+// Some replicas are placed on removed broker which is not balanced.
+func newRandomSnapshot(random *rand.Rand) clusterSnapshot {
+	snapshot := clusterSnapshot{}
+	racksCount := random.Intn(4)
+	poolsEnabled := random.Intn(3) == 0
+	for _, brokerId := range randomBrokerIds(random, 1+random.Intn(6)) {
+		broker := balancingBroker{id: brokerId, pool: "general"}
+		if racksCount > 0 {
+			broker.rack = fmt.Sprintf("zone-%d", random.Intn(racksCount))
+		}
+		if poolsEnabled && random.Intn(2) == 0 {
+			broker.pool = "large"
+		}
+		snapshot.brokers = append(snapshot.brokers, broker)
+	}
+	removedBrokerId := int32(5000)
+	candidates := []int32{removedBrokerId}
+	for _, broker := range snapshot.brokers {
+		candidates = append(candidates, broker.id)
+	}
+	for topic := 0; topic < 1+random.Intn(4); topic++ {
+		replicationFactor := 1 + random.Intn(minInt(3, len(candidates)))
+		partitionsCount := 1 + random.Intn(8)
+		for partition := 0; partition < partitionsCount; partition++ {
+			partitionReplicationFactor := replicationFactor
+			// replication factor of topic can be changed partially
+			if random.Intn(10) == 0 {
+				partitionReplicationFactor = 1 + random.Intn(replicationFactor)
+			}
+			var permutation []int
+			if random.Intn(5) == 0 {
+				permutation = random.Perm(len(candidates))
+			} else {
+				for _, index := range random.Perm(len(candidates) - 1) {
+					permutation = append(permutation, index+1)
+				}
+			}
+			var replicas []int32
+			for _, index := range permutation[:minInt(partitionReplicationFactor, len(permutation))] {
+				replicas = append(replicas, candidates[index])
+			}
+			snapshot.partitions = append(snapshot.partitions, &partitionReplicas{topic: fmt.Sprintf("topic-%d", topic),
+				partition: int32(partition), leader: replicas[0], replicas: replicas, sizeBytes: int64(random.Intn(1000))})
+		}
+	}
+	return snapshot
+}
+
+func randomBrokerIds(random *rand.Rand, count int) []int32 {
+	unique := map[int32]bool{}
+	var brokerIds []int32
+	for len(brokerIds) < count {
+		brokerId := int32(random.Intn(1000))
+		if !unique[brokerId] {
+			unique[brokerId] = true
+			brokerIds = append(brokerIds, brokerId)
+		}
+	}
+	sort.Slice(brokerIds, func(i, j int) bool {
+		return brokerIds[i] < brokerIds[j]
+	})
+	return brokerIds
+}
+
+// applyAssignments returns copies of partitions with target replicas, preferred replicas become leaders of moved partitions
+func applyAssignments(partitions []*partitionReplicas, assignments []PartitionAssignment) []*partitionReplicas {
+	result := clusterSnapshot{partitions: partitions}.copyPartitions()
+	for _, assignment := range assignments {
+		for _, partition := range result {
+			if partition.topic == assignment.Topic && partition.partition == assignment.Partition {
+				partition.replicas = assignment.Replicas
+				partition.leader = assignment.Replicas[0]
+			}
+		}
+	}
+	return result
+}
+
+func minInt(x, y int) int {
+	if x < y {
+		return x
+	}
+	return y
+}
@@ -0,0 +1,529 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+const (
+	// diskSkewTolerancePercent is the deviation of broker load from the average load of its pool
+	// which does not require reassignment
+	diskSkewTolerancePercent = 5
+	// diskWeightedPlanningTimeout limits the time of planning, moves found before timeout are kept and
+	// the rest of skew is balanced by the next plan
+	diskWeightedPlanningTimeout = 30 * time.Second
+)
+
+// BalancingSettings defines how partitions are balanced between brokers
+type BalancingSettings struct {
+	Strategy string
+	// BrokerCapacityBytes contains storage size by broker IDs, brokers with unknown size are not limited
+	BrokerCapacityBytes       map[int32]int64
+	MaxBrokerDiskUsagePercent int
+}
+
+// BrokerBalance describes partitions and disk usage of broker. Skew is deviation of broker load
+// from the average load of brokers in the same pool in percent, load is measured by balancing strategy.
+type BrokerBalance struct {
+	BrokerId       int32
+	Pool           string
+	Replicas       int
+	Leaders        int
+	DiskUsageBytes int64
+	Skew           int32
+}
+
+// partitionReplicas describes replicas of partition and its size on disk
+type partitionReplicas struct {
+	topic     string
+	partition int32
+	leader    int32
+	replicas  []int32
+	sizeBytes int64
+}
+
+// balancingBroker describes broker which partitions are balanced between
+type balancingBroker struct {
+	id            int32
+	rack          string
+	pool          string
+	capacityBytes int64
+}
+
+// planDiskWeightedAssignment moves replicas between brokers of the same pool, so that brokers have close load.
+// Load of broker is a sum of weights of its replicas, where weight is a size of partition plus the average size
+// of partitions, so that the number of replicas is balanced too when partitions are small or empty.
+// Replicas are moved only if the target broker does not exceed maxUsagePercent of its capacity and
+// the number of racks of partition is not reduced. It returns partitions with changed replicas.
+func planDiskWeightedAssignment(brokers []balancingBroker, partitions []*partitionReplicas, maxUsagePercent int) []*partitionReplicas {
+	unit := averagePartitionSize(partitions)
+	brokersById := make(map[int32]balancingBroker, len(brokers))
+	for _, broker := range brokers {
+		brokersById[broker.id] = broker
+	}
+	load := map[int32]int64{}
+	usage := map[int32]int64{}
+	for _, partition := range partitions {
+		for _, replica := range partition.replicas {
+			if _, found := brokersById[replica]; found {
+				load[replica] += partition.sizeBytes + unit
+				usage[replica] += partition.sizeBytes
+			}
+		}
+	}
+	ceiling := func(brokerId int32) int64 {
+		if maxUsagePercent <= 0 {
+			return 0
+		}
+		return brokersById[brokerId].capacityBytes * int64(maxUsagePercent) / 100
+	}
+	replicas := newBrokerReplicas(partitions)
+
+	// the number of moves is limited by the number of replicas, so that moves of replicas back and forth stop
+	maxMoves := 0
+	for _, partition := range partitions {
+		maxMoves += len(partition.replicas)
+	}
+	deadline := time.Now().Add(diskWeightedPlanningTimeout)
+	moves := 0
+	changed := map[*partitionReplicas]bool{}
+	var result []*partitionReplicas
+	for _, poolBrokers := range groupBrokersByPool(brokers) {
+		if len(poolBrokers) < 2 {
+			continue
+		}
+		moveReplica := func(partition *partitionReplicas, source int32, target int32) {
+			weight := partition.sizeBytes + unit
+			load[source] -= weight
+			usage[source] -= partition.sizeBytes
+			load[target] += weight
+			usage[target] += partition.sizeBytes
+			replicas.move(partition, source, target)
+			if !changed[partition] {
+				changed[partition] = true
+				result = append(result, partition)
+			}
+		}
+		for ; ; moves++ {
+			if moves >= maxMoves || time.Now().After(deadline) {
+				log.Info(fmt.Sprintf("Planning of disk weighted assignment is stopped after %d moves, "+
+					"the rest of skew is balanced by the next plan", moves))
+				return result
+			}
+			move := findDiskWeightedMove(poolBrokers, replicas, brokersById, load, usage, unit, ceiling)
+			if move == nil {
+				break
+			}
+			moveReplica(move.partition, move.source, move.target)
+			if move.swapPartition != nil {
+				moveReplica(move.swapPartition, move.target, move.source)
+			}
+		}
+		for _, broker := range poolBrokers {
+			if limit := ceiling(broker.id); limit > 0 && usage[broker.id] > limit {
+				log.Info(fmt.Sprintf("Disk usage of broker %d exceeds %d%% of its storage after balancing", broker.id, maxUsagePercent))
+			}
+		}
+	}
+	return result
+}
+
+// diskWeightedMove is a movement of partition replica from source broker to target one. If swapPartition is set,
+// its replica is moved from target broker to source one in exchange.
+type diskWeightedMove struct {
+	partition     *partitionReplicas
+	source        int32
+	target        int32
+	swapPartition *partitionReplicas
+}
+
+// findDiskWeightedMove looks for the replica which movement reduces the difference of load between overloaded and
+// underloaded brokers the most. If each replica of overloaded broker is too large to move, replicas are swapped with
+// smaller ones of underloaded broker. Brokers exceeding their disk usage ceiling are unloaded first.
+// It returns nil if there is nothing to move.
+func findDiskWeightedMove(poolBrokers []balancingBroker, replicas *brokerReplicas, brokersById map[int32]balancingBroker,
+	load map[int32]int64, usage map[int32]int64, unit int64, ceiling func(int32) int64) *diskWeightedMove {
+	var poolLoad int64
+	for _, broker := range poolBrokers {
+		poolLoad += load[broker.id]
+	}
+	average := poolLoad / int64(len(poolBrokers))
+	tolerance := average * diskSkewTolerancePercent / 100
+
+	sources := append([]balancingBroker{}, poolBrokers...)
+	overCeiling := func(brokerId int32) bool {
+		limit := ceiling(brokerId)
+		return limit > 0 && usage[brokerId] > limit
+	}
+	sort.SliceStable(sources, func(i, j int) bool {
+		if overCeiling(sources[i].id) != overCeiling(sources[j].id) {
+			return overCeiling(sources[i].id)
+		}
+		return load[sources[i].id] > load[sources[j].id]
+	})
+	targets := append([]balancingBroker{}, poolBrokers...)
+	sort.SliceStable(targets, func(i, j int) bool {
+		return load[targets[i].id] < load[targets[j].id]
+	})
+
+	for _, source := range sources {
+		unloading := overCeiling(source.id)
+		if !unloading && load[source.id]-average <= tolerance {
+			continue
+		}
+		for _, target := range targets {
+			gap := load[source.id] - load[target.id]
+			if target.id == source.id || gap <= 0 || overCeiling(target.id) {
+				continue
+			}
+			sourceReplicas := replicas.movable(source.id, target.id, brokersById)
+			var best *diskWeightedMove
+			var bestDistance int64 = math.MaxInt64
+			for _, partition := range sourceReplicas {
+				weight := partition.sizeBytes + unit
+				// the move must reduce the difference of load, unless source broker exceeds its ceiling
+				if !unloading && weight >= gap {
+					continue
+				}
+				if limit := ceiling(target.id); limit > 0 && usage[target.id]+partition.sizeBytes > limit {
+					continue
+				}
+				if distance := absInt64(weight - gap/2); distance < bestDistance {
+					best, bestDistance = &diskWeightedMove{partition: partition, source: source.id, target: target.id}, distance
+				}
+			}
+			if best == nil && !unloading {
+				best = findDiskWeightedSwap(sourceReplicas, replicas.movable(target.id, source.id, brokersById),
+					source.id, target.id, gap, usage, ceiling)
+			}
+			if best != nil {
+				return best
+			}
+		}
+	}
+	return nil
+}
+
+// findDiskWeightedSwap looks for a pair of replicas of source and target brokers which exchange reduces
+// the difference of their load the most. Replicas of target broker are sorted by size, so that the best pair
+// for each replica of source broker is found by binary search.
+func findDiskWeightedSwap(sourceReplicas []*partitionReplicas, targetReplicas []*partitionReplicas, source int32, target int32,
+	gap int64, usage map[int32]int64, ceiling func(int32) int64) *diskWeightedMove {
+	targetReplicas = append([]*partitionReplicas{}, targetReplicas...)
+	sort.SliceStable(targetReplicas, func(i, j int) bool {
+		return targetReplicas[i].sizeBytes < targetReplicas[j].sizeBytes
+	})
+	// weights of replicas differ by their sizes only, the difference must be positive, less than the gap and
+	// must not exceed the ceiling of target broker
+	maxDifference := gap - 1
+	if limit := ceiling(target); limit > 0 && limit-usage[target] < maxDifference {
+		maxDifference = limit - usage[target]
+	}
+	var best *diskWeightedMove
+	var bestDistance int64 = math.MaxInt64
+	for _, partition := range sourceReplicas {
+		minSize, maxSize := partition.sizeBytes-maxDifference, partition.sizeBytes-1
+		if minSize > maxSize {
+			continue
+		}
+		// the best size of swapped replica is in the middle of the gap
+		wanted := partition.sizeBytes - gap/2
+		if wanted < minSize {
+			wanted = minSize
+		} else if wanted > maxSize {
+			wanted = maxSize
+		}
+		i := sort.Search(len(targetReplicas), func(i int) bool { return targetReplicas[i].sizeBytes >= wanted })
+		for _, j := range []int{i - 1, i} {
+			if j < 0 || j >= len(targetReplicas) {
+				continue
+			}
+			swapPartition := targetReplicas[j]
+			if swapPartition.sizeBytes < minSize || swapPartition.sizeBytes > maxSize {
+				continue
+			}
+			difference := partition.sizeBytes - swapPartition.sizeBytes
+			if distance := absInt64(difference - gap/2); distance < bestDistance {
+				best, bestDistance = &diskWeightedMove{partition: partition, source: source, target: target, swapPartition: swapPartition}, distance
+			}
+		}
+	}
+	return best
+}
+
+// brokerReplicas indexes partitions by brokers of their replicas, so that moves are looked for among replicas
+// of given brokers only. Partitions of broker are kept in the order of planned partitions.
+type brokerReplicas struct {
+	order      map[*partitionReplicas]int
+	partitions map[int32][]*partitionReplicas
+}
+
+func newBrokerReplicas(partitions []*partitionReplicas) *brokerReplicas {
+	replicas := &brokerReplicas{order: make(map[*partitionReplicas]int, len(partitions)), partitions: map[int32][]*partitionReplicas{}}
+	for i, partition := range partitions {
+		replicas.order[partition] = i
+		for _, replica := range partition.replicas {
+			replicas.partitions[replica] = append(replicas.partitions[replica], partition)
+		}
+	}
+	return replicas
+}
+
+// move replaces replica of partition on source broker with replica on target broker
+func (br *brokerReplicas) move(partition *partitionReplicas, source int32, target int32) {
+	replicas := append([]int32{}, partition.replicas...)
+	replicas[indexOfInt32(replicas, source)] = target
+	partition.replicas = replicas
+
+	sourcePartitions := br.partitions[source]
+	for i, sourcePartition := range sourcePartitions {
+		if sourcePartition == partition {
+			br.partitions[source] = append(sourcePartitions[:i], sourcePartitions[i+1:]...)
+			break
+		}
+	}
+	targetPartitions := br.partitions[target]
+	i := sort.Search(len(targetPartitions), func(i int) bool { return br.order[targetPartitions[i]] > br.order[partition] })
+	targetPartitions = append(targetPartitions, nil)
+	copy(targetPartitions[i+1:], targetPartitions[i:])
+	targetPartitions[i] = partition
+	br.partitions[target] = targetPartitions
+}
+
+// movable returns partitions which replicas can be moved from source broker to target one
+// without duplicating replicas on target broker and reducing the number of racks of partition
+func (br *brokerReplicas) movable(source int32, target int32, brokersById map[int32]balancingBroker) []*partitionReplicas {
+	var result []*partitionReplicas
+	for _, partition := range br.partitions[source] {
+		if containsInt32(partition.replicas, target) {
+			continue
+		}
+		if keepsRacks(partition.replicas, indexOfInt32(partition.replicas, source), target, brokersById) {
+			result = append(result, partition)
+		}
+	}
+	return result
+}
+
+// keepsRacks checks that replacement of replica does not reduce the number of racks which partition replicas are placed in.
+// The number is reduced only if rack of replaced replica is lost and rack of target broker is already used.
+func keepsRacks(replicas []int32, index int, target int32, brokersById map[int32]balancingBroker) bool {
+	sourceRack, targetRack := brokersById[replicas[index]].rack, brokersById[target].rack
+	if sourceRack == targetRack {
+		return true
+	}
+	sourceRackLost, targetRackAdded := sourceRack != "", targetRack != ""
+	for i, replica := range replicas {
+		if i == index {
+			continue
+		}
+		switch brokersById[replica].rack {
+		case sourceRack:
+			sourceRackLost = false
+		case targetRack:
+			targetRackAdded = false
+		}
+	}
+	return targetRackAdded || !sourceRackLost
+}
+
+func countRacks(replicas []int32, brokersById map[int32]balancingBroker) int {
+	racks := map[string]bool{}
+	for _, replica := range replicas {
+		if rack := brokersById[replica].rack; rack != "" {
+			racks[rack] = true
+		}
+	}
+	return len(racks)
+}
+
+func averagePartitionSize(partitions []*partitionReplicas) int64 {
+	var size int64
+	for _, partition := range partitions {
+		size += partition.sizeBytes
+	}
+	if len(partitions) == 0 || size < int64(len(partitions)) {
+		return 1
+	}
+	return size / int64(len(partitions))
+}
+
+// groupBrokersByPool returns brokers grouped by node pools in order of pool names
+func groupBrokersByPool(brokers []balancingBroker) [][]balancingBroker {
+	pools := map[string][]balancingBroker{}
+	var names []string
+	for _, broker := range brokers {
+		if _, found := pools[broker.pool]; !found {
+			names = append(names, broker.pool)
+		}
+		pools[broker.pool] = append(pools[broker.pool], broker)
+	}
+	sort.Strings(names)
+	groups := make([][]balancingBroker, 0, len(names))
+	for _, name := range names {
+		groups = append(groups, pools[name])
+	}
+	return groups
+}
+
+// calculateBrokersBalance returns replicas, leaders and disk usage of brokers with skew of their load
+// from the average load of their pools, load is measured by given function
+func calculateBrokersBalance(brokers []balancingBroker, partitions []*partitionReplicas, diskUsage map[int32]int64,
+	brokerLoad func(balance BrokerBalance) int64) []BrokerBalance {
+	balances := make(map[int32]*BrokerBalance, len(brokers))
+	for _, broker := range brokers {
+		balances[broker.id] = &BrokerBalance{BrokerId: broker.id, Pool: broker.pool, DiskUsageBytes: diskUsage[broker.id]}
+	}
+	for _, partition := range partitions {
+		for _, replica := range partition.replicas {
+			if balance, found := balances[replica]; found {
+				balance.Replicas++
+			}
+		}
+		if balance, found := balances[partition.leader]; found {
+			balance.Leaders++
+		}
+	}
+	var result []BrokerBalance
+	for _, poolBrokers := range groupBrokersByPool(brokers) {
+		var poolLoad int64
+		for _, broker := range poolBrokers {
+			poolLoad += brokerLoad(*balances[broker.id])
+		}
+		average := float64(poolLoad) / float64(len(poolBrokers))
+		for _, broker := range poolBrokers {
+			balance := balances[broker.id]
+			if average > 0 {
+				balance.Skew = int32(math.Round((float64(brokerLoad(*balance)) - average) / average * 100))
+			}
+			result = append(result, *balance)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].BrokerId < result[j].BrokerId
+	})
+	return result
+}
+
+// DescribeBrokersBalance returns current distribution of partitions between brokers
+func (kc *KafkaClient) DescribeBrokersBalance() ([]BrokerBalance, error) {
+	topics, err := kc.adminClient.ListTopics()
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := kc.describeCluster(topicNames(topics))
+	if err != nil {
+		return nil, err
+	}
+	brokerLoad := newBalancingStrategy(kc.balancingSettings).brokerLoad(snapshot)
+	return calculateBrokersBalance(snapshot.brokers, snapshot.partitions, snapshot.diskUsage, brokerLoad), nil
+}
+
+// describePartitions returns replicas of partitions of given topics with their sizes and disk usage of brokers.
+// Size of partition is the maximum size of its replicas, because followers can lag behind the leader.
+func (kc *KafkaClient) describePartitions(topics []string) ([]*partitionReplicas, map[int32]int64, error) {
+	metadata, err := kc.adminClient.DescribeTopics(topics)
+	if err != nil {
+		return nil, nil, err
+	}
+	logDirs, err := kc.adminClient.DescribeLogDirs(kc.brokerIds)
+	if err != nil {
+		return nil, nil, err
+	}
+	sizes := map[string]int64{}
+	diskUsage := map[int32]int64{}
+	for brokerId, brokerLogDirs := range logDirs {
+		for _, logDir := range brokerLogDirs {
+			if logDir.ErrorCode != sarama.ErrNoError {
+				return nil, nil, fmt.Errorf("cannot describe log directory %s of broker %d: %v", logDir.Path, brokerId, logDir.ErrorCode)
+			}
+			for _, topic := range logDir.Topics {
+				for _, partition := range topic.Partitions {
+					diskUsage[brokerId] += partition.Size
+					key := fmt.Sprintf("%s-%d", topic.Topic, partition.PartitionID)
+					if partition.Size > sizes[key] {
+						sizes[key] = partition.Size
+					}
+				}
+			}
+		}
+	}
+	var partitions []*partitionReplicas
+	for _, topic := range metadata {
+		if topic.Err != sarama.ErrNoError {
+			return nil, nil, fmt.Errorf("cannot describe topic %s: %v", topic.Name, topic.Err)
+		}
+		for _, partition := range topic.Partitions {
+			partitions = append(partitions, &partitionReplicas{
+				topic:     topic.Name,
+				partition: partition.ID,
+				leader:    partition.Leader,
+				replicas:  append([]int32{}, partition.Replicas...),
+				sizeBytes: sizes[fmt.Sprintf("%s-%d", topic.Name, partition.ID)],
+			})
+		}
+	}
+	sort.Slice(partitions, func(i, j int) bool {
+		if partitions[i].topic != partitions[j].topic {
+			return partitions[i].topic < partitions[j].topic
+		}
+		return partitions[i].partition < partitions[j].partition
+	})
+	return partitions, diskUsage, nil
+}
+
+func (kc *KafkaClient) balancingBrokers() []balancingBroker {
+	brokers := make([]balancingBroker, 0, len(kc.brokerIds))
+	for _, brokerId := range kc.brokerIds {
+		brokers = append(brokers, balancingBroker{
+			id:            brokerId,
+			rack:          kc.brokerRacks[brokerId],
+			pool:          kc.brokerPools[brokerId],
+			capacityBytes: kc.balancingSettings.BrokerCapacityBytes[brokerId],
+		})
+	}
+	return brokers
+}
+
+func topicNames(topics map[string]sarama.TopicDetail) []string {
+	names := make([]string, 0, len(topics))
+	for topic := range topics {
+		names = append(names, topic)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func absInt64(x int64) int64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func indexOfInt32(array []int32, element int32) int {
+	for i, a := range array {
+		if a == element {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,223 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeLogDirsAdmin describes topics and log directories of brokers, other admin operations are not implemented
+type fakeLogDirsAdmin struct {
+	sarama.ClusterAdmin
+	topics  []*sarama.TopicMetadata
+	logDirs map[int32][]sarama.DescribeLogDirsResponseDirMetadata
+}
+
+func (a *fakeLogDirsAdmin) DescribeTopics(topics []string) ([]*sarama.TopicMetadata, error) {
+	return a.topics, nil
+}
+
+func (a *fakeLogDirsAdmin) DescribeLogDirs(brokerIds []int32) (map[int32][]sarama.DescribeLogDirsResponseDirMetadata, error) {
+	return a.logDirs, nil
+}
+
+func brokerLoads(partitions []*partitionReplicas) (map[int32]int64, map[int32]int) {
+	sizes := map[int32]int64{}
+	replicas := map[int32]int{}
+	for _, partition := range partitions {
+		for _, replica := range partition.replicas {
+			sizes[replica] += partition.sizeBytes
+			replicas[replica]++
+		}
+	}
+	return sizes, replicas
+}
+
+func assertReplicasAreUnique(t *testing.T, partitions []*partitionReplicas) {
+	for _, partition := range partitions {
+		seen := map[int32]bool{}
+		for _, replica := range partition.replicas {
+			assert.False(t, seen[replica], "partition %s-%d has duplicate replica %d", partition.topic, partition.partition, replica)
+			seen[replica] = true
+		}
+	}
+}
+
+func TestPlanDiskWeightedAssignment(t *testing.T) {
+	brokers := []balancingBroker{{id: 1}, {id: 2}, {id: 3}}
+	var partitions []*partitionReplicas
+	for i := int32(0); i < 6; i++ {
+		partitions = append(partitions, &partitionReplicas{topic: "large", partition: i, replicas: []int32{1, 2}, sizeBytes: 1000})
+	}
+	for i := int32(0); i < 6; i++ {
+		partitions = append(partitions, &partitionReplicas{topic: "small", partition: i, replicas: []int32{3, 1}, sizeBytes: 10})
+	}
+	moved := planDiskWeightedAssignment(brokers, partitions, 0)
+	assert.NotEmpty(t, moved)
+	assertReplicasAreUnique(t, partitions)
+
+	sizes, replicas := brokerLoads(partitions)
+	for _, brokerId := range []int32{1, 2, 3} {
+		assert.InDelta(t, 4020, sizes[brokerId], 1100, "disk usage of broker %d", brokerId)
+		assert.InDelta(t, 8, replicas[brokerId], 2, "replicas of broker %d", brokerId)
+	}
+
+	assert.Empty(t, planDiskWeightedAssignment(brokers, partitions, 0), "balanced cluster must not be changed")
+}
+
+func TestPlanDiskWeightedAssignmentBalancesReplicasOfEmptyPartitions(t *testing.T) {
+	brokers := []balancingBroker{{id: 1}, {id: 2}, {id: 3}}
+	var partitions []*partitionReplicas
+	for i := int32(0); i < 9; i++ {
+		partitions = append(partitions, &partitionReplicas{topic: "empty", partition: i, replicas: []int32{1}})
+	}
+	planDiskWeightedAssignment(brokers, partitions, 0)
+	_, replicas := brokerLoads(partitions)
+	assert.Equal(t, map[int32]int{1: 3, 2: 3, 3: 3}, replicas)
+}
+
+func TestPlanDiskWeightedAssignmentKeepsPools(t *testing.T) {
+	brokers := []balancingBroker{{id: 1, pool: "general"}, {id: 2, pool: "general"}, {id: 100, pool: "large"}}
+	partitions := []*partitionReplicas{
+		{topic: "test", partition: 0, replicas: []int32{1}, sizeBytes: 100},
+		{topic: "test", partition: 1, replicas: []int32{1}, sizeBytes: 100},
+		{topic: "test", partition: 2, replicas: []int32{1}, sizeBytes: 100},
+		{topic: "test", partition: 3, replicas: []int32{1}, sizeBytes: 100},
+	}
+	planDiskWeightedAssignment(brokers, partitions, 0)
+	_, replicas := brokerLoads(partitions)
+	assert.Equal(t, map[int32]int{1: 2, 2: 2}, replicas)
+}
+
+func TestPlanDiskWeightedAssignmentRespectsDiskUsageCeiling(t *testing.T) {
+	brokers := []balancingBroker{{id: 1, capacityBytes: 1000}, {id: 2, capacityBytes: 1000}, {id: 3, capacityBytes: 250}}
+	var partitions []*partitionReplicas
+	for i := int32(0); i < 6; i++ {
+		partitions = append(partitions, &partitionReplicas{topic: "test", partition: i, replicas: []int32{1}, sizeBytes: 100})
+	}
+	planDiskWeightedAssignment(brokers, partitions, 80)
+	sizes, _ := brokerLoads(partitions)
+	assert.LessOrEqual(t, sizes[3], int64(200))
+	assert.Equal(t, int64(600), sizes[1]+sizes[2]+sizes[3])
+	assert.LessOrEqual(t, sizes[1], int64(300))
+
+	// broker exceeding its ceiling is unloaded even if load is balanced by weights
+	brokers = []balancingBroker{{id: 1, capacityBytes: 400}, {id: 2, capacityBytes: 1000}}
+	partitions = []*partitionReplicas{
+		{topic: "test", partition: 0, replicas: []int32{1}, sizeBytes: 400},
+		{topic: "test", partition: 1, replicas: []int32{2}, sizeBytes: 350},
+	}
+	planDiskWeightedAssignment(brokers, partitions, 90)
+	sizes, _ = brokerLoads(partitions)
+	assert.LessOrEqual(t, sizes[1], int64(360))
+	assert.Equal(t, int64(750), sizes[1]+sizes[2])
+}
+
+func TestPlanDiskWeightedAssignmentKeepsRacks(t *testing.T) {
+	brokers := []balancingBroker{{id: 1, rack: "a"}, {id: 2, rack: "b"}, {id: 3, rack: "a"}, {id: 4, rack: "b"}}
+	var partitions []*partitionReplicas
+	for i := int32(0); i < 8; i++ {
+		partitions = append(partitions, &partitionReplicas{topic: "test", partition: i, replicas: []int32{1, 2}, sizeBytes: 100})
+	}
+	planDiskWeightedAssignment(brokers, partitions, 0)
+	assertReplicasAreUnique(t, partitions)
+	brokersById := map[int32]balancingBroker{}
+	for _, broker := range brokers {
+		brokersById[broker.id] = broker
+	}
+	for _, partition := range partitions {
+		assert.Equal(t, 2, countRacks(partition.replicas, brokersById), "partition %d replicas %v", partition.partition, partition.replicas)
+	}
+	_, replicas := brokerLoads(partitions)
+	assert.Equal(t, map[int32]int{1: 4, 2: 4, 3: 4, 4: 4}, replicas)
+}
+
+func TestCalculateBrokersBalance(t *testing.T) {
+	brokers := []balancingBroker{{id: 2, pool: "general"}, {id: 1, pool: "general"}, {id: 100, pool: "large"}}
+	partitions := []*partitionReplicas{
+		{topic: "test", partition: 0, leader: 1, replicas: []int32{1, 2}},
+		{topic: "test", partition: 1, leader: 1, replicas: []int32{1, 100}},
+		{topic: "test", partition: 2, leader: 1, replicas: []int32{1, 2}},
+		{topic: "test", partition: 3, leader: 2, replicas: []int32{2, 100}},
+	}
+	balances := calculateBrokersBalance(brokers, partitions, map[int32]int64{1: 300, 2: 100}, func(balance BrokerBalance) int64 {
+		return int64(balance.Leaders)
+	})
+	assert.Equal(t, []BrokerBalance{
+		{BrokerId: 1, Pool: "general", Replicas: 3, Leaders: 3, DiskUsageBytes: 300, Skew: 50},
+		{BrokerId: 2, Pool: "general", Replicas: 3, Leaders: 1, DiskUsageBytes: 100, Skew: -50},
+		{BrokerId: 100, Pool: "large", Replicas: 2, Leaders: 0, Skew: 0},
+	}, balances)
+}
+
+func TestDescribePartitions(t *testing.T) {
+	admin := &fakeLogDirsAdmin{
+		topics: []*sarama.TopicMetadata{
+			{Name: "test", Partitions: []*sarama.PartitionMetadata{
+				{ID: 0, Leader: 1, Replicas: []int32{1, 2}},
+				{ID: 1, Leader: 2, Replicas: []int32{2, 1}},
+			}},
+		},
+		logDirs: map[int32][]sarama.DescribeLogDirsResponseDirMetadata{
+			1: {{Path: "/var/opt/kafka/data/1", Topics: []sarama.DescribeLogDirsResponseTopic{
+				{Topic: "test", Partitions: []sarama.DescribeLogDirsResponsePartition{{PartitionID: 0, Size: 500}, {PartitionID: 1, Size: 90}}},
+			}}},
+			2: {{Path: "/var/opt/kafka/data/2", Topics: []sarama.DescribeLogDirsResponseTopic{
+				{Topic: "test", Partitions: []sarama.DescribeLogDirsResponsePartition{{PartitionID: 0, Size: 450}, {PartitionID: 1, Size: 100}}},
+			}}},
+		},
+	}
+	kc := &KafkaClient{adminClient: admin, brokerIds: []int32{1, 2}}
+	partitions, diskUsage, err := kc.describePartitions([]string{"test"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*partitionReplicas{
+		{topic: "test", partition: 0, leader: 1, replicas: []int32{1, 2}, sizeBytes: 500},
+		{topic: "test", partition: 1, leader: 2, replicas: []int32{2, 1}, sizeBytes: 100},
+	}, partitions)
+	assert.Equal(t, map[int32]int64{1: 590, 2: 550}, diskUsage)
+}
+
+// BenchmarkPlanDiskWeightedAssignment plans balancing after a cluster of 6 brokers in 3 racks is scaled out to 12 brokers,
+// partitions of 200 topics with replication factor 3 have different sizes
+func BenchmarkPlanDiskWeightedAssignment(b *testing.B) {
+	var brokers []balancingBroker
+	for id := int32(1); id <= 12; id++ {
+		brokers = append(brokers, balancingBroker{id: id, rack: string(rune('a' + id%3))})
+	}
+	var initial []partitionReplicas
+	for topic := 0; topic < 200; topic++ {
+		for partition := int32(0); partition < 30; partition++ {
+			first := (int32(topic) + partition) % 6
+			initial = append(initial, partitionReplicas{
+				topic:     string(rune('a'+topic%26)) + string(rune('a'+topic/26)),
+				partition: partition,
+				replicas:  []int32{first + 1, (first+1)%6 + 1, (first+2)%6 + 1},
+				sizeBytes: int64(topic%10+1) * int64(partition%4+1) << 20,
+			})
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		partitions := make([]*partitionReplicas, len(initial))
+		for j := range initial {
+			partition := initial[j]
+			partitions[j] = &partition
+		}
+		planDiskWeightedAssignment(brokers, partitions, 0)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/IBM/sarama"
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// checkBrokerConfig checks that Kafka config does not override properties configured by operator
+func (r ReconcileKafka) checkBrokerConfig() error {
+	var managed []string
+	for key := range r.cr.Spec.Config {
+		if provider.IsManagedConfig(key) {
+			managed = append(managed, key)
+		}
+	}
+	if len(managed) > 0 {
+		sort.Strings(managed)
+		return fmt.Errorf("properties %v are configured by operator and cannot be specified in Config", managed)
+	}
+	return nil
+}
+
+// reconcileBrokerConfig applies dynamic properties from Kafka config to running brokers with IncrementalAlterConfigs
+// request, removes dynamic properties deleted from config and updates status of broker config
+func (r ReconcileKafka) reconcileBrokerConfig(brokerIds []int) error {
+	status, err := r.reconciler.StatusUpdater.GetStatus()
+	if err != nil {
+		return err
+	}
+	dynamicConfigs := r.kafkaProvider.GetDynamicConfigs()
+	removedConfigs := getRemovedConfigs(status.ConfigStatus.Applied, r.cr.Spec.Config)
+	if len(dynamicConfigs) > 0 || len(removedConfigs) > 0 {
+		r.logger.Info(fmt.Sprintf("Applying dynamic broker properties %v, removing properties %v", dynamicConfigs, removedConfigs))
+		adminClient, err := r.newKafkaAdminClient()
+		if err != nil {
+			return err
+		}
+		defer adminClient.Close()
+		entries := newBrokerConfigEntries(r.cr.Spec.Config, dynamicConfigs, removedConfigs, provider.ClusterWideConfigScope)
+		if len(entries) > 0 {
+			// empty name means cluster-wide default config of all brokers
+			if err = adminClient.IncrementalAlterConfig(sarama.BrokerResource, "", entries, false); err != nil {
+				return fmt.Errorf("unable to update cluster-wide broker config: %v", err)
+			}
+		}
+		entries = newBrokerConfigEntries(r.cr.Spec.Config, dynamicConfigs, removedConfigs, provider.PerBrokerConfigScope)
+		if len(entries) > 0 {
+			for _, brokerId := range brokerIds {
+				if err = adminClient.IncrementalAlterConfig(sarama.BrokerResource, strconv.Itoa(brokerId), entries, false); err != nil {
+					return fmt.Errorf("unable to update config of broker %d: %v", brokerId, err)
+				}
+			}
+		}
+	}
+	pendingRestartConfigs, err := r.getPendingRestartConfigs(brokerIds)
+	if err != nil {
+		return err
+	}
+	return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
+		instance.Status.ConfigStatus = kafka.BrokerConfigStatus{
+			Applied:        dynamicConfigs,
+			PendingRestart: pendingRestartConfigs,
+		}
+	})
+}
+
+// updatePendingRestartConfigs refreshes static properties which are not applied yet after brokers restart
+func (r ReconcileKafka) updatePendingRestartConfigs(brokerIds []int) error {
+	status, err := r.reconciler.StatusUpdater.GetStatus()
+	if err != nil {
+		return err
+	}
+	if len(status.ConfigStatus.PendingRestart) == 0 {
+		return nil
+	}
+	pendingRestartConfigs, err := r.getPendingRestartConfigs(brokerIds)
+	if err != nil {
+		return err
+	}
+	return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
+		instance.Status.ConfigStatus.PendingRestart = pendingRestartConfigs
+	})
+}
+
+// getPendingRestartConfigs returns static properties which differ from properties of running broker pods
+func (r ReconcileKafka) getPendingRestartConfigs(brokerIds []int) ([]string, error) {
+	staticConfigs := r.kafkaProvider.GetStaticConfigs()
+	if len(staticConfigs) == 0 {
+		return nil, nil
+	}
+	var pods []corev1.Pod
+	for _, brokerId := range brokerIds {
+		labels := r.kafkaProvider.GetBrokerPodSelectorLabels(brokerId)
+		podList, err := r.reconciler.FindPodList(r.cr.Namespace, labels)
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, podList.Items...)
+	}
+	return filterPendingRestartConfigs(r.cr.Spec.Config, staticConfigs, pods), nil
+}
+
+// filterPendingRestartConfigs returns static properties which are absent or have different value in any pod
+func filterPendingRestartConfigs(config map[string]string, staticConfigs []string, pods []corev1.Pod) []string {
+	var pending []string
+	for _, key := range staticConfigs {
+		envName := provider.GetConfigEnvName(key)
+		for _, pod := range pods {
+			if !hasPodEnv(pod, envName, config[key]) {
+				pending = append(pending, key)
+				break
+			}
+		}
+	}
+	return pending
+}
+
+func hasPodEnv(pod corev1.Pod, name string, value string) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name != "kafka" {
+			continue
+		}
+		for _, env := range container.Env {
+			if env.Name == name {
+				return env.Value == value
+			}
+		}
+	}
+	return false
+}
+
+// getRemovedConfigs returns previously applied dynamic properties which are removed from config
+func getRemovedConfigs(applied []string, config map[string]string) []string {
+	var removed []string
+	for _, key := range applied {
+		if _, ok := config[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+	return removed
+}
+
+// newBrokerConfigEntries creates IncrementalAlterConfigs entries of given scope which set dynamic properties
+// and delete removed properties
+func newBrokerConfigEntries(config map[string]string, dynamicConfigs []string, removedConfigs []string, scope string) map[string]sarama.IncrementalAlterConfigsEntry {
+	entries := make(map[string]sarama.IncrementalAlterConfigsEntry)
+	for _, key := range dynamicConfigs {
+		if provider.GetConfigScope(key) != scope {
+			continue
+		}
+		value := config[key]
+		entries[key] = sarama.IncrementalAlterConfigsEntry{Operation: sarama.IncrementalAlterConfigsOperationSet, Value: &value}
+	}
+	for _, key := range removedConfigs {
+		if provider.GetConfigScope(key) != scope {
+			continue
+		}
+		entries[key] = sarama.IncrementalAlterConfigsEntry{Operation: sarama.IncrementalAlterConfigsOperationDelete}
+	}
+	return entries
+}
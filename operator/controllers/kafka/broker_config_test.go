@@ -0,0 +1,70 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCheckBrokerConfig(t *testing.T) {
+	cr := &kafka.Kafka{}
+	r := ReconcileKafka{cr: cr}
+	cr.Spec.Config = map[string]string{"log.retention.ms": "1000", "auto.create.topics.enable": "false"}
+	assert.NoError(t, r.checkBrokerConfig())
+	cr.Spec.Config["ssl.client.auth"] = "required"
+	cr.Spec.Config["advertised.listeners"] = "INTERNAL://kafka:9092"
+	assert.EqualError(t, r.checkBrokerConfig(),
+		"properties [advertised.listeners ssl.client.auth] are configured by operator and cannot be specified in Config")
+}
+
+func TestNewBrokerConfigEntries(t *testing.T) {
+	config := map[string]string{"log.retention.ms": "1000", "leader.replication.throttled.rate": "1048576"}
+	dynamicConfigs := []string{"leader.replication.throttled.rate", "log.retention.ms"}
+	removedConfigs := getRemovedConfigs([]string{"log.retention.ms", "min.insync.replicas", "follower.replication.throttled.rate"}, config)
+	assert.Equal(t, []string{"min.insync.replicas", "follower.replication.throttled.rate"}, removedConfigs)
+
+	entries := newBrokerConfigEntries(config, dynamicConfigs, removedConfigs, "cluster-wide")
+	assert.Len(t, entries, 2)
+	assert.Equal(t, sarama.IncrementalAlterConfigsOperationSet, entries["log.retention.ms"].Operation)
+	assert.Equal(t, "1000", *entries["log.retention.ms"].Value)
+	assert.Equal(t, sarama.IncrementalAlterConfigsOperationDelete, entries["min.insync.replicas"].Operation)
+
+	entries = newBrokerConfigEntries(config, dynamicConfigs, removedConfigs, "per-broker")
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "1048576", *entries["leader.replication.throttled.rate"].Value)
+	assert.Equal(t, sarama.IncrementalAlterConfigsOperationDelete, entries["follower.replication.throttled.rate"].Operation)
+}
+
+func TestFilterPendingRestartConfigs(t *testing.T) {
+	newPod := func(envs ...corev1.EnvVar) corev1.Pod {
+		return corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "kafka", Env: envs}}}}
+	}
+	config := map[string]string{"auto.create.topics.enable": "false", "log.retention.hours": "24"}
+	staticConfigs := []string{"auto.create.topics.enable", "log.retention.hours"}
+	pods := []corev1.Pod{
+		newPod(corev1.EnvVar{Name: "CONF_KAFKA_AUTO_CREATE_TOPICS_ENABLE", Value: "false"},
+			corev1.EnvVar{Name: "CONF_KAFKA_LOG_RETENTION_HOURS", Value: "24"}),
+		newPod(corev1.EnvVar{Name: "CONF_KAFKA_AUTO_CREATE_TOPICS_ENABLE", Value: "false"},
+			corev1.EnvVar{Name: "CONF_KAFKA_LOG_RETENTION_HOURS", Value: "168"}),
+	}
+	assert.Equal(t, []string{"log.retention.hours"}, filterPendingRestartConfigs(config, staticConfigs, pods))
+	assert.Empty(t, filterPendingRestartConfigs(config, staticConfigs, pods[:1]))
+	assert.Equal(t, staticConfigs, filterPendingRestartConfigs(config, staticConfigs, []corev1.Pod{newPod()}))
+}
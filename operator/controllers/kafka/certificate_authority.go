@@ -0,0 +1,360 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"sort"
+	"time"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	caCertKey    = "ca.crt"
+	caKeyKey     = "ca.key"
+	oldCaCertKey = "ca-old.crt"
+	// certificateRenewalCheckInterval is the period of checking expiry of certificates issued by operator
+	certificateRenewalCheckInterval = time.Hour
+	day                             = 24 * time.Hour
+)
+
+// certificateAuthority is a CA generated by operator, the previous CA is trusted until it expires
+type certificateAuthority struct {
+	cert       *x509.Certificate
+	certPem    []byte
+	keyPem     []byte
+	oldCertPem []byte
+}
+
+// trustedCerts returns PEM bundle of current and previous CA certificates
+func (ca certificateAuthority) trustedCerts() []byte {
+	return append(append([]byte{}, ca.certPem...), ca.oldCertPem...)
+}
+
+// reconcileCertificateAuthority generates cluster and clients CAs, issues certificates of brokers, dedicated controllers
+// and operator client, renews them before expiry and exposes their expiry dates in status
+func (r ReconcileKafka) reconcileCertificateAuthority(brokerIds []int) error {
+	if !r.kafkaProvider.IsCertificateAuthorityEnabled() {
+		return nil
+	}
+	clusterCa, clientsCa, err := r.reconcileCas()
+	if err != nil {
+		return err
+	}
+	status := kafka.CertificatesStatus{
+		ClusterCaNotAfter: clusterCa.cert.NotAfter.Format(time.RFC3339),
+		ClientsCaNotAfter: clientsCa.cert.NotAfter.Format(time.RFC3339),
+	}
+	appendStatus := func(secret *corev1.Secret) error {
+		cert, err := parseCertificate(secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			return err
+		}
+		status.Certificates = append(status.Certificates,
+			kafka.CertificateExpiry{SecretName: secret.Name, NotAfter: cert.NotAfter.Format(time.RFC3339)})
+		return nil
+	}
+	if r.cr.Spec.Kraft.Enabled && !r.cr.Spec.Kraft.Migration && r.kafkaProvider.IsQuorumControllersEnabled() {
+		for controllerIndex := 1; controllerIndex <= r.cr.Spec.Controllers.Replicas; controllerIndex++ {
+			secret, err := r.issueQuorumControllerCertificate(controllerIndex, clusterCa, clientsCa)
+			if err != nil {
+				return err
+			}
+			if err = appendStatus(secret); err != nil {
+				return err
+			}
+		}
+	}
+	for _, brokerId := range brokerIds {
+		secret, err := r.issueBrokerCertificate(brokerId, clusterCa, clientsCa)
+		if err != nil {
+			return err
+		}
+		if err = appendStatus(secret); err != nil {
+			return err
+		}
+	}
+	clientSecret, err := r.reconcileIssuedCertificate(r.kafkaProvider.GetClientCertificateSecretName(),
+		fmt.Sprintf("%s-operator", r.cr.Name), nil, nil, clientsCa, clusterCa.trustedCerts())
+	if err != nil {
+		return err
+	}
+	if err = appendStatus(clientSecret); err != nil {
+		return err
+	}
+	return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
+		instance.Status.CertificatesStatus = status
+	})
+}
+
+// reconcileCas returns cluster CA which signs certificates of brokers and clients CA which signs certificates of clients
+func (r ReconcileKafka) reconcileCas() (certificateAuthority, certificateAuthority, error) {
+	clusterCa, err := r.reconcileCa(r.kafkaProvider.GetClusterCaSecretName(), fmt.Sprintf("%s-cluster-ca", r.cr.Name))
+	if err != nil {
+		return certificateAuthority{}, certificateAuthority{}, err
+	}
+	clientsCa, err := r.reconcileCa(r.kafkaProvider.GetClientsCaSecretName(), fmt.Sprintf("%s-clients-ca", r.cr.Name))
+	return clusterCa, clientsCa, err
+}
+
+// reconcileCa loads CA from secret or generates new one if it does not exist or expires soon.
+// The previous CA certificate is kept in secret until its expiry, so certificates signed by it are still trusted.
+func (r ReconcileKafka) reconcileCa(secretName string, commonName string) (certificateAuthority, error) {
+	secret, err := r.reconciler.FindSecret(secretName, r.cr.Namespace, r.logger)
+	if err != nil && !errors.IsNotFound(err) {
+		return certificateAuthority{}, err
+	}
+	renewBefore := time.Duration(r.kafkaProvider.GetCertificateRenewalDays()) * day
+	var oldCertPem []byte
+	if err == nil {
+		ca := certificateAuthority{certPem: secret.Data[caCertKey], keyPem: secret.Data[caKeyKey]}
+		if ca.cert, err = parseCertificate(ca.certPem); err == nil && len(ca.keyPem) > 0 && time.Until(ca.cert.NotAfter) > renewBefore {
+			if oldCert, err := parseCertificate(secret.Data[oldCaCertKey]); err == nil && time.Now().Before(oldCert.NotAfter) {
+				ca.oldCertPem = secret.Data[oldCaCertKey]
+			}
+			if bytes.Equal(ca.oldCertPem, secret.Data[oldCaCertKey]) {
+				return ca, nil
+			}
+			r.logger.Info(fmt.Sprintf("Previous CA certificate in secret '%s' is expired, removing it", secretName))
+			return ca, r.saveCa(secretName, ca)
+		}
+		if ca.cert != nil && time.Now().Before(ca.cert.NotAfter) {
+			oldCertPem = ca.certPem
+		}
+		r.logger.Info(fmt.Sprintf("CA certificate in secret '%s' is absent or expires soon, generating new one", secretName))
+	}
+	certPem, keyPem, err := generateCa(commonName, time.Duration(r.kafkaProvider.GetCaValidityDays())*day)
+	if err != nil {
+		return certificateAuthority{}, err
+	}
+	ca := certificateAuthority{certPem: certPem, keyPem: keyPem, oldCertPem: oldCertPem}
+	if ca.cert, err = parseCertificate(certPem); err != nil {
+		return certificateAuthority{}, err
+	}
+	return ca, r.saveCa(secretName, ca)
+}
+
+func (r ReconcileKafka) saveCa(secretName string, ca certificateAuthority) error {
+	data := map[string][]byte{caCertKey: ca.certPem, caKeyKey: ca.keyPem}
+	if len(ca.oldCertPem) > 0 {
+		data[oldCaCertKey] = ca.oldCertPem
+	}
+	return r.saveCertificateSecret(r.kafkaProvider.NewCertificateSecret(secretName, data))
+}
+
+func (r ReconcileKafka) saveCertificateSecret(secret *corev1.Secret) error {
+	if err := r.reconciler.SetControllerReference(r.cr, secret, r.reconciler.Scheme); err != nil {
+		return err
+	}
+	return r.reconciler.CreateOrUpdateSecret(secret, r.logger)
+}
+
+func (r ReconcileKafka) issueBrokerCertificate(brokerId int, clusterCa certificateAuthority, clientsCa certificateAuthority) (*corev1.Secret, error) {
+	dnsNames, ipAddresses := r.kafkaProvider.GetBrokerCertificateNames(brokerId)
+	deploymentName := fmt.Sprintf("%s-%d", r.cr.Name, brokerId)
+	return r.reconcileIssuedCertificate(r.kafkaProvider.GetCertificateSecretName(deploymentName), deploymentName,
+		dnsNames, ipAddresses, clusterCa, append(clusterCa.trustedCerts(), clientsCa.trustedCerts()...))
+}
+
+func (r ReconcileKafka) issueQuorumControllerCertificate(controllerIndex int, clusterCa certificateAuthority, clientsCa certificateAuthority) (*corev1.Secret, error) {
+	dnsNames, ipAddresses := r.kafkaProvider.GetQuorumControllerCertificateNames(controllerIndex)
+	deploymentName := r.kafkaProvider.GetQuorumControllerName(controllerIndex)
+	return r.reconcileIssuedCertificate(r.kafkaProvider.GetCertificateSecretName(deploymentName), deploymentName,
+		dnsNames, ipAddresses, clusterCa, append(clusterCa.trustedCerts(), clientsCa.trustedCerts()...))
+}
+
+// reconcileIssuedCertificate issues certificate signed by CA if it does not exist, is signed by another CA,
+// has different names or expires soon, and stores it in secret along with trusted CA certificates
+func (r ReconcileKafka) reconcileIssuedCertificate(secretName string, commonName string, dnsNames []string, ipAddresses []string,
+	ca certificateAuthority, trustedCerts []byte) (*corev1.Secret, error) {
+	secret, err := r.reconciler.FindSecret(secretName, r.cr.Namespace, r.logger)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	renewBefore := time.Duration(r.kafkaProvider.GetCertificateRenewalDays()) * day
+	certPem, keyPem := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+	renewalRequired := err != nil || len(keyPem) == 0 || isCertificateRenewalRequired(certPem, dnsNames, ipAddresses, ca.cert, renewBefore)
+	if !renewalRequired && bytes.Equal(secret.Data[caCertKey], trustedCerts) {
+		return secret, nil
+	}
+	if renewalRequired {
+		r.logger.Info(fmt.Sprintf("Issuing certificate for secret '%s'", secretName))
+		validity := time.Duration(r.kafkaProvider.GetCertificateValidityDays()) * day
+		if certPem, keyPem, err = issueCertificate(commonName, dnsNames, ipAddresses, validity, ca); err != nil {
+			return nil, err
+		}
+	}
+	newSecret := r.kafkaProvider.NewCertificateSecret(secretName, map[string][]byte{
+		corev1.TLSCertKey:       certPem,
+		corev1.TLSPrivateKeyKey: keyPem,
+		caCertKey:               trustedCerts,
+	})
+	newSecret.ResourceVersion = secret.ResourceVersion
+	if err = r.saveCertificateSecret(newSecret); err != nil {
+		return nil, err
+	}
+	return newSecret, nil
+}
+
+// getCertificateAuthoritySslCertificates returns certificates used by operator to connect to Kafka,
+// both current and previous cluster CAs are trusted during CA rotation
+func (r ReconcileKafka) getCertificateAuthoritySslCertificates() (*controllers.SslCertificates, error) {
+	clusterCaSecret, err := r.reconciler.FindSecret(r.kafkaProvider.GetClusterCaSecretName(), r.cr.Namespace, r.logger)
+	if err != nil {
+		return nil, err
+	}
+	clientSecret, err := r.reconciler.FindSecret(r.kafkaProvider.GetClientCertificateSecretName(), r.cr.Namespace, r.logger)
+	if err != nil {
+		return nil, err
+	}
+	sslCertificates := &controllers.SslCertificates{
+		CaCert:  clusterCaSecret.Data[caCertKey],
+		TlsCert: clientSecret.Data[corev1.TLSCertKey],
+		TlsKey:  clientSecret.Data[corev1.TLSPrivateKeyKey],
+	}
+	if oldCaCert := clusterCaSecret.Data[oldCaCertKey]; len(oldCaCert) > 0 {
+		sslCertificates.AdditionalCaCerts = [][]byte{oldCaCert}
+	}
+	return sslCertificates, nil
+}
+
+// isCertificateRenewalRequired checks if certificate is invalid, is not signed by CA, expires soon or has different names
+func isCertificateRenewalRequired(certPem []byte, dnsNames []string, ipAddresses []string, ca *x509.Certificate, renewBefore time.Duration) bool {
+	cert, err := parseCertificate(certPem)
+	if err != nil || cert.CheckSignatureFrom(ca) != nil || time.Until(cert.NotAfter) < renewBefore {
+		return true
+	}
+	var certIpAddresses []string
+	for _, ip := range cert.IPAddresses {
+		certIpAddresses = append(certIpAddresses, ip.String())
+	}
+	return !equalNames(cert.DNSNames, dnsNames) || !equalNames(certIpAddresses, ipAddresses)
+}
+
+func equalNames(first []string, second []string) bool {
+	if len(first) != len(second) {
+		return false
+	}
+	first = append([]string{}, first...)
+	second = append([]string{}, second...)
+	sort.Strings(first)
+	sort.Strings(second)
+	for i := range first {
+		if first[i] != second[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func parseCertificate(certPem []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPem)
+	if block == nil {
+		return nil, fmt.Errorf("certificate is not in PEM format")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// generateCa returns self-signed CA certificate and its private key in PEM format
+func generateCa(commonName string, validity time.Duration) ([]byte, []byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+	template, err := newCertificateTemplate(commonName, validity)
+	if err != nil {
+		return nil, nil, err
+	}
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+	certDer, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encodeCertificate(certDer), encodePrivateKey(key), nil
+}
+
+// issueCertificate returns certificate signed by CA and its private key in PEM format,
+// the certificate is valid for both server and client authentication and does not outlive CA
+func issueCertificate(commonName string, dnsNames []string, ipAddresses []string, validity time.Duration,
+	ca certificateAuthority) ([]byte, []byte, error) {
+	caKeyBlock, _ := pem.Decode(ca.keyPem)
+	if caKeyBlock == nil {
+		return nil, nil, fmt.Errorf("CA private key is not in PEM format")
+	}
+	caKey, err := x509.ParsePKCS1PrivateKey(caKeyBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+	template, err := newCertificateTemplate(commonName, validity)
+	if err != nil {
+		return nil, nil, err
+	}
+	if template.NotAfter.After(ca.cert.NotAfter) {
+		template.NotAfter = ca.cert.NotAfter
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	template.DNSNames = dnsNames
+	for _, ipAddress := range ipAddresses {
+		if ip := net.ParseIP(ipAddress); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		}
+	}
+	certDer, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encodeCertificate(certDer), encodePrivateKey(key), nil
+}
+
+func newCertificateTemplate(commonName string, validity time.Duration) (*x509.Certificate, error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(validity),
+	}, nil
+}
+
+func encodeCertificate(certDer []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDer})
+}
+
+func encodePrivateKey(key *rsa.PrivateKey) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
@@ -0,0 +1,115 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newTestCertificateAuthority(t *testing.T, validity time.Duration) certificateAuthority {
+	certPem, keyPem, err := generateCa("kafka-cluster-ca", validity)
+	assert.NoError(t, err)
+	cert, err := parseCertificate(certPem)
+	assert.NoError(t, err)
+	return certificateAuthority{cert: cert, certPem: certPem, keyPem: keyPem}
+}
+
+func TestIssueCertificate(t *testing.T) {
+	ca := newTestCertificateAuthority(t, 10*day)
+	certPem, keyPem, err := issueCertificate("kafka-1", []string{"kafka-1", "kafka-1.kafka-service"}, []string{"127.0.0.1"}, 365*day, ca)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, keyPem)
+	cert, err := parseCertificate(certPem)
+	assert.NoError(t, err)
+	assert.NoError(t, cert.CheckSignatureFrom(ca.cert))
+	assert.Equal(t, "kafka-1", cert.Subject.CommonName)
+	// certificate does not outlive CA
+	assert.False(t, cert.NotAfter.After(ca.cert.NotAfter))
+
+	assert.False(t, isCertificateRenewalRequired(certPem, []string{"kafka-1.kafka-service", "kafka-1"}, []string{"127.0.0.1"}, ca.cert, day))
+	assert.True(t, isCertificateRenewalRequired(certPem, []string{"kafka-1"}, []string{"127.0.0.1"}, ca.cert, day))
+	assert.True(t, isCertificateRenewalRequired(certPem, []string{"kafka-1", "kafka-1.kafka-service"}, []string{"10.0.0.1"}, ca.cert, day))
+	assert.True(t, isCertificateRenewalRequired(certPem, []string{"kafka-1", "kafka-1.kafka-service"}, []string{"127.0.0.1"}, ca.cert, 20*day))
+	anotherCa := newTestCertificateAuthority(t, 10*day)
+	assert.True(t, isCertificateRenewalRequired(certPem, []string{"kafka-1", "kafka-1.kafka-service"}, []string{"127.0.0.1"}, anotherCa.cert, day))
+	assert.True(t, isCertificateRenewalRequired([]byte("invalid"), nil, nil, ca.cert, day))
+}
+
+func newTestCertificateAuthorityReconcile(t *testing.T) (*ReconcileKafka, *kafka.Kafka) {
+	spec := kafka.KafkaSpec{Replicas: 2}
+	spec.Ssl.Enabled = true
+	spec.Ssl.CertificateAuthority.Enabled = true
+	return newTestReconcileKafka(t, spec)
+}
+
+func TestReconcileCertificateAuthority(t *testing.T) {
+	r, cr := newTestCertificateAuthorityReconcile(t)
+	assert.NoError(t, r.reconcileCertificateAuthority([]int{1, 2}))
+
+	getSecret := func(name string) *corev1.Secret {
+		secret := &corev1.Secret{}
+		assert.NoError(t, r.reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: cr.Namespace}, secret))
+		return secret
+	}
+	clusterCaSecret := getSecret("kafka-cluster-ca")
+	clientsCaSecret := getSecret("kafka-clients-ca")
+	brokerSecret := getSecret("kafka-2-tls-cert")
+	clusterCa, err := parseCertificate(clusterCaSecret.Data[caCertKey])
+	assert.NoError(t, err)
+	brokerCert, err := parseCertificate(brokerSecret.Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	assert.NoError(t, brokerCert.CheckSignatureFrom(clusterCa))
+	assert.Contains(t, brokerCert.DNSNames, "kafka-2.kafka-broker.kafka-service")
+	assert.Equal(t, append(append([]byte{}, clusterCaSecret.Data[caCertKey]...), clientsCaSecret.Data[caCertKey]...),
+		brokerSecret.Data[caCertKey])
+	clientCert, err := parseCertificate(getSecret("kafka-client-tls-cert").Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	clientsCa, err := parseCertificate(clientsCaSecret.Data[caCertKey])
+	assert.NoError(t, err)
+	assert.NoError(t, clientCert.CheckSignatureFrom(clientsCa))
+
+	status, err := r.reconciler.StatusUpdater.GetStatus()
+	assert.NoError(t, err)
+	assert.Equal(t, clusterCa.NotAfter.Format(time.RFC3339), status.CertificatesStatus.ClusterCaNotAfter)
+	assert.Equal(t, 3, len(status.CertificatesStatus.Certificates))
+
+	// certificates are not reissued if they are valid
+	assert.NoError(t, r.reconcileCertificateAuthority([]int{1, 2}))
+	assert.Equal(t, brokerSecret.Data[corev1.TLSCertKey], getSecret("kafka-2-tls-cert").Data[corev1.TLSCertKey])
+
+	// CA which expires soon is rotated, previous CA is trusted until its expiry
+	cr.Spec.Ssl.CertificateAuthority.CaValidityDays = 3650
+	cr.Spec.Ssl.CertificateAuthority.RenewalDays = 1830
+	assert.NoError(t, r.reconcileCertificateAuthority([]int{1, 2}))
+	rotatedClusterCaSecret := getSecret("kafka-cluster-ca")
+	assert.NotEqual(t, clusterCaSecret.Data[caCertKey], rotatedClusterCaSecret.Data[caCertKey])
+	assert.Equal(t, clusterCaSecret.Data[caCertKey], rotatedClusterCaSecret.Data[oldCaCertKey])
+	rotatedClusterCa, err := parseCertificate(rotatedClusterCaSecret.Data[caCertKey])
+	assert.NoError(t, err)
+	brokerCert, err = parseCertificate(getSecret("kafka-2-tls-cert").Data[corev1.TLSCertKey])
+	assert.NoError(t, err)
+	assert.NoError(t, brokerCert.CheckSignatureFrom(rotatedClusterCa))
+
+	sslCertificates, err := r.getCertificateAuthoritySslCertificates()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{clusterCaSecret.Data[caCertKey]}, sslCertificates.AdditionalCaCerts)
+}
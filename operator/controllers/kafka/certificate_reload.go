@@ -0,0 +1,170 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	keystoreScript                     = "${KAFKA_HOME}/bin/kafka-keystore.sh"
+	keystorePendingOutput              = "PENDING"
+	reloadedResourceVersionTemplate    = "%s/reloaded-resource-version"
+	listenerSecurityProtocolMapConfig  = "listener.security.protocol.map"
+	listenerKeystoreLocationConfigName = "listener.name.%s.ssl.keystore.location"
+	// certificateReloadTimeout covers the delay of mounted secret update by kubelet
+	certificateReloadTimeout = 180 * time.Second
+)
+
+// reloadBrokerCertificate makes broker to reload renewed certificate without restart.
+// The broker rebuilds its keystore from the mounted secret and the new keystore location is set
+// for each SSL listener with AlterConfigs request. CA changes are not reloaded and require broker restart.
+// Only dedicated broker certificates issued by cert-manager or operator CA are reloaded,
+// changes of the common TLS secret from spec are not tracked by the operator.
+func (r ReconcileKafka) reloadBrokerCertificate(brokerId int) error {
+	deploymentName := fmt.Sprintf("%s-%d", r.cr.Name, brokerId)
+	secretName := r.kafkaProvider.GetCertificateSecretName(deploymentName)
+	secret, err := r.reconciler.FindSecret(secretName, r.cr.Namespace, r.logger)
+	if err != nil {
+		return err
+	}
+	keystoreLocation, err := r.rebuildBrokerKeystore(deploymentName, secret)
+	if err != nil {
+		return err
+	}
+	adminClient, err := r.newKafkaAdminClient()
+	if err != nil {
+		return err
+	}
+	defer adminClient.Close()
+	if err = alterKeystoreLocation(adminClient, brokerId, keystoreLocation); err != nil {
+		return err
+	}
+	deployment, err := r.reconciler.FindDeployment(deploymentName, r.cr.Namespace, r.logger)
+	if err != nil {
+		return err
+	}
+	if deployment.Annotations == nil {
+		deployment.Annotations = map[string]string{}
+	}
+	deployment.Annotations[fmt.Sprintf(reloadedResourceVersionTemplate, secretName)] = secret.ResourceVersion
+	return r.reconciler.Client.Update(context.TODO(), deployment)
+}
+
+// rebuildBrokerKeystore creates new keystore in broker pod as soon as mounted secret contains renewed certificate
+func (r ReconcileKafka) rebuildBrokerKeystore(deploymentName string, secret *corev1.Secret) (string, error) {
+	labels := r.kafkaProvider.GetSelectorLabels()
+	labels["name"] = deploymentName
+	podList, err := r.reconciler.FindPodList(r.cr.Namespace, labels)
+	if err != nil {
+		return "", err
+	}
+	pod := controllers.GetFirstAvailablePod(podList)
+	if pod == nil {
+		return "", fmt.Errorf("there is no available pod for %s", deploymentName)
+	}
+	checksum := sha256.Sum256(secret.Data[corev1.TLSCertKey])
+	command := []string{keystoreScript, "rebuild", secret.ResourceVersion, hex.EncodeToString(checksum[:])}
+	var keystoreLocation string
+	err = wait.PollImmediate(waitingInterval, certificateReloadTimeout, func() (bool, error) {
+		output, err := r.runCommandInPod(pod.Name, "kafka", r.cr.Namespace, []string{"/bin/sh", "-c", strings.Join(command, " ")})
+		if err != nil {
+			return false, err
+		}
+		keystoreLocation = strings.TrimSpace(output)
+		return keystoreLocation != keystorePendingOutput, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to rebuild keystore in pod %s: %v", pod.Name, err)
+	}
+	return keystoreLocation, nil
+}
+
+// alterKeystoreLocation sets new keystore location for all SSL listeners of the broker
+func alterKeystoreLocation(adminClient sarama.ClusterAdmin, brokerId int, keystoreLocation string) error {
+	brokerName := strconv.Itoa(brokerId)
+	entries, err := adminClient.DescribeConfig(sarama.ConfigResource{
+		Type:        sarama.BrokerResource,
+		Name:        brokerName,
+		ConfigNames: []string{listenerSecurityProtocolMapConfig},
+	})
+	if err != nil {
+		return err
+	}
+	var protocolMap string
+	for _, entry := range entries {
+		if entry.Name == listenerSecurityProtocolMapConfig {
+			protocolMap = entry.Value
+		}
+	}
+	listeners := getSslListeners(protocolMap)
+	if len(listeners) == 0 {
+		return fmt.Errorf("there are no SSL listeners in '%s' of broker %d", protocolMap, brokerId)
+	}
+	configs := make(map[string]sarama.IncrementalAlterConfigsEntry, len(listeners))
+	for _, listener := range listeners {
+		configs[fmt.Sprintf(listenerKeystoreLocationConfigName, listener)] = sarama.IncrementalAlterConfigsEntry{
+			Operation: sarama.IncrementalAlterConfigsOperationSet,
+			Value:     &keystoreLocation,
+		}
+	}
+	return adminClient.IncrementalAlterConfig(sarama.BrokerResource, brokerName, configs, false)
+}
+
+// getSslListeners returns names of listeners with SSL or SASL_SSL security protocol in lower case
+func getSslListeners(protocolMap string) []string {
+	var listeners []string
+	for _, pair := range strings.Split(protocolMap, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if parts[1] == "SSL" || parts[1] == "SASL_SSL" {
+			listeners = append(listeners, strings.ToLower(parts[0]))
+		}
+	}
+	return listeners
+}
+
+// addCertificateAnnotation adds resource version of certificate secret to deployment template,
+// so that deployment is restarted when the certificate is renewed. If the certificate was reloaded
+// dynamically, the previous resource version is kept to avoid needless restart.
+func (r ReconcileKafka) addCertificateAnnotation(deployment *appsv1.Deployment, secret *corev1.Secret) {
+	annotationName := fmt.Sprintf(resourceVersionAnnotationTemplate, secret.Name)
+	reloadedAnnotationName := fmt.Sprintf(reloadedResourceVersionTemplate, secret.Name)
+	foundDeployment, err := r.reconciler.FindDeployment(deployment.Name, deployment.Namespace, r.logger)
+	if err == nil && foundDeployment.Annotations[reloadedAnnotationName] == secret.ResourceVersion &&
+		foundDeployment.Spec.Template.Annotations[annotationName] != "" {
+		if deployment.Annotations == nil {
+			deployment.Annotations = map[string]string{}
+		}
+		deployment.Annotations[reloadedAnnotationName] = secret.ResourceVersion
+		r.addDeploymentAnnotation(deployment, annotationName, foundDeployment.Spec.Template.Annotations[annotationName])
+		return
+	}
+	r.addDeploymentAnnotation(deployment, annotationName, secret.ResourceVersion)
+}
@@ -0,0 +1,69 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetSslListeners(t *testing.T) {
+	assert.Equal(t, []string{"internal", "inter_broker"},
+		getSslListeners("INTERNAL:SASL_SSL,INTER_BROKER:SSL,NONENCRYPTED:SASL_PLAINTEXT"))
+	assert.Empty(t, getSslListeners("INTERNAL:SASL_PLAINTEXT,INTER_BROKER:PLAINTEXT"))
+	assert.Empty(t, getSslListeners(""))
+}
+
+func TestCertificateReloadAnnotations(t *testing.T) {
+	r, cr := newTestCertificateAuthorityReconcile(t)
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "kafka-1-tls-cert", Namespace: cr.Namespace}}
+	assert.NoError(t, r.reconciler.Client.Create(context.TODO(), secret))
+	newDeployment := func() *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "kafka-1", Namespace: cr.Namespace}}
+	}
+
+	deployment := newDeployment()
+	r.addCertificateAnnotation(deployment, secret)
+	assert.Equal(t, secret.ResourceVersion, deployment.Spec.Template.Annotations["kafka-1-tls-cert/resource-version"])
+	assert.NoError(t, r.reconciler.Client.Create(context.TODO(), deployment))
+	renewed, err := r.isCertificateRenewed("kafka-1")
+	assert.NoError(t, err)
+	assert.False(t, renewed)
+
+	rolledOutVersion := secret.ResourceVersion
+	secret.Data = map[string][]byte{corev1.TLSCertKey: []byte("renewed")}
+	assert.NoError(t, r.reconciler.Client.Update(context.TODO(), secret))
+	renewed, err = r.isCertificateRenewed("kafka-1")
+	assert.NoError(t, err)
+	assert.True(t, renewed)
+
+	// certificate is reloaded dynamically, so deployment template must not be changed
+	deployment, err = r.reconciler.FindDeployment("kafka-1", cr.Namespace, r.logger)
+	assert.NoError(t, err)
+	deployment.Annotations = map[string]string{"kafka-1-tls-cert/reloaded-resource-version": secret.ResourceVersion}
+	assert.NoError(t, r.reconciler.Client.Update(context.TODO(), deployment))
+	renewed, err = r.isCertificateRenewed("kafka-1")
+	assert.NoError(t, err)
+	assert.False(t, renewed)
+	deployment = newDeployment()
+	r.addCertificateAnnotation(deployment, secret)
+	assert.Equal(t, rolledOutVersion, deployment.Spec.Template.Annotations["kafka-1-tls-cert/resource-version"])
+	assert.Equal(t, secret.ResourceVersion, deployment.Annotations["kafka-1-tls-cert/reloaded-resource-version"])
+}
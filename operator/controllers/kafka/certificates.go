@@ -0,0 +1,197 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"time"
+
+	certmanagerv1 "github.com/Netcracker/qubership-kafka/operator/api/certmanager/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const certificateIssuingTimeout = 300 * time.Second
+
+// checkCertManager checks that issuer and validity periods of certificates issued by cert-manager are correct
+func (r *ReconcileKafka) checkCertManager() error {
+	certManager := r.cr.Spec.Ssl.CertManager
+	if !certManager.Enabled {
+		return nil
+	}
+	if r.cr.Spec.Ssl.CertificateAuthority.Enabled {
+		return fmt.Errorf("Ssl.CertManager.Enabled and Ssl.CertificateAuthority.Enabled cannot be true at the same time")
+	}
+	if !r.cr.Spec.Ssl.Enabled {
+		return fmt.Errorf("when Ssl.CertManager.Enabled=true, Ssl.Enabled must be true")
+	}
+	if certManager.IssuerRef.Name == "" {
+		return fmt.Errorf("when Ssl.CertManager.Enabled=true, Ssl.CertManager.IssuerRef.Name must be specified")
+	}
+	var duration, renewBefore time.Duration
+	var err error
+	if certManager.Duration != "" {
+		if duration, err = time.ParseDuration(certManager.Duration); err != nil {
+			return fmt.Errorf("Ssl.CertManager.Duration '%s' is invalid: %v", certManager.Duration, err)
+		}
+	}
+	if certManager.RenewBefore != "" {
+		if renewBefore, err = time.ParseDuration(certManager.RenewBefore); err != nil {
+			return fmt.Errorf("Ssl.CertManager.RenewBefore '%s' is invalid: %v", certManager.RenewBefore, err)
+		}
+	}
+	if duration > 0 && renewBefore >= duration {
+		return fmt.Errorf("Ssl.CertManager.RenewBefore must be less than Ssl.CertManager.Duration")
+	}
+	return nil
+}
+
+// checkCertificateAuthority checks that validity periods of certificates issued by operator are correct
+func (r *ReconcileKafka) checkCertificateAuthority() error {
+	if !r.cr.Spec.Ssl.CertificateAuthority.Enabled {
+		return nil
+	}
+	if !r.cr.Spec.Ssl.Enabled {
+		return fmt.Errorf("when Ssl.CertificateAuthority.Enabled=true, Ssl.Enabled must be true")
+	}
+	renewalDays := r.kafkaProvider.GetCertificateRenewalDays()
+	if renewalDays >= r.kafkaProvider.GetCertificateValidityDays() || renewalDays >= r.kafkaProvider.GetCaValidityDays() {
+		return fmt.Errorf("Ssl.CertificateAuthority.RenewalDays must be less than validity days of certificates and CAs")
+	}
+	return nil
+}
+
+// reconcileBrokerCertificate returns secret with certificate of broker issued by cert-manager or operator
+func (r ReconcileKafka) reconcileBrokerCertificate(brokerId int) (*corev1.Secret, error) {
+	if r.kafkaProvider.IsCertManagerEnabled() {
+		return r.reconcileCertificate(r.kafkaProvider.NewKafkaBrokerCertificateForCR(brokerId))
+	}
+	clusterCa, clientsCa, err := r.reconcileCas()
+	if err != nil {
+		return nil, err
+	}
+	return r.issueBrokerCertificate(brokerId, clusterCa, clientsCa)
+}
+
+// reconcileQuorumControllerCertificate returns secret with certificate of dedicated controller issued by cert-manager or operator
+func (r ReconcileKafka) reconcileQuorumControllerCertificate(controllerIndex int) (*corev1.Secret, error) {
+	if r.kafkaProvider.IsCertManagerEnabled() {
+		return r.reconcileCertificate(r.kafkaProvider.NewKafkaQuorumControllerCertificateForCR(controllerIndex))
+	}
+	clusterCa, clientsCa, err := r.reconcileCas()
+	if err != nil {
+		return nil, err
+	}
+	return r.issueQuorumControllerCertificate(controllerIndex, clusterCa, clientsCa)
+}
+
+// reconcileCertificate creates or updates cert-manager certificate and waits until its secret is issued.
+// The secret is owned by custom resource, so its renewal triggers reconciliation.
+func (r ReconcileKafka) reconcileCertificate(certificate *certmanagerv1.Certificate) (*corev1.Secret, error) {
+	if err := r.reconciler.SetControllerReference(r.cr, certificate, r.reconciler.Scheme); err != nil {
+		return nil, err
+	}
+	if err := r.reconciler.CreateOrUpdateCertificate(certificate, r.logger); err != nil {
+		return nil, err
+	}
+	secretName := certificate.Spec.SecretName
+	err := wait.PollImmediate(waitingInterval, certificateIssuingTimeout, func() (bool, error) {
+		secret, err := r.reconciler.FindSecret(secretName, r.cr.Namespace, r.logger)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				r.logger.Info(fmt.Sprintf("Secret '%s' is not issued yet", secretName))
+				return false, nil
+			}
+			return false, err
+		}
+		return len(secret.Data[corev1.TLSCertKey]) > 0 && len(secret.Data[corev1.TLSPrivateKeyKey]) > 0, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("certificate '%s' is not issued by cert-manager: %v", certificate.Name, err)
+	}
+	return r.reconciler.WatchSecret(secretName, r.cr, r.logger)
+}
+
+// rolloutWithRenewedCertificates restarts dedicated controllers and brokers one by one
+// if their certificates were renewed by cert-manager or operator after the last rollout.
+// Brokers reload renewed certificates dynamically and are restarted only if the reload fails.
+func (r ReconcileKafka) rolloutWithRenewedCertificates(brokerIds []int, kafkaSecret *corev1.Secret) error {
+	if !r.kafkaProvider.IsDeploymentCertificatesEnabled() {
+		return nil
+	}
+	kraft := r.cr.Spec.Kraft.Enabled && !r.cr.Spec.Kraft.Migration
+	if kraft && r.kafkaProvider.IsQuorumControllersEnabled() {
+		for controllerIndex := 1; controllerIndex <= r.cr.Spec.Controllers.Replicas; controllerIndex++ {
+			renewed, err := r.isCertificateRenewed(r.kafkaProvider.GetQuorumControllerName(controllerIndex))
+			if err != nil {
+				return err
+			}
+			if !renewed {
+				continue
+			}
+			r.logger.Info(fmt.Sprintf("Certificate of controller %d is renewed, updating controller", controllerIndex))
+			if err = r.rolloutQuorumController(controllerIndex, kafkaSecret); err != nil {
+				return err
+			}
+			if err = r.waitUntilQuorumControllerIsReady(controllerIndex, 300); err != nil {
+				return err
+			}
+		}
+	}
+	for _, brokerId := range brokerIds {
+		renewed, err := r.isCertificateRenewed(fmt.Sprintf("%s-%d", r.cr.Name, brokerId))
+		if err != nil {
+			return err
+		}
+		if !renewed {
+			continue
+		}
+		r.logger.Info(fmt.Sprintf("Certificate of broker %d is renewed, reloading it dynamically", brokerId))
+		if err = r.reloadBrokerCertificate(brokerId); err == nil {
+			continue
+		}
+		r.logger.Error(err, fmt.Sprintf("Unable to reload certificate of broker %d, updating broker", brokerId))
+		if err = r.rolloutBroker(brokerId, kraft, kafkaSecret); err != nil {
+			return err
+		}
+		if err = r.waitUntilBrokerIsReady(brokerId, 300); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isCertificateRenewed checks if resource version of certificate secret differs from the one deployment was rolled out
+// with or the certificate was reloaded with
+func (r ReconcileKafka) isCertificateRenewed(deploymentName string) (bool, error) {
+	deployment, err := r.reconciler.FindDeployment(deploymentName, r.cr.Namespace, r.logger)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	secretName := r.kafkaProvider.GetCertificateSecretName(deploymentName)
+	secret, err := r.reconciler.FindSecret(secretName, r.cr.Namespace, r.logger)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return deployment.Spec.Template.Annotations[fmt.Sprintf(resourceVersionAnnotationTemplate, secretName)] != secret.ResourceVersion &&
+		deployment.Annotations[fmt.Sprintf(reloadedResourceVersionTemplate, secretName)] != secret.ResourceVersion, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCertManager(t *testing.T) {
+	cr := &kafka.Kafka{}
+	r := &ReconcileKafka{cr: cr}
+	assert.NoError(t, r.checkCertManager())
+
+	cr.Spec.Ssl.CertManager.Enabled = true
+	assert.Error(t, r.checkCertManager())
+
+	cr.Spec.Ssl.Enabled = true
+	assert.Error(t, r.checkCertManager())
+
+	cr.Spec.Ssl.CertManager.IssuerRef.Name = "kafka-issuer"
+	assert.NoError(t, r.checkCertManager())
+
+	cr.Spec.Ssl.CertManager.Duration = "1y"
+	assert.Error(t, r.checkCertManager())
+
+	cr.Spec.Ssl.CertManager.Duration = "720h"
+	cr.Spec.Ssl.CertManager.RenewBefore = "720h"
+	assert.Error(t, r.checkCertManager())
+
+	cr.Spec.Ssl.CertManager.RenewBefore = "240h"
+	assert.NoError(t, r.checkCertManager())
+}
+
+func TestCheckCertificateAuthority(t *testing.T) {
+	cr := &kafka.Kafka{}
+	r := &ReconcileKafka{cr: cr, kafkaProvider: provider.NewKafkaResourceProvider(cr, logr.Discard())}
+	assert.NoError(t, r.checkCertificateAuthority())
+
+	cr.Spec.Ssl.CertificateAuthority.Enabled = true
+	assert.Error(t, r.checkCertificateAuthority())
+
+	cr.Spec.Ssl.Enabled = true
+	assert.NoError(t, r.checkCertificateAuthority())
+
+	cr.Spec.Ssl.CertificateAuthority.ValidityDays = 30
+	assert.Error(t, r.checkCertificateAuthority())
+
+	cr.Spec.Ssl.CertificateAuthority.RenewalDays = 10
+	assert.NoError(t, r.checkCertificateAuthority())
+
+	cr.Spec.Ssl.CertManager.Enabled = true
+	cr.Spec.Ssl.CertManager.IssuerRef.Name = "kafka-issuer"
+	assert.Error(t, r.checkCertManager())
+}
@@ -0,0 +1,298 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"time"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	externalAddressTimeoutSeconds = 300
+	externalServicePortName       = "external-kafka-client"
+)
+
+// checkExternalAccess checks that external access type is supported and can be combined with other parameters
+func checkExternalAccess(cr *kafka.Kafka, accessType string) error {
+	switch accessType {
+	case "":
+		return nil
+	case provider.ExternalAccessLoadBalancer, provider.ExternalAccessNodePort:
+	case provider.ExternalAccessIngress:
+		if cr.Spec.ExternalAccess.Host == "" {
+			return fmt.Errorf("when externalAccess.type=ingress, externalAccess.host must be specified")
+		}
+		if !cr.Spec.Ssl.Enabled {
+			return fmt.Errorf("when externalAccess.type=ingress, TLS must be enabled for Kafka, because ingress passes TLS connections through")
+		}
+	default:
+		return fmt.Errorf("external access type '%s' is not supported, it must be one of %s, %s, %s", cr.Spec.ExternalAccess.Type,
+			provider.ExternalAccessLoadBalancer, provider.ExternalAccessNodePort, provider.ExternalAccessIngress)
+	}
+	if len(cr.Spec.ExternalHostNames) > 0 {
+		return fmt.Errorf("externalHostNames cannot be specified together with externalAccess.type")
+	}
+	return nil
+}
+
+// getExternalServiceAddress returns address assigned to external service by Kubernetes or empty address if it is not assigned yet.
+// Node port service is accessed via the specified host, if it is empty, via the node on which the pod is running.
+func getExternalServiceAddress(accessType string, service *corev1.Service, host string, pod *corev1.Pod) (string, int32) {
+	switch accessType {
+	case provider.ExternalAccessLoadBalancer:
+		for _, ingress := range service.Status.LoadBalancer.Ingress {
+			if ingress.Hostname != "" {
+				return ingress.Hostname, getServicePort(service).Port
+			}
+			if ingress.IP != "" {
+				return ingress.IP, getServicePort(service).Port
+			}
+		}
+	case provider.ExternalAccessNodePort:
+		nodePort := getServicePort(service).NodePort
+		if host == "" && pod != nil {
+			host = pod.Status.HostIP
+		}
+		if host != "" && nodePort != 0 {
+			return host, nodePort
+		}
+	}
+	return "", 0
+}
+
+func getServicePort(service *corev1.Service) corev1.ServicePort {
+	for _, port := range service.Spec.Ports {
+		if port.Name == externalServicePortName {
+			return port
+		}
+	}
+	return corev1.ServicePort{}
+}
+
+// reconcileExternalAccess creates external services of brokers and waits until their addresses are assigned,
+// so that brokers are started with advertised external listeners
+func (r ReconcileKafka) reconcileExternalAccess(brokerIds []int) error {
+	accessType := r.kafkaProvider.GetExternalAccessType()
+	if accessType == "" {
+		return r.removeExternalAccess()
+	}
+	services := []*corev1.Service{r.kafkaProvider.NewKafkaBootstrapExternalServiceForCR()}
+	for _, brokerId := range brokerIds {
+		services = append(services, r.kafkaProvider.NewKafkaBrokerExternalServiceForCR(brokerId))
+	}
+	for _, service := range services {
+		if err := r.reconciler.SetControllerReference(r.cr, service, r.reconciler.Scheme); err != nil {
+			return err
+		}
+		if err := r.reconciler.CreateOrUpdateService(service, r.logger); err != nil {
+			return err
+		}
+	}
+	if accessType == provider.ExternalAccessIngress {
+		ingresses := []*networkingv1.Ingress{r.kafkaProvider.NewKafkaBootstrapIngressForCR()}
+		for _, brokerId := range brokerIds {
+			ingresses = append(ingresses, r.kafkaProvider.NewKafkaBrokerIngressForCR(brokerId))
+		}
+		for _, ingress := range ingresses {
+			if err := r.reconciler.SetControllerReference(r.cr, ingress, r.reconciler.Scheme); err != nil {
+				return err
+			}
+			if err := r.reconciler.CreateOrUpdateIngress(ingress, r.logger); err != nil {
+				return err
+			}
+		}
+	}
+	status, err := r.waitForExternalAddresses(brokerIds)
+	if err != nil {
+		return err
+	}
+	return r.updateExternalAccessStatus(status)
+}
+
+// waitForExternalAddresses returns external addresses of bootstrap service and brokers.
+// Load balancer addresses are awaited, node port addresses are resolved only for already running brokers.
+func (r ReconcileKafka) waitForExternalAddresses(brokerIds []int) (kafka.ExternalAccessStatus, error) {
+	var status kafka.ExternalAccessStatus
+	err := wait.PollImmediate(waitingInterval, externalAddressTimeoutSeconds*time.Second, func() (done bool, err error) {
+		status, err = r.getExternalAddresses(brokerIds)
+		if err != nil {
+			r.logger.Info(fmt.Sprintf("Cannot get external addresses of brokers: %v", err))
+			return false, nil
+		}
+		if r.kafkaProvider.GetExternalAccessType() != provider.ExternalAccessLoadBalancer {
+			return true, nil
+		}
+		for _, address := range status.Brokers {
+			if address.Host == "" {
+				r.logger.Info(fmt.Sprintf("Load balancer address is not assigned to broker %d yet", address.BrokerId))
+				return false, nil
+			}
+		}
+		return status.Bootstrap != "", nil
+	})
+	if err != nil {
+		return status, fmt.Errorf("external addresses were not assigned to Kafka brokers: %v", err)
+	}
+	return status, nil
+}
+
+func (r ReconcileKafka) getExternalAddresses(brokerIds []int) (kafka.ExternalAccessStatus, error) {
+	accessType := r.kafkaProvider.GetExternalAccessType()
+	status := kafka.ExternalAccessStatus{Type: accessType}
+	if accessType == provider.ExternalAccessIngress {
+		status.Bootstrap = fmt.Sprintf("%s:%d", r.kafkaProvider.GetBootstrapIngressHost(), provider.IngressExternalPort)
+		for _, brokerId := range brokerIds {
+			status.Brokers = append(status.Brokers, kafka.BrokerExternalAddress{
+				BrokerId: brokerId,
+				Host:     r.kafkaProvider.GetBrokerIngressHost(brokerId),
+				Port:     provider.IngressExternalPort,
+			})
+		}
+		return status, nil
+	}
+	host := r.cr.Spec.ExternalAccess.Host
+	for _, brokerId := range brokerIds {
+		service, err := r.reconciler.FindService(r.kafkaProvider.GetBrokerExternalServiceName(brokerId), r.cr.Namespace, r.logger)
+		if err != nil {
+			return status, err
+		}
+		pod, err := r.findBrokerPod(brokerId)
+		if err != nil {
+			return status, err
+		}
+		brokerHost, brokerPort := getExternalServiceAddress(accessType, service, host, pod)
+		status.Brokers = append(status.Brokers, kafka.BrokerExternalAddress{BrokerId: brokerId, Host: brokerHost, Port: brokerPort})
+		if host == "" {
+			// node port of bootstrap service is available on any node
+			host = brokerHost
+		}
+	}
+	service, err := r.reconciler.FindService(r.kafkaProvider.GetBootstrapExternalServiceName(), r.cr.Namespace, r.logger)
+	if err != nil {
+		return status, err
+	}
+	if bootstrapHost, bootstrapPort := getExternalServiceAddress(accessType, service, host, nil); bootstrapHost != "" {
+		status.Bootstrap = fmt.Sprintf("%s:%d", bootstrapHost, bootstrapPort)
+	}
+	return status, nil
+}
+
+func (r ReconcileKafka) findBrokerPod(brokerId int) (*corev1.Pod, error) {
+	labels := r.kafkaProvider.GetBrokerPodSelectorLabels(brokerId)
+	podList, err := r.reconciler.FindPodList(r.cr.Namespace, labels)
+	if err != nil {
+		return nil, err
+	}
+	for i := range podList.Items {
+		if podList.Items[i].Status.HostIP != "" {
+			return &podList.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// waitForBrokersNodes waits until brokers exposed via node ports without explicit host are running,
+// because their external addresses are addresses of nodes
+func (r ReconcileKafka) waitForBrokersNodes(brokerIds []int) error {
+	if r.kafkaProvider.GetExternalAccessType() != provider.ExternalAccessNodePort || r.cr.Spec.ExternalAccess.Host != "" {
+		return nil
+	}
+	for _, brokerId := range brokerIds {
+		if err := r.waitUntilBrokerIsReady(brokerId, 300); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rolloutBrokersWithChangedExternalAddresses updates brokers whose external addresses differ from advertised ones,
+// for example, when load balancer address is changed or broker is moved to another node
+func (r ReconcileKafka) rolloutBrokersWithChangedExternalAddresses(brokerIds []int, kafkaSecret *corev1.Secret) error {
+	accessType := r.kafkaProvider.GetExternalAccessType()
+	if accessType == "" || accessType == provider.ExternalAccessIngress {
+		return nil
+	}
+	previous := r.cr.Status.ExternalAccessStatus
+	status, err := r.getExternalAddresses(brokerIds)
+	if err != nil {
+		return err
+	}
+	if err = r.updateExternalAccessStatus(status); err != nil {
+		return err
+	}
+	kraft := r.cr.Spec.Kraft.Enabled && !r.cr.Spec.Kraft.Migration
+	for _, address := range status.Brokers {
+		if address.Host == "" || containsExternalAddress(previous.Brokers, address) {
+			continue
+		}
+		r.logger.Info(fmt.Sprintf("External address of broker %d is changed to %s:%d, updating broker", address.BrokerId, address.Host, address.Port))
+		if err = r.rolloutBroker(address.BrokerId, kraft, kafkaSecret); err != nil {
+			return err
+		}
+		if err = r.waitUntilBrokerIsReady(address.BrokerId, 300); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func containsExternalAddress(addresses []kafka.BrokerExternalAddress, address kafka.BrokerExternalAddress) bool {
+	for _, existing := range addresses {
+		if existing == address {
+			return true
+		}
+	}
+	return false
+}
+
+// removeExternalAccess deletes external services and ingresses created for brokers if external access is disabled
+func (r ReconcileKafka) removeExternalAccess() error {
+	status := r.cr.Status.ExternalAccessStatus
+	if status.Type == "" {
+		return nil
+	}
+	services := []*corev1.Service{r.kafkaProvider.NewKafkaBootstrapExternalServiceForCR()}
+	ingresses := []*networkingv1.Ingress{r.kafkaProvider.NewKafkaBootstrapIngressForCR()}
+	for _, address := range status.Brokers {
+		services = append(services, r.kafkaProvider.NewKafkaBrokerExternalServiceForCR(address.BrokerId))
+		ingresses = append(ingresses, r.kafkaProvider.NewKafkaBrokerIngressForCR(address.BrokerId))
+	}
+	for _, service := range services {
+		if err := r.reconciler.DeleteService(service, r.logger); err != nil {
+			return err
+		}
+	}
+	if status.Type == provider.ExternalAccessIngress {
+		for _, ingress := range ingresses {
+			if err := r.reconciler.DeleteIngress(ingress, r.logger); err != nil {
+				return err
+			}
+		}
+	}
+	return r.updateExternalAccessStatus(kafka.ExternalAccessStatus{})
+}
+
+func (r ReconcileKafka) updateExternalAccessStatus(status kafka.ExternalAccessStatus) error {
+	r.cr.Status.ExternalAccessStatus = status
+	return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
+		instance.Status.ExternalAccessStatus = status
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func newTestExternalService(nodePort int32, ingress ...corev1.LoadBalancerIngress) *corev1.Service {
+	service := &corev1.Service{}
+	service.Spec.Ports = []corev1.ServicePort{{Name: externalServicePortName, Port: 9094, NodePort: nodePort}}
+	service.Status.LoadBalancer.Ingress = ingress
+	return service
+}
+
+func TestGetExternalServiceAddress(t *testing.T) {
+	host, port := getExternalServiceAddress(provider.ExternalAccessLoadBalancer, newTestExternalService(0), "", nil)
+	assert.Equal(t, "", host)
+	assert.Equal(t, int32(0), port)
+
+	host, port = getExternalServiceAddress(provider.ExternalAccessLoadBalancer,
+		newTestExternalService(0, corev1.LoadBalancerIngress{IP: "10.0.0.1"}), "", nil)
+	assert.Equal(t, "10.0.0.1", host)
+	assert.Equal(t, int32(9094), port)
+
+	host, _ = getExternalServiceAddress(provider.ExternalAccessLoadBalancer,
+		newTestExternalService(0, corev1.LoadBalancerIngress{Hostname: "kafka-1.elb.example.com", IP: "10.0.0.1"}), "", nil)
+	assert.Equal(t, "kafka-1.elb.example.com", host)
+
+	pod := &corev1.Pod{Status: corev1.PodStatus{HostIP: "192.168.0.5"}}
+	host, port = getExternalServiceAddress(provider.ExternalAccessNodePort, newTestExternalService(31001), "", pod)
+	assert.Equal(t, "192.168.0.5", host)
+	assert.Equal(t, int32(31001), port)
+
+	host, _ = getExternalServiceAddress(provider.ExternalAccessNodePort, newTestExternalService(31001), "kafka.example.com", pod)
+	assert.Equal(t, "kafka.example.com", host)
+
+	host, _ = getExternalServiceAddress(provider.ExternalAccessNodePort, newTestExternalService(31001), "", nil)
+	assert.Equal(t, "", host)
+}
+
+func TestCheckExternalAccess(t *testing.T) {
+	cr := &kafka.Kafka{}
+	assert.NoError(t, checkExternalAccess(cr, ""))
+	assert.NoError(t, checkExternalAccess(cr, provider.ExternalAccessLoadBalancer))
+	assert.Error(t, checkExternalAccess(cr, "route"))
+	assert.Error(t, checkExternalAccess(cr, provider.ExternalAccessIngress))
+
+	cr.Spec.ExternalAccess.Host = "kafka.example.com"
+	assert.Error(t, checkExternalAccess(cr, provider.ExternalAccessIngress))
+	cr.Spec.Ssl.Enabled = true
+	assert.NoError(t, checkExternalAccess(cr, provider.ExternalAccessIngress))
+
+	cr.Spec.ExternalHostNames = []string{"kafka-1.example.com"}
+	assert.Error(t, checkExternalAccess(cr, provider.ExternalAccessNodePort))
+}
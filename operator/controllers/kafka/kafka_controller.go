@@ -19,17 +19,20 @@ import (
 	"fmt"
 	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
 	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
 	"github.com/Netcracker/qubership-kafka/operator/util"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"os"
+	"reflect"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"time"
 )
 
 var (
@@ -49,6 +52,14 @@ const (
 type KafkaReconciler struct {
 	controllers.Reconciler
 	StatusUpdater StatusUpdater
+	// maintenanceWindowStart is the start of maintenance window for operations postponed during reconciliation
+	maintenanceWindowStart time.Time
+	// partitionsReassignmentInProgress is true if partitions reassignment is continued with the next reconciliation
+	partitionsReassignmentInProgress bool
+	// partitionsReassignmentPaused is true if partitions reassignment is paused with annotation of custom resource or dry run
+	partitionsReassignmentPaused bool
+	// newServiceReconcilers replaces reconcilers built for custom resource, it is used in tests
+	newServiceReconcilers func(r *KafkaReconciler, cr *kafka.Kafka, logger logr.Logger) []ReconcileService
 }
 
 //+kubebuilder:rbac:groups=qubership.org,resources=kafkas,verbs=get;list;watch;create;update;patch;delete
@@ -87,6 +98,9 @@ func (r *KafkaReconciler) Reconcile(ctx context.Context, request ctrl.Request) (
 		return reconcile.Result{}, nil
 	}
 	r.StatusUpdater = NewStatusUpdater(r.Client, instance)
+	r.maintenanceWindowStart = time.Time{}
+	r.partitionsReassignmentInProgress = false
+	r.partitionsReassignmentPaused = false
 
 	specHash, err := util.Hash(instance.Spec)
 	if err != nil {
@@ -121,6 +135,48 @@ func (r *KafkaReconciler) Reconcile(ctx context.Context, request ctrl.Request) (
 		}
 	}
 
+	if r.isWaitingForMaintenanceWindow() {
+		// hashes are not saved to continue reconciliation when the window opens
+		if err = r.updateConditions(NewCondition(statusFalse,
+			typeWaitingForMaintenanceWindow,
+			kafkaServiceConditionReason,
+			fmt.Sprintf("Disruptive operations are postponed until maintenance window at %s",
+				r.maintenanceWindowStart.Format(time.RFC3339)))); err != nil {
+			return reconcile.Result{}, err
+		}
+		reqLogger.Info("Reconciliation cycle is waiting for maintenance window")
+		requeueAfter := time.Until(r.maintenanceWindowStart)
+		if instance.Spec.Ssl.Enabled && instance.Spec.Ssl.CertificateAuthority.Enabled && requeueAfter > certificateRenewalCheckInterval {
+			requeueAfter = certificateRenewalCheckInterval
+		}
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	if r.isPartitionsReassignmentInProgress() {
+		// hashes are not saved to check readiness when reassignment is finished,
+		// Kafka reconciler saves applied configuration and continues reassignment without rollout of brokers
+		if err = r.updateConditions(NewCondition(statusFalse,
+			typeInProgress,
+			kafkaServiceConditionReason,
+			"Partitions reassignment is in progress")); err != nil {
+			return reconcile.Result{}, err
+		}
+		reqLogger.Info("Reconciliation cycle is waiting for partitions reassignment")
+		return reconcile.Result{RequeueAfter: partitionsReassignmentCheckInterval}, nil
+	}
+
+	if r.isPartitionsReassignmentPaused() {
+		// hashes are not saved to resume reassignment when the annotation is removed or dry run is disabled
+		if err = r.updateConditions(NewCondition(statusFalse,
+			typeInProgress,
+			kafkaServiceConditionReason,
+			fmt.Sprintf("Partitions reassignment is paused with %s annotation or dry run", partitionsReassignmentAnnotation))); err != nil {
+			return reconcile.Result{}, err
+		}
+		reqLogger.Info("Reconciliation cycle is waiting for partitions reassignment to be resumed")
+		return reconcile.Result{RequeueAfter: getPeriodicCheckInterval(instance)}, nil
+	}
+
 	if isCustomResourceChanged {
 		if instance.Spec.WaitForPodsReady {
 			if err = r.updateConditions(NewCondition(statusFalse,
@@ -164,6 +220,9 @@ func (r *KafkaReconciler) Reconcile(ctx context.Context, request ctrl.Request) (
 	reqLogger.Info("Reconciliation cycle succeeded")
 	r.ResourceHashes["annotations"] = annotationsHash
 	r.ResourceHashes["spec"] = specHash
+	if interval := getPeriodicCheckInterval(instance); interval > 0 {
+		return reconcile.Result{RequeueAfter: interval}, nil
+	}
 	return reconcile.Result{}, nil
 }
 
@@ -188,9 +247,30 @@ func (r *KafkaReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			return e.ObjectNew.GetResourceVersion() != e.ObjectOld.GetResourceVersion()
 		},
 	}
+	externalServicePredicate := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldService, oldOk := e.ObjectOld.(*corev1.Service)
+			newService, newOk := e.ObjectNew.(*corev1.Service)
+			if !oldOk || !newOk || newService.Labels[provider.ExternalAccessLabel] != "true" {
+				return false
+			}
+			return !reflect.DeepEqual(oldService.Status.LoadBalancer, newService.Status.LoadBalancer) ||
+				!reflect.DeepEqual(oldService.Spec.Ports, newService.Spec.Ports)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kafka.Kafka{}).
 		Owns(&corev1.Secret{}, builder.WithPredicates(namespacePredicate, dummyPredicate)).
+		Owns(&corev1.Service{}, builder.WithPredicates(namespacePredicate, externalServicePredicate)).
 		Complete(r)
 }
 
@@ -202,6 +282,9 @@ func (r *KafkaReconciler) writeFailedStatus(errorMessage string) {
 
 // buildReconcilers returns service reconcilers in accordance with custom resource.
 func (r *KafkaReconciler) buildReconcilers(cr *kafka.Kafka, logger logr.Logger) []ReconcileService {
+	if r.newServiceReconcilers != nil {
+		return r.newServiceReconcilers(r, cr, logger)
+	}
 	var reconcilers []ReconcileService
 	reconcilers = append(reconcilers, NewReconcileKafka(r, cr, logger))
 	return reconcilers
@@ -0,0 +1,106 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/Netcracker/qubership-kafka/operator/util"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeReassigningReconciler applies configuration like Kafka reconciler and reports partitions reassignment
+// in progress during the given number of reconciliations
+type fakeReassigningReconciler struct {
+	reconciler       *KafkaReconciler
+	cr               *kafka.Kafka
+	reconciliations  *int
+	reassignmentRuns int
+	statusChecks     *int
+}
+
+func (f fakeReassigningReconciler) Reconcile() error {
+	*f.reconciliations++
+	if *f.reconciliations <= f.reassignmentRuns {
+		f.reconciler.partitionsReassignmentInProgress = true
+	}
+	hash, err := util.Hash(f.cr.Spec)
+	if err != nil {
+		return err
+	}
+	f.reconciler.ResourceHashes[kafkaHashName] = hash
+	return nil
+}
+
+func (f fakeReassigningReconciler) Status() error {
+	*f.statusChecks++
+	return nil
+}
+
+func TestReconcileChecksReadinessWhenReassignmentIsFinished(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, kafka.AddToScheme(scheme))
+	cr := &kafka.Kafka{
+		TypeMeta:   metav1.TypeMeta{APIVersion: kafka.GroupVersion.String(), Kind: "Kafka"},
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka-service"},
+	}
+	cr.Spec.WaitForPodsReady = true
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cr).Build()
+	reconciliations, statusChecks := 0, 0
+	r := &KafkaReconciler{
+		Reconciler: controllers.Reconciler{Client: fakeClient, Scheme: scheme, ApiGroup: kafka.GroupVersion.Group,
+			ResourceVersions: map[string]string{}, ResourceHashes: map[string]string{}},
+		newServiceReconcilers: func(r *KafkaReconciler, cr *kafka.Kafka, logger logr.Logger) []ReconcileService {
+			return []ReconcileService{fakeReassigningReconciler{reconciler: r, cr: cr, reconciliations: &reconciliations,
+				reassignmentRuns: 2, statusChecks: &statusChecks}}
+		},
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}}
+	getConditions := func() []kafka.StatusCondition {
+		instance := &kafka.Kafka{}
+		assert.NoError(t, fakeClient.Get(context.TODO(), request.NamespacedName, instance))
+		return instance.Status.Conditions
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := r.Reconcile(context.TODO(), request)
+		assert.NoError(t, err)
+		assert.Equal(t, partitionsReassignmentCheckInterval, result.RequeueAfter)
+		conditions := getConditions()
+		assert.Len(t, conditions, 1)
+		assert.Equal(t, typeInProgress, conditions[0].Type)
+		assert.Equal(t, "Partitions reassignment is in progress", conditions[0].Message)
+	}
+	assert.Equal(t, 0, statusChecks)
+
+	// readiness is checked when reassignment is finished even if configuration was applied before
+	_, err := r.Reconcile(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, statusChecks)
+	conditions := getConditions()
+	assert.Len(t, conditions, 1)
+	assert.Equal(t, typeSuccessful, conditions[0].Type)
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -39,9 +40,11 @@ import (
 
 const (
 	kafkaConditionReason              = "KafkaReadinessStatus"
-	kafkaHashName                     = "spec"
 	autoRestartAnnotation             = "kafkaservice.qubership.org/auto-restart"
 	resourceVersionAnnotationTemplate = "%s/resource-version"
+	// kafkaHashName is the key of configuration applied by Kafka reconciler, it differs from the key of custom resource
+	// spec saved by controller, so that readiness is checked when partitions reassignment is finished
+	kafkaHashName = "kafka-spec"
 )
 
 type ReconcileKafka struct {
@@ -76,6 +79,22 @@ func (r ReconcileKafka) Reconcile() error {
 
 	if !kafkaConfigurationChanged {
 		r.logger.Info("Kafka configuration didn't change, skipping reconcile loop")
+		brokerIds := r.kafkaProvider.GetBrokerIds()
+		if err = r.rolloutBrokersWithChangedExternalAddresses(brokerIds, kafkaSecret); err != nil {
+			return err
+		}
+		if err = r.reconcileCertificateAuthority(brokerIds); err != nil {
+			return err
+		}
+		if err = r.rolloutWithRenewedCertificates(brokerIds, kafkaSecret); err != nil {
+			return err
+		}
+		if err = r.updatePendingRestartConfigs(brokerIds); err != nil {
+			return err
+		}
+		if err = r.continuePartitionsReassignment(brokerIds); err != nil {
+			return err
+		}
 	} else {
 		if r.cr.Spec.Replicas > 0 {
 			if err = r.processKafkaReplicas(kafkaSecret); err != nil {
@@ -108,6 +127,18 @@ func (r ReconcileKafka) Reconcile() error {
 		}
 	}
 
+	if r.reconciler.isWaitingForMaintenanceWindow() {
+		return nil
+	}
+	if !r.reconciler.isPartitionsReassignmentInProgress() && !r.reconciler.isPartitionsReassignmentPaused() {
+		if err = r.balanceLeaders(r.kafkaProvider.GetBrokerIds()); err != nil {
+			return err
+		}
+		if err = r.auditRackAwareness(r.kafkaProvider.GetBrokerIds()); err != nil {
+			return err
+		}
+	}
+	// configuration is applied, partitions reassignment is continued without rollout with the next reconciliations
 	r.reconciler.ResourceVersions[kafkaSecret.Name] = kafkaSecret.ResourceVersion
 	r.reconciler.ResourceHashes[kafkaHashName] = kafkaSpecHash
 	return nil
@@ -120,10 +151,37 @@ func (r ReconcileKafka) processKafkaReplicas(kafkaSecret *corev1.Secret) error {
 	if err != nil {
 		return err
 	}
+	if err = checkExternalAccess(r.cr, r.kafkaProvider.GetExternalAccessType()); err != nil {
+		return err
+	}
+	if err = checkNodePools(kafkaSpec, r.isGetRacksFromNodeLabelsEnabled()); err != nil {
+		return err
+	}
 	err = r.checkRacksConfig(kafkaSpec.Replicas)
 	if err != nil {
 		return err
 	}
+	if err = r.checkDataVolumes(); err != nil {
+		return err
+	}
+	if err = r.checkTieredStorage(); err != nil {
+		return err
+	}
+	if err = r.checkListeners(); err != nil {
+		return err
+	}
+	if err = r.checkCertManager(); err != nil {
+		return err
+	}
+	if err = r.checkCertificateAuthority(); err != nil {
+		return err
+	}
+	if err = r.checkBrokerConfig(); err != nil {
+		return err
+	}
+	if err = checkStatefulSet(kafkaSpec, r.kafkaProvider.GetExternalAccessType(), r.kafkaProvider.IsDeploymentCertificatesEnabled()); err != nil {
+		return err
+	}
 
 	clientService := r.kafkaProvider.NewKafkaClientServiceForCR()
 	if err := r.reconciler.SetControllerReference(r.cr, clientService, r.reconciler.Scheme); err != nil {
@@ -156,10 +214,12 @@ func (r ReconcileKafka) processKafkaReplicas(kafkaSecret *corev1.Secret) error {
 		return err
 	}
 
-	currentReplicas, err := r.getCurrentDeploymentsCount()
+	currentBrokerIds, err := r.getCurrentBrokerIds()
 	if err != nil {
 		return err
 	}
+	currentReplicas := len(currentBrokerIds)
+	brokerIds := r.kafkaProvider.GetBrokerIds()
 
 	if currentReplicas < 3 {
 		r.logger.Info("RollingUpdate value is set to false")
@@ -171,20 +231,111 @@ func (r ReconcileKafka) processKafkaReplicas(kafkaSecret *corev1.Secret) error {
 	if r.cr.Spec.Kraft.Migration {
 		kraft = false
 	}
-	if err := r.rolloutBrokers(kafkaSpec.Replicas, kraft, kafkaSecret); err != nil {
+	maintenanceWindowOpen, err := r.isMaintenanceWindowOpen(currentBrokerIds)
+	if err != nil {
+		return err
+	}
+	if err := r.reconcileCertificateAuthority(brokerIds); err != nil {
 		return err
 	}
-
-	if currentReplicas > 0 && currentReplicas < kafkaSpec.Replicas {
-		if err := r.reassignPartitionsWithStatusUpdate(int32(kafkaSpec.Replicas), true); err != nil {
+	if err := r.reconcilePodDisruptionBudgets(kraft); err != nil {
+		return err
+	}
+	if kraft && r.kafkaProvider.IsQuorumControllersEnabled() && maintenanceWindowOpen {
+		if err := r.processQuorumControllers(kafkaSpec.Controllers.Replicas, kafkaSecret); err != nil {
 			return err
 		}
+	}
+	if err := r.reconcileExternalAccess(brokerIds); err != nil {
+		return err
+	}
+	if r.kafkaProvider.IsStatefulSetEnabled() {
+		if maintenanceWindowOpen {
+			if err := r.rolloutBrokerStatefulSet(brokerIds, currentBrokerIds, kraft, kafkaSecret); err != nil {
+				return err
+			}
+		}
 	} else {
-		if err := r.reassignPartitionsWithStatusUpdate(int32(kafkaSpec.Replicas), false); err != nil {
+		statefulSetBrokerIds, err := r.getStatefulSetBrokerIds()
+		if err != nil {
+			return err
+		}
+		if len(statefulSetBrokerIds) > 0 {
+			return fmt.Errorf("brokers are managed with stateful set, migration back to deployments is not supported")
+		}
+		rolloutBrokerIds := brokerIds
+		if !maintenanceWindowOpen {
+			// new brokers are created immediately, existing brokers are restarted in maintenance window
+			rolloutBrokerIds = subtractBrokerIds(brokerIds, currentBrokerIds)
+		}
+		if err = r.rolloutBrokers(rolloutBrokerIds, kraft, kafkaSecret); err != nil {
 			return err
 		}
-		if currentReplicas > kafkaSpec.Replicas && r.kafkaProvider.IsBrokerScalingInEnabled() {
-			if err = r.performBrokerScalingIn(currentReplicas, kafkaSpec.Replicas); err != nil {
+	}
+	if err := r.waitForBrokersNodes(brokerIds); err != nil {
+		return err
+	}
+	if err := r.rolloutBrokersWithChangedExternalAddresses(brokerIds, kafkaSecret); err != nil {
+		return err
+	}
+	if err := r.reconcileBrokerConfig(brokerIds); err != nil {
+		return err
+	}
+	if !maintenanceWindowOpen {
+		return nil
+	}
+	if err := r.expandBrokersStorage(brokerIds); err != nil {
+		return err
+	}
+	if err := r.rebalanceLogDirs(brokerIds); err != nil {
+		return err
+	}
+
+	clusterScaling := currentReplicas > 0 && len(subtractBrokerIds(brokerIds, currentBrokerIds)) > 0
+	if err := r.reassignPartitionsWithStatusUpdate(brokerIds, clusterScaling); err != nil {
+		return err
+	}
+	if r.reconciler.isPartitionsReassignmentInProgress() || r.reconciler.isPartitionsReassignmentPaused() ||
+		r.getReassignmentAction() == cancelReassignmentAction {
+		// excess brokers are removed and migration is continued when reassignment is finished
+		return nil
+	}
+	return r.completeBrokersChanges(currentBrokerIds, brokerIds, clusterScaling)
+}
+
+// continuePartitionsReassignment advances partitions reassignment started by previous reconciliations when
+// configuration of Kafka is not changed, so that progress checks do not roll out brokers again.
+// Excess brokers are removed and migration is continued when reassignment is finished.
+func (r ReconcileKafka) continuePartitionsReassignment(brokerIds []int) error {
+	state, err := r.loadReassignmentState()
+	if err != nil || state == nil {
+		return err
+	}
+	currentBrokerIds, err := r.getCurrentBrokerIds()
+	if err != nil {
+		return err
+	}
+	maintenanceWindowOpen, err := r.isMaintenanceWindowOpen(currentBrokerIds)
+	if err != nil || !maintenanceWindowOpen {
+		return err
+	}
+	if err = r.reassignPartitionsWithStatusUpdate(brokerIds, false); err != nil {
+		return err
+	}
+	if r.reconciler.isPartitionsReassignmentInProgress() || r.reconciler.isPartitionsReassignmentPaused() ||
+		r.getReassignmentAction() == cancelReassignmentAction {
+		return nil
+	}
+	return r.completeBrokersChanges(currentBrokerIds, brokerIds, false)
+}
+
+// completeBrokersChanges removes excess brokers and performs migration to Kraft after partitions are reassigned
+func (r ReconcileKafka) completeBrokersChanges(currentBrokerIds []int, brokerIds []int, clusterScaling bool) error {
+	var err error
+	if !clusterScaling {
+		excessBrokerIds := subtractBrokerIds(currentBrokerIds, brokerIds)
+		if len(excessBrokerIds) > 0 && r.kafkaProvider.IsBrokerScalingInEnabled() {
+			if err = r.performBrokerScalingIn(excessBrokerIds); err != nil {
 				return err
 			}
 		}
@@ -234,7 +385,7 @@ func (r ReconcileKafka) processKafkaReplicas(kafkaSecret *corev1.Secret) error {
 		if step < 2 {
 			// ZooKeeper -> Kraft migration step two, updating brokers and waiting for migration
 			log.Info("Updating brokers and waiting for migration")
-			if err := r.updateBrokersAndWaitMigrationResult(zkClusterID, currentReplicas); err != nil {
+			if err := r.updateBrokersAndWaitMigrationResult(zkClusterID, currentBrokerIds); err != nil {
 				return err
 			}
 			if err := r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
@@ -250,7 +401,7 @@ func (r ReconcileKafka) processKafkaReplicas(kafkaSecret *corev1.Secret) error {
 
 			log.Info("Updating Kraft controller and brokers to remove ZooKeeper connection and creating Kraft cluster")
 
-			if err := r.updateBrokersWithoutZooKeeper(zkClusterID, currentReplicas); err != nil {
+			if err := r.updateBrokersWithoutZooKeeper(zkClusterID, currentBrokerIds); err != nil {
 				return err
 			}
 
@@ -268,7 +419,7 @@ func (r ReconcileKafka) processKafkaReplicas(kafkaSecret *corev1.Secret) error {
 		if step < 4 {
 			// ZooKeeper -> Kraft migration step four, removing Kraft controller and finishing migration
 			log.Info("Updating brokers to remove Kraft controller from voters list")
-			if err := r.updateBrokersWithoutKraftMigrationController(zkClusterID, currentReplicas); err != nil {
+			if err := r.updateBrokersWithoutKraftMigrationController(zkClusterID, currentBrokerIds); err != nil {
 				return err
 			}
 			log.Info("Removing Kraft controller entities")
@@ -288,9 +439,9 @@ func (r ReconcileKafka) processKafkaReplicas(kafkaSecret *corev1.Secret) error {
 	return nil
 }
 
-func (r ReconcileKafka) rolloutBrokers(replicas int, kraft bool, kafkaSecret *corev1.Secret) error {
+func (r ReconcileKafka) rolloutBrokers(brokerIds []int, kraft bool, kafkaSecret *corev1.Secret) error {
 	r.logger.Info("Perform brokers rollout procedure")
-	for brokerId := 1; brokerId <= replicas; brokerId++ {
+	for _, brokerId := range brokerIds {
 		if err := r.rolloutBroker(brokerId, kraft, kafkaSecret); err != nil {
 			return err
 		}
@@ -303,9 +454,65 @@ func (r ReconcileKafka) rolloutBrokers(replicas int, kraft bool, kafkaSecret *co
 	return nil
 }
 
-func (r ReconcileKafka) reassignPartitionsWithStatusUpdate(replicas int32, clusterScaling bool) error {
+func (r ReconcileKafka) processQuorumControllers(replicas int, kafkaSecret *corev1.Secret) error {
+	currentControllers, err := r.getCurrentQuorumControllersCount()
+	if err != nil {
+		return err
+	}
+	dynamicQuorum := r.kafkaProvider.IsDynamicQuorumEnabled()
+	if !dynamicQuorum && currentControllers > 0 && currentControllers != replicas {
+		return fmt.Errorf("the number of controllers in static Kraft quorum cannot be changed from %d to %d, "+
+			"dynamic quorum must be enabled to add or remove voters", currentControllers, replicas)
+	}
+	if dynamicQuorum && r.kafkaProvider.NewKafkaQuorumControllerPersistentVolumeClaimForCR(1) == nil {
+		return fmt.Errorf("dynamic Kraft quorum requires persistent storage for controllers")
+	}
+	r.logger.Info(fmt.Sprintf("Update controllers set: current controllers count is [%d], new controllers count is [%d].", currentControllers, replicas))
+	if dynamicQuorum {
+		bootstrapService := r.kafkaProvider.NewKafkaQuorumBootstrapServiceForCR()
+		if err := r.reconciler.SetControllerReference(r.cr, bootstrapService, r.reconciler.Scheme); err != nil {
+			return err
+		}
+		if err := r.reconciler.CreateOrUpdateService(bootstrapService, r.logger); err != nil {
+			return err
+		}
+	}
+	if err := r.rolloutQuorumControllers(currentControllers, replicas, kafkaSecret); err != nil {
+		return err
+	}
+	if !dynamicQuorum {
+		return nil
+	}
+	if err := r.waitUntilQuorumControllerIsReady(1, quorumChangeTimeoutSeconds); err != nil {
+		return err
+	}
+	if err := r.reconcileQuorumVoters(replicas); err != nil {
+		return err
+	}
+	return r.removeExcessQuorumControllers(replicas)
+}
+
+// rolloutQuorumControllers updates dedicated Kraft controllers one by one. Existing controllers are quorum voters,
+// so each of them is waited to be ready before the next one is restarted regardless of rolling update of brokers.
+// New controllers are not waited, because they cannot be ready until quorum is formed or they are added as voters.
+func (r ReconcileKafka) rolloutQuorumControllers(currentControllers int, replicas int, kafkaSecret *corev1.Secret) error {
+	r.logger.Info(fmt.Sprintf("Perform rollout procedure for %d dedicated Kraft controllers", replicas))
+	for controllerIndex := 1; controllerIndex <= replicas; controllerIndex++ {
+		if err := r.rolloutQuorumController(controllerIndex, kafkaSecret); err != nil {
+			return err
+		}
+		if controllerIndex <= currentControllers {
+			if err := r.waitUntilQuorumControllerIsReady(controllerIndex, 300); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r ReconcileKafka) reassignPartitionsWithStatusUpdate(brokerIds []int, clusterScaling bool) error {
 	r.logger.Info(fmt.Sprintf("Reassign partitions with cluster scaling enabled: %t", clusterScaling))
-	if err := r.reassignPartitions(replicas, clusterScaling); err != nil {
+	if err := r.reassignPartitions(brokerIds, clusterScaling); err != nil {
 		err2 := r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
 			instance.Status.PartitionsReassignmentStatus.Status = "Failed"
 		})
@@ -317,10 +524,14 @@ func (r ReconcileKafka) reassignPartitionsWithStatusUpdate(replicas int32, clust
 	return nil
 }
 
-func (r ReconcileKafka) performBrokerScalingIn(currentReplicas int, requiredReplicas int) error {
-	r.logger.Info(fmt.Sprintf("There is an attempt to downscale Kafka with %d replicas to Kafka with %d replicas. For correct work excess Kafka deployments need to be scaled down.", currentReplicas, requiredReplicas))
-	for i := requiredReplicas + 1; i <= currentReplicas; i++ {
-		if err := r.reconciler.ScaleDeployment(fmt.Sprintf("%s-%d", r.cr.Name, i), 0, r.cr.Namespace, r.logger); err != nil {
+func (r ReconcileKafka) performBrokerScalingIn(excessBrokerIds []int) error {
+	r.logger.Info(fmt.Sprintf("There is an attempt to downscale Kafka, brokers %v are excess. For correct work excess Kafka deployments need to be scaled down.", excessBrokerIds))
+	if r.kafkaProvider.IsStatefulSetEnabled() {
+		// excess brokers have the greatest ordinals and are removed by stateful set
+		return r.reconciler.ScaleStatefulSet(r.kafkaProvider.GetBrokerStatefulSetName(), int32(r.cr.Spec.Replicas), r.cr.Namespace, r.logger)
+	}
+	for _, brokerId := range excessBrokerIds {
+		if err := r.reconciler.ScaleDeployment(fmt.Sprintf("%s-%d", r.cr.Name, brokerId), 0, r.cr.Namespace, r.logger); err != nil {
 			return err
 		}
 	}
@@ -360,6 +571,11 @@ func (r *ReconcileKafka) rolloutBroker(brokerId int, kraft bool, kafkaSecret *co
 			return err
 		}
 	}
+	for _, dataVolumeClaim := range r.kafkaProvider.NewKafkaDataVolumePersistentVolumeClaimsForCR(brokerId) {
+		if err := r.reconciler.CreatePersistentVolumeClaim(dataVolumeClaim, r.logger); err != nil {
+			return err
+		}
+	}
 
 	rack, err := r.getRack(brokerId, r.logger)
 	if err != nil {
@@ -372,13 +588,53 @@ func (r *ReconcileKafka) rolloutBroker(brokerId int, kraft bool, kafkaSecret *co
 	if kafkaSecret.Annotations != nil && kafkaSecret.Annotations[autoRestartAnnotation] == "true" {
 		r.addDeploymentAnnotation(brokerDeployment, fmt.Sprintf(resourceVersionAnnotationTemplate, kafkaSecret.Name), kafkaSecret.ResourceVersion)
 	}
+	if r.kafkaProvider.IsDeploymentCertificatesEnabled() {
+		certificateSecret, err := r.reconcileBrokerCertificate(brokerId)
+		if err != nil {
+			return err
+		}
+		r.addCertificateAnnotation(brokerDeployment, certificateSecret)
+	}
 	if err := r.reconciler.CreateOrUpdateDeployment(brokerDeployment, r.logger); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *ReconcileKafka) updateBrokerDeploymentForMigration(brokerId int, replicas int, zkClusterID string, migrated bool) error {
+func (r *ReconcileKafka) rolloutQuorumController(controllerIndex int, kafkaSecret *corev1.Secret) error {
+	controllerService := r.kafkaProvider.NewKafkaQuorumControllerServiceForCR(controllerIndex)
+	if err := r.reconciler.SetControllerReference(r.cr, controllerService, r.reconciler.Scheme); err != nil {
+		return err
+	}
+	if err := r.reconciler.CreateOrUpdateService(controllerService, r.logger); err != nil {
+		return err
+	}
+
+	persistentVolumeClaim := r.kafkaProvider.NewKafkaQuorumControllerPersistentVolumeClaimForCR(controllerIndex)
+	if persistentVolumeClaim != nil {
+		if err := r.reconciler.CreatePersistentVolumeClaim(persistentVolumeClaim, r.logger); err != nil {
+			return err
+		}
+	}
+
+	controllerDeployment := r.kafkaProvider.NewKafkaQuorumControllerDeploymentForCR(controllerIndex, "")
+	if err := r.reconciler.SetControllerReference(r.cr, controllerDeployment, r.reconciler.Scheme); err != nil {
+		return err
+	}
+	if kafkaSecret.Annotations != nil && kafkaSecret.Annotations[autoRestartAnnotation] == "true" {
+		r.addDeploymentAnnotation(controllerDeployment, fmt.Sprintf(resourceVersionAnnotationTemplate, kafkaSecret.Name), kafkaSecret.ResourceVersion)
+	}
+	if r.kafkaProvider.IsDeploymentCertificatesEnabled() {
+		certificateSecret, err := r.reconcileQuorumControllerCertificate(controllerIndex)
+		if err != nil {
+			return err
+		}
+		r.addDeploymentAnnotation(controllerDeployment, fmt.Sprintf(resourceVersionAnnotationTemplate, certificateSecret.Name), certificateSecret.ResourceVersion)
+	}
+	return r.reconciler.CreateOrUpdateDeployment(controllerDeployment, r.logger)
+}
+
+func (r *ReconcileKafka) updateBrokerDeploymentForMigration(brokerId int, brokerIds []int, zkClusterID string, migrated bool) error {
 	rack, err := r.getRack(brokerId, r.logger)
 	if err != nil {
 		return err
@@ -396,8 +652,8 @@ func (r *ReconcileKafka) updateBrokerDeploymentForMigration(brokerId int, replic
 		brokerDeployment.Spec.Template.Spec.Containers[0].Env = append(brokerDeployment.Spec.Template.Spec.Containers[0].Env, additionalEnvs...)
 	} else {
 		var voters []string
-		for i := 1; i <= replicas; i++ {
-			voters = append(voters, fmt.Sprintf("%d@%s-%d.kafka-broker.%s:9096", i, r.cr.Name, i, r.cr.Namespace))
+		for _, id := range brokerIds {
+			voters = append(voters, fmt.Sprintf("%d@%s-%d.kafka-broker.%s:9096", id, r.cr.Name, id, r.cr.Namespace))
 		}
 		voters = append(voters, fmt.Sprintf("3000@%s-%s:9092", r.cr.Name, "kraft-controller"))
 		additionalEnvs = []corev1.EnvVar{
@@ -434,6 +690,9 @@ func (r *ReconcileKafka) createMigrationControllerEntities(zkClusterID string) e
 	if err := r.reconciler.SetControllerReference(r.cr, controllerDeployment, r.reconciler.Scheme); err != nil {
 		return err
 	}
+	if err := r.reconcileKraftControllerPodDisruptionBudget(); err != nil {
+		return err
+	}
 
 	if err := r.reconciler.CreateOrUpdateDeployment(controllerDeployment, r.logger); err != nil {
 		return err
@@ -463,6 +722,9 @@ func (r *ReconcileKafka) removeMigrationControllerEntities(zkClusterID string) e
 	if err := r.reconciler.DeleteDeployment(controllerDeployment, r.logger); err != nil {
 		return err
 	}
+	if err := r.reconciler.DeletePodDisruptionBudget(r.kafkaProvider.NewKafkaKraftControllerPodDisruptionBudgetForCR(), r.logger); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -472,6 +734,9 @@ func (r *ReconcileKafka) updateMigrationControllerWithoutZooKeeper(zkClusterID s
 	if err := r.reconciler.SetControllerReference(r.cr, controllerDeployment, r.reconciler.Scheme); err != nil {
 		return err
 	}
+	if err := r.reconcileKraftControllerPodDisruptionBudget(); err != nil {
+		return err
+	}
 
 	if err := r.reconciler.CreateOrUpdateDeployment(controllerDeployment, r.logger); err != nil {
 		return err
@@ -484,9 +749,9 @@ func (r *ReconcileKafka) updateMigrationControllerWithoutZooKeeper(zkClusterID s
 	return nil
 }
 
-func (r *ReconcileKafka) updateBrokersAndWaitMigrationResult(zkClusterID string, currentReplicas int) error {
-	for brokerId := 1; brokerId <= currentReplicas; brokerId++ {
-		if err := r.updateBrokerDeploymentForMigration(brokerId, currentReplicas, zkClusterID, false); err != nil {
+func (r *ReconcileKafka) updateBrokersAndWaitMigrationResult(zkClusterID string, currentBrokerIds []int) error {
+	for _, brokerId := range currentBrokerIds {
+		if err := r.updateBrokerDeploymentForMigration(brokerId, currentBrokerIds, zkClusterID, false); err != nil {
 			return err
 		}
 		if err := r.waitUntilBrokerIsReady(brokerId, r.cr.Spec.Kraft.MigrationTimeout); err != nil {
@@ -501,13 +766,13 @@ func (r *ReconcileKafka) updateBrokersAndWaitMigrationResult(zkClusterID string,
 	return nil
 }
 
-func (r *ReconcileKafka) updateBrokersWithoutZooKeeper(zkClusterID string, currentReplicas int) error {
-	for brokerId := 1; brokerId <= currentReplicas; brokerId++ {
-		if err := r.updateBrokerDeploymentForMigration(brokerId, currentReplicas, zkClusterID, true); err != nil {
+func (r *ReconcileKafka) updateBrokersWithoutZooKeeper(zkClusterID string, currentBrokerIds []int) error {
+	for _, brokerId := range currentBrokerIds {
+		if err := r.updateBrokerDeploymentForMigration(brokerId, currentBrokerIds, zkClusterID, true); err != nil {
 			return err
 		}
 	}
-	for brokerId := 1; brokerId <= currentReplicas; brokerId++ {
+	for _, brokerId := range currentBrokerIds {
 		if err := r.waitUntilBrokerIsReady(brokerId, r.cr.Spec.Kraft.MigrationTimeout); err != nil {
 			return err
 		}
@@ -516,8 +781,8 @@ func (r *ReconcileKafka) updateBrokersWithoutZooKeeper(zkClusterID string, curre
 	return nil
 }
 
-func (r *ReconcileKafka) updateBrokersWithoutKraftMigrationController(zkClusterID string, currentReplicas int) error {
-	for brokerId := 1; brokerId <= currentReplicas; brokerId++ {
+func (r *ReconcileKafka) updateBrokersWithoutKraftMigrationController(zkClusterID string, currentBrokerIds []int) error {
+	for _, brokerId := range currentBrokerIds {
 		rack, err := r.getRack(brokerId, r.logger)
 		if err != nil {
 			return err
@@ -531,7 +796,7 @@ func (r *ReconcileKafka) updateBrokersWithoutKraftMigrationController(zkClusterI
 		}
 	}
 
-	for brokerId := 1; brokerId <= currentReplicas; brokerId++ {
+	for _, brokerId := range currentBrokerIds {
 		if err := r.waitUntilBrokerIsReady(brokerId, r.cr.Spec.Kraft.MigrationTimeout); err != nil {
 			return err
 		}
@@ -619,60 +884,6 @@ func (r ReconcileKafka) getMigrationStatus() bool {
 	}
 }
 
-func (r *ReconcileKafka) reassignPartitions(newBrokersCount int32, clusterScaling bool) error {
-	reassignPartitionsEnabled := r.kafkaProvider.IsReassignPartitionsEnabled(clusterScaling)
-	allBrokersStartTimeoutSeconds := r.kafkaProvider.GetAllBrokersStartTimeoutSeconds()
-	topicReassignmentTimeoutSeconds := r.kafkaProvider.GetTopicReassignmentTimeoutSeconds()
-	if !reassignPartitionsEnabled {
-		r.logger.Info("Partitions reassignment is disabled")
-		return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
-			instance.Status.PartitionsReassignmentStatus.Status = "Disabled"
-		})
-	}
-	// for cluster scaling we run reassignment without taking into account Status,
-	// because we can scale a cluster several times and always want to reassign
-	// despite the Finished status from previous reassignment
-	if clusterScaling || r.cr.Status.PartitionsReassignmentStatus.Status != "Finished" {
-		r.logger.Info(fmt.Sprintf("Partitions reassignment is enabled, allBrokersStartTimeoutSeconds is %d, topicReassignmentTimeoutSeconds is %d", allBrokersStartTimeoutSeconds, topicReassignmentTimeoutSeconds))
-		err := r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
-			instance.Status.PartitionsReassignmentStatus.Status = "In Progress"
-		})
-		if err != nil {
-			return err
-		}
-		username, password, err := r.getKafkaCredentials()
-		if err != nil {
-			return err
-		}
-		sslEnabled := r.cr.Spec.Ssl.Enabled
-		sslCertificates, err := r.getKafkaCertificates()
-		if err != nil {
-			return err
-		}
-		kafkaClient, err := controllers.NewKafkaClient(
-			r.kafkaProvider.GetServiceName(),
-			username,
-			password,
-			sslEnabled,
-			sslCertificates,
-			newBrokersCount,
-			allBrokersStartTimeoutSeconds,
-			topicReassignmentTimeoutSeconds)
-		if err != nil {
-			return err
-		}
-		err = kafkaClient.ReassignPartitions()
-		if err != nil {
-			return err
-		}
-		return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
-			instance.Status.PartitionsReassignmentStatus.Status = "Finished"
-		})
-	}
-	r.logger.Info("Partitions are already reassigned. Skip reassignment")
-	return nil
-}
-
 func (r *ReconcileKafka) getKafkaCredentials() (string, string, error) {
 	foundSecret, err := r.reconciler.FindSecret(r.cr.Spec.SecretName, r.cr.Namespace, r.logger)
 	if err != nil {
@@ -684,9 +895,17 @@ func (r *ReconcileKafka) getKafkaCredentials() (string, string, error) {
 }
 
 func (r *ReconcileKafka) getKafkaCertificates() (*controllers.SslCertificates, error) {
-	if r.cr.Spec.Ssl.Enabled && r.cr.Spec.Ssl.SecretName != "" {
+	if r.kafkaProvider.IsCertificateAuthorityEnabled() {
+		return r.getCertificateAuthoritySslCertificates()
+	}
+	secretName := r.cr.Spec.Ssl.SecretName
+	if secretName == "" && r.kafkaProvider.IsCertManagerEnabled() {
+		// certificates issued by cert-manager are valid for client authentication as well
+		secretName = r.kafkaProvider.GetCertificateSecretName(fmt.Sprintf("%s-%d", r.cr.Name, 1))
+	}
+	if r.cr.Spec.Ssl.Enabled && secretName != "" {
 		sslCertificates, err :=
-			r.reconciler.GetSslCertificates(r.cr.Spec.Ssl.SecretName, r.cr.Namespace, r.logger)
+			r.reconciler.GetSslCertificates(secretName, r.cr.Namespace, r.logger)
 		if err != nil {
 			return nil, err
 		}
@@ -695,20 +914,27 @@ func (r *ReconcileKafka) getKafkaCertificates() (*controllers.SslCertificates, e
 	return &controllers.SslCertificates{}, nil
 }
 
-func (r *ReconcileKafka) getCurrentDeploymentsCount() (int, error) {
-	deployments, err := r.reconciler.FindKafkaDeployments(r.cr)
+// getCurrentBrokerIds returns sorted IDs of brokers whose deployments are not scaled down and brokers of stateful set
+func (r *ReconcileKafka) getCurrentBrokerIds() ([]int, error) {
+	brokerIds, err := r.getDeploymentBrokerIds()
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-
-	deploymentsWithPods := 0
-	for _, deployment := range deployments.Items {
-		if *deployment.Spec.Replicas > 0 {
-			deploymentsWithPods++
+	if r.kafkaProvider.IsStatefulSetEnabled() {
+		statefulSetBrokerIds, err := r.getStatefulSetBrokerIds()
+		if err != nil {
+			return nil, err
 		}
+		brokerIds = append(brokerIds, subtractBrokerIds(statefulSetBrokerIds, brokerIds)...)
 	}
+	sort.Ints(brokerIds)
+	return brokerIds, nil
+}
 
-	return deploymentsWithPods, nil
+// parseBrokerId returns ID of broker from name of its deployment or pod
+func parseBrokerId(crName string, name string) (int, bool) {
+	brokerId, err := strconv.Atoi(strings.TrimPrefix(name, crName+"-"))
+	return brokerId, err == nil
 }
 
 func checkParamsForExternalAccess(cr *kafka.Kafka, replicasCount int) error {
@@ -734,6 +960,13 @@ func (r *ReconcileKafka) isGetRacksFromNodeLabelsEnabled() bool {
 }
 
 func (r *ReconcileKafka) checkRacksConfig(replicasCount int) error {
+	if len(r.cr.Spec.NodePools) > 0 {
+		// racks of brokers in node pools are checked for each pool
+		if r.isGetRacksFromNodeLabelsEnabled() && r.cr.Spec.NodeLabelNameForRack == "" {
+			return fmt.Errorf("when GetRacksFromNodeLabels=true, nodeLabelNameForRack must be specified")
+		}
+		return nil
+	}
 	if r.isGetRacksFromNodeLabelsEnabled() {
 		nodesCount := len(r.cr.Spec.Storage.Nodes)
 		if r.cr.Spec.NodeLabelNameForRack == "" || nodesCount != replicasCount {
@@ -750,16 +983,24 @@ func (r *ReconcileKafka) checkRacksConfig(replicasCount int) error {
 	return nil
 }
 
+// checkTieredStorage checks that remote storage bucket is specified when tiered storage is enabled
+func (r *ReconcileKafka) checkTieredStorage() error {
+	tieredStorage := r.cr.Spec.TieredStorage
+	if !tieredStorage.Enabled {
+		return nil
+	}
+	if tieredStorage.Bucket == "" {
+		return fmt.Errorf("when TieredStorage.Enabled=true, TieredStorage.Bucket must be specified")
+	}
+	return nil
+}
+
 // Get rack for broker if GetRacksFromNodeLabels configured or explicit list of racks' names is provided
 func (r *ReconcileKafka) getRack(brokerId int, logger logr.Logger) (string, error) {
 	if r.isGetRacksFromNodeLabelsEnabled() {
-		return r.reconciler.GetNodeLabel(r.cr.Spec.Storage.Nodes[brokerId-1], r.cr.Spec.NodeLabelNameForRack, logger)
-	} else {
-		if len(r.cr.Spec.Racks) > 0 {
-			return r.cr.Spec.Racks[brokerId-1], nil
-		}
+		return r.reconciler.GetNodeLabel(r.kafkaProvider.GetBrokerStorageNode(brokerId), r.cr.Spec.NodeLabelNameForRack, logger)
 	}
-	return "", nil
+	return r.kafkaProvider.GetBrokerConfiguredRack(brokerId), nil
 }
 
 func (r *ReconcileKafka) updateKafkaStatus() error {
@@ -768,10 +1009,28 @@ func (r *ReconcileKafka) updateKafkaStatus() error {
 	if err != nil {
 		return err
 	}
-	podNames := controllers.GetPodNames(foundPodList.Items)
+	podNames := getBrokerPodNames(foundPodList.Items)
 	sort.Strings(podNames)
+	quorumStatus := kafka.KraftQuorumStatus{}
+	if r.cr.Spec.Kraft.Enabled && !r.cr.Spec.Kraft.Migration {
+		quorumStatus.Voters = r.kafkaProvider.GetQuorumVoters()
+		if r.kafkaProvider.IsQuorumControllersEnabled() {
+			controllerPodList, err := r.reconciler.FindPodList(r.cr.Namespace, r.kafkaProvider.GetQuorumControllerSelectorLabels())
+			if err != nil {
+				return err
+			}
+			quorumStatus.Controllers = controllers.GetPodNames(controllerPodList.Items)
+			sort.Strings(quorumStatus.Controllers)
+		}
+	}
 	return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
 		instance.Status.KafkaBrokerStatus.Brokers = podNames
+		instance.Status.KafkaBrokerStatus.NodePools = r.kafkaProvider.GetNodePoolsStatus()
+		instance.Status.KraftQuorumStatus.Voters = quorumStatus.Voters
+		instance.Status.KraftQuorumStatus.Controllers = quorumStatus.Controllers
+		if !r.kafkaProvider.IsDynamicQuorumEnabled() {
+			instance.Status.KraftQuorumStatus.Members = nil
+		}
 	})
 }
 
@@ -791,12 +1050,30 @@ func (r *ReconcileKafka) waitUntilControllerIsReady(maxWaitingInterval int) erro
 	return nil
 }
 
+func (r *ReconcileKafka) waitUntilQuorumControllerIsReady(controllerIndex int, maxWaitingInterval int) error {
+	controllerName := r.kafkaProvider.GetQuorumControllerName(controllerIndex)
+	r.logger.Info(fmt.Sprintf("Waiting for %s deployment.", controllerName))
+	time.Sleep(waitingInterval)
+	err := wait.PollImmediate(waitingInterval, time.Duration(maxWaitingInterval)*time.Second, func() (done bool, err error) {
+		labels := r.kafkaProvider.GetQuorumControllerSelectorLabels()
+		labels["name"] = controllerName
+		return r.reconciler.AreDeploymentsReady(labels, r.cr.Namespace, r.logger), nil
+	})
+	if err != nil {
+		r.logger.Error(err, fmt.Sprintf("Deployment %s failed.", controllerName))
+		return err
+	}
+	return nil
+}
+
 func (r *ReconcileKafka) waitUntilBrokerIsReady(brokerId int, maxWaitingInterval int) error {
 	r.logger.Info(fmt.Sprintf("Waiting for kafka-%d deployment.", brokerId))
 	time.Sleep(waitingInterval)
 	err := wait.PollImmediate(waitingInterval, time.Duration(maxWaitingInterval)*time.Second, func() (done bool, err error) {
-		kafkaLabels := r.kafkaProvider.GetSelectorLabels()
-		kafkaLabels["name"] = fmt.Sprintf("%s-%d", r.cr.Name, brokerId)
+		if r.kafkaProvider.IsStatefulSetEnabled() {
+			return r.isBrokerPodReady(brokerId), nil
+		}
+		kafkaLabels := r.kafkaProvider.GetBrokerPodSelectorLabels(brokerId)
 		return r.reconciler.AreDeploymentsReady(kafkaLabels, r.cr.Namespace, r.logger), nil
 	})
 	if err != nil {
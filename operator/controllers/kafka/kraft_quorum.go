@@ -0,0 +1,291 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	quorumScript               = "${KAFKA_HOME}/bin/kafka-quorum.sh"
+	quorumChangeTimeoutSeconds = 300
+	// quorumMaxLag is the maximum number of metadata records controller can be behind the high watermark
+	// to be considered as caught up
+	quorumMaxLag = 100
+)
+
+// KraftQuorumState describes Kraft metadata quorum from the leader's point of view
+type KraftQuorumState struct {
+	LeaderId      int
+	HighWatermark int64
+	Voters        []kafka.KraftQuorumMember
+	Observers     []kafka.KraftQuorumMember
+}
+
+func (qs KraftQuorumState) findVoter(nodeId int) *kafka.KraftQuorumMember {
+	for i := range qs.Voters {
+		if qs.Voters[i].NodeId == nodeId {
+			return &qs.Voters[i]
+		}
+	}
+	return nil
+}
+
+func (qs KraftQuorumState) findObserver(nodeId int) *kafka.KraftQuorumMember {
+	for i := range qs.Observers {
+		if qs.Observers[i].NodeId == nodeId {
+			return &qs.Observers[i]
+		}
+	}
+	return nil
+}
+
+// votersCaughtUp returns true if all voters replicated metadata log up to the high watermark
+func (qs KraftQuorumState) votersCaughtUp() bool {
+	for _, voter := range qs.Voters {
+		if voter.Lag > quorumMaxLag {
+			return false
+		}
+	}
+	return true
+}
+
+// parseQuorumStatus parses output of "kafka-metadata-quorum.sh describe --status" command
+// and returns leader id and high watermark of the quorum
+func parseQuorumStatus(output string) (int, int64, error) {
+	leaderId := -1
+	highWatermark := int64(-1)
+	for _, line := range strings.Split(output, "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "LeaderId":
+			id, err := strconv.Atoi(value)
+			if err != nil {
+				return 0, 0, fmt.Errorf("cannot parse quorum leader id '%s': %v", value, err)
+			}
+			leaderId = id
+		case "HighWatermark":
+			hw, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return 0, 0, fmt.Errorf("cannot parse quorum high watermark '%s': %v", value, err)
+			}
+			highWatermark = hw
+		}
+	}
+	if leaderId < 0 || highWatermark < 0 {
+		return 0, 0, fmt.Errorf("quorum status does not contain leader id and high watermark: %s", output)
+	}
+	return leaderId, highWatermark, nil
+}
+
+// parseQuorumReplication parses output of "kafka-metadata-quorum.sh describe --replication" command.
+// Columns are found by header names, because DirectoryId column is present only in Kafka 3.9+.
+func parseQuorumReplication(output string) ([]kafka.KraftQuorumMember, []kafka.KraftQuorumMember, error) {
+	var header []string
+	var voters, observers []kafka.KraftQuorumMember
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "NodeId" {
+			header = fields
+			continue
+		}
+		if header == nil || len(fields) != len(header) {
+			continue
+		}
+		member := kafka.KraftQuorumMember{}
+		for i, column := range header {
+			var err error
+			switch column {
+			case "NodeId":
+				member.NodeId, err = strconv.Atoi(fields[i])
+			case "DirectoryId":
+				member.DirectoryId = fields[i]
+			case "LogEndOffset":
+				member.LogEndOffset, err = strconv.ParseInt(fields[i], 10, 64)
+			case "Lag":
+				member.Lag, err = strconv.ParseInt(fields[i], 10, 64)
+			case "Status":
+				member.Status = fields[i]
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("cannot parse quorum replication line '%s': %v", line, err)
+			}
+		}
+		if member.Status == "Observer" {
+			observers = append(observers, member)
+		} else {
+			voters = append(voters, member)
+		}
+	}
+	if header == nil {
+		return nil, nil, fmt.Errorf("quorum replication output does not contain header: %s", output)
+	}
+	sort.Slice(voters, func(i, j int) bool { return voters[i].NodeId < voters[j].NodeId })
+	sort.Slice(observers, func(i, j int) bool { return observers[i].NodeId < observers[j].NodeId })
+	return voters, observers, nil
+}
+
+// reconcileQuorumVoters adds and removes dedicated controllers to/from dynamic Kraft quorum one at a time,
+// so that the quorum never loses majority during the change
+func (r ReconcileKafka) reconcileQuorumVoters(replicas int) error {
+	state, err := r.describeQuorum()
+	if err != nil {
+		return err
+	}
+	for controllerIndex := 1; controllerIndex <= replicas; controllerIndex++ {
+		nodeId := r.kafkaProvider.GetQuorumControllerId(controllerIndex)
+		if state.findVoter(nodeId) != nil {
+			continue
+		}
+		if err := r.waitUntilQuorumControllerIsReady(controllerIndex, quorumChangeTimeoutSeconds); err != nil {
+			return err
+		}
+		if state, err = r.waitForQuorum(func(qs KraftQuorumState) bool {
+			observer := qs.findObserver(nodeId)
+			return observer != nil && observer.Lag <= quorumMaxLag && qs.votersCaughtUp()
+		}); err != nil {
+			return fmt.Errorf("controller %d did not catch up with quorum: %v", nodeId, err)
+		}
+		r.logger.Info(fmt.Sprintf("Adding controller %d to Kraft quorum voters", nodeId))
+		if _, err := r.runQuorumCommand(controllerIndex, "add"); err != nil {
+			return err
+		}
+		if state, err = r.waitForQuorum(func(qs KraftQuorumState) bool {
+			return qs.findVoter(nodeId) != nil && qs.votersCaughtUp()
+		}); err != nil {
+			return fmt.Errorf("controller %d was not added to quorum voters: %v", nodeId, err)
+		}
+	}
+
+	for i := len(state.Voters) - 1; i >= 0; i-- {
+		voter := state.Voters[i]
+		controllerIndex := r.kafkaProvider.GetQuorumControllerIndex(voter.NodeId)
+		if controllerIndex <= replicas {
+			continue
+		}
+		if state, err = r.waitForQuorum(KraftQuorumState.votersCaughtUp); err != nil {
+			return fmt.Errorf("quorum voters did not catch up before removal of controller %d: %v", voter.NodeId, err)
+		}
+		r.logger.Info(fmt.Sprintf("Removing controller %d with directory %s from Kraft quorum voters", voter.NodeId, voter.DirectoryId))
+		if _, err := r.runQuorumCommand(1, "remove", strconv.Itoa(voter.NodeId), voter.DirectoryId); err != nil {
+			return err
+		}
+		if state, err = r.waitForQuorum(func(qs KraftQuorumState) bool {
+			return qs.findVoter(voter.NodeId) == nil && qs.votersCaughtUp()
+		}); err != nil {
+			return fmt.Errorf("controller %d was not removed from quorum voters: %v", voter.NodeId, err)
+		}
+	}
+	return r.updateQuorumStatus(state)
+}
+
+// removeExcessQuorumControllers deletes deployments and services of controllers which are not quorum voters anymore.
+// Persistent volume claims are kept, so that metadata log is not fetched from scratch on the next scale out.
+func (r ReconcileKafka) removeExcessQuorumControllers(replicas int) error {
+	deployments, err := r.reconciler.FindDeploymentList(r.cr.Namespace, r.kafkaProvider.GetQuorumControllerSelectorLabels())
+	if err != nil {
+		return err
+	}
+	for _, deployment := range deployments.Items {
+		controllerIndex, err := strconv.Atoi(strings.TrimPrefix(deployment.Name, fmt.Sprintf("%s-controller-", r.cr.Name)))
+		if err != nil || controllerIndex <= replicas {
+			continue
+		}
+		if err := r.reconciler.DeleteDeployment(deployment.DeepCopy(), r.logger); err != nil {
+			return err
+		}
+		if err := r.reconciler.DeleteService(r.kafkaProvider.NewKafkaQuorumControllerServiceForCR(controllerIndex), r.logger); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getCurrentQuorumControllersCount returns number of existing dedicated controller deployments
+func (r ReconcileKafka) getCurrentQuorumControllersCount() (int, error) {
+	deployments, err := r.reconciler.FindDeploymentList(r.cr.Namespace, r.kafkaProvider.GetQuorumControllerSelectorLabels())
+	if err != nil {
+		return 0, err
+	}
+	return len(deployments.Items), nil
+}
+
+func (r ReconcileKafka) waitForQuorum(condition func(KraftQuorumState) bool) (KraftQuorumState, error) {
+	var state KraftQuorumState
+	err := wait.PollImmediate(waitingInterval, quorumChangeTimeoutSeconds*time.Second, func() (done bool, err error) {
+		state, err = r.describeQuorum()
+		if err != nil {
+			r.logger.Info(fmt.Sprintf("Cannot describe Kraft quorum: %v", err))
+			return false, nil
+		}
+		return condition(state), nil
+	})
+	return state, err
+}
+
+func (r ReconcileKafka) describeQuorum() (KraftQuorumState, error) {
+	state := KraftQuorumState{}
+	statusOutput, err := r.runQuorumCommand(1, "status")
+	if err != nil {
+		return state, err
+	}
+	if state.LeaderId, state.HighWatermark, err = parseQuorumStatus(statusOutput); err != nil {
+		return state, err
+	}
+	replicationOutput, err := r.runQuorumCommand(1, "replication")
+	if err != nil {
+		return state, err
+	}
+	state.Voters, state.Observers, err = parseQuorumReplication(replicationOutput)
+	return state, err
+}
+
+func (r ReconcileKafka) runQuorumCommand(controllerIndex int, args ...string) (string, error) {
+	labels := r.kafkaProvider.GetQuorumControllerSelectorLabels()
+	labels["name"] = r.kafkaProvider.GetQuorumControllerName(controllerIndex)
+	podList, err := r.reconciler.FindPodList(r.cr.Namespace, labels)
+	if err != nil {
+		return "", err
+	}
+	pod := controllers.GetFirstAvailablePod(podList)
+	if pod == nil {
+		return "", fmt.Errorf("there is no available pod for %s", labels["name"])
+	}
+	command := append([]string{quorumScript}, args...)
+	return r.runCommandInPod(pod.Name, "kafka", r.cr.Namespace, []string{"/bin/sh", "-c", strings.Join(command, " ")})
+}
+
+func (r ReconcileKafka) updateQuorumStatus(state KraftQuorumState) error {
+	return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
+		instance.Status.KraftQuorumStatus.LeaderId = state.LeaderId
+		instance.Status.KraftQuorumStatus.HighWatermark = state.HighWatermark
+		instance.Status.KraftQuorumStatus.Members = state.Voters
+	})
+}
@@ -0,0 +1,84 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	quorumStatusOutput = `ClusterId:              U5tHX5uHQnmsniDS54EF_w
+LeaderId:               2001
+LeaderEpoch:            12
+HighWatermark:          4521
+MaxFollowerLag:         3
+MaxFollowerLagTimeMs:   0
+CurrentVoters:          [{"id": 2001, "directoryId": "pXh3Zb9eR8qYnE5b7Jb3xw", "endpoints": ["CONTROLLER://kafka-controller-1.kafka-service:9092"]}]
+CurrentObservers:       [{"id": 1, "directoryId": "Jv0Kx7o0T9m6cK3s0M3b2A"}]`
+	quorumReplicationOutput = `[2025-01-10 10:00:00,000] WARN The configuration 'sasl.jaas.config' was supplied but isn't a known config. (org.apache.kafka.clients.admin.AdminClientConfig)
+NodeId	DirectoryId           	LogEndOffset	Lag	LastFetchTimestamp	LastCaughtUpTimestamp	Status
+2001  	pXh3Zb9eR8qYnE5b7Jb3xw	4521        	0  	1736503200000     	1736503200000        	Leader
+2002  	Zy8n0Q3aS1uQ0pWk2XbR9g	4518        	3  	1736503199950     	1736503199950        	Follower
+2003  	Qm5f1W7tR2e8YcVb4NaL6h	3100        	1421	1736503199900     	1736503190000        	Observer
+1     	Jv0Kx7o0T9m6cK3s0M3b2A	4521        	0  	1736503199990     	1736503199990        	Observer	`
+)
+
+func TestParseQuorumStatus(t *testing.T) {
+	leaderId, highWatermark, err := parseQuorumStatus(quorumStatusOutput)
+	assert.Nil(t, err)
+	assert.Equal(t, 2001, leaderId)
+	assert.Equal(t, int64(4521), highWatermark)
+
+	_, _, err = parseQuorumStatus("Error while describing quorum")
+	assert.NotNil(t, err)
+}
+
+func TestParseQuorumReplication(t *testing.T) {
+	voters, observers, err := parseQuorumReplication(quorumReplicationOutput)
+	assert.Nil(t, err)
+	assert.Equal(t, []kafka.KraftQuorumMember{
+		{NodeId: 2001, DirectoryId: "pXh3Zb9eR8qYnE5b7Jb3xw", LogEndOffset: 4521, Lag: 0, Status: "Leader"},
+		{NodeId: 2002, DirectoryId: "Zy8n0Q3aS1uQ0pWk2XbR9g", LogEndOffset: 4518, Lag: 3, Status: "Follower"},
+	}, voters)
+	assert.Len(t, observers, 2)
+	assert.Equal(t, 1, observers[0].NodeId)
+	assert.Equal(t, int64(1421), observers[1].Lag)
+
+	state := KraftQuorumState{Voters: voters, Observers: observers}
+	assert.True(t, state.votersCaughtUp())
+	assert.NotNil(t, state.findVoter(2002))
+	assert.Nil(t, state.findVoter(2003))
+	assert.NotNil(t, state.findObserver(2003))
+}
+
+func TestParseQuorumReplicationWithoutDirectoryId(t *testing.T) {
+	output := `NodeId	LogEndOffset	Lag	LastFetchTimestamp	LastCaughtUpTimestamp	Status
+1     	120         	0  	1736503200000     	1736503200000        	Leader
+2     	20          	100	1736503199950     	1736503199950        	Follower	`
+	voters, observers, err := parseQuorumReplication(output)
+	assert.Nil(t, err)
+	assert.Len(t, voters, 2)
+	assert.Empty(t, observers)
+	assert.Equal(t, "", voters[1].DirectoryId)
+
+	state := KraftQuorumState{Voters: []kafka.KraftQuorumMember{{NodeId: 1, Lag: quorumMaxLag + 1}}}
+	assert.False(t, state.votersCaughtUp())
+
+	_, _, err = parseQuorumReplication("connection refused")
+	assert.NotNil(t, err)
+}
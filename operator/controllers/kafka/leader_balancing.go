@@ -0,0 +1,108 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"time"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
+)
+
+// balanceLeaders checks leader skew of brokers once per interval and elects preferred leaders of imbalanced partitions.
+// Elections move client traffic between brokers, so they are performed only in maintenance window.
+// Errors are logged without failing reconciliation, the check is repeated after the interval.
+func (r ReconcileKafka) balanceLeaders(brokerIds []int) error {
+	if !r.kafkaProvider.IsLeaderBalancingEnabled() || len(brokerIds) == 0 {
+		return nil
+	}
+	interval := time.Duration(r.kafkaProvider.GetLeaderBalancingIntervalSeconds()) * time.Second
+	if !isPeriodicCheckDue(r.cr.Status.LeaderBalancingStatus.LastCheckTime, interval, time.Now()) {
+		return nil
+	}
+	open, nextStart, err := r.checkMaintenanceWindows()
+	if err != nil {
+		return err
+	}
+	if !open {
+		r.logger.Info(fmt.Sprintf("Leader balancing is postponed until maintenance window at %s", nextStart.Format(time.RFC3339)))
+		return nil
+	}
+	kafkaClient, err := r.newReassignmentKafkaClient(brokerIds)
+	if err != nil {
+		r.logger.Error(err, "Cannot connect to Kafka to balance partition leaders")
+		return nil
+	}
+	defer kafkaClient.Close()
+	result, err := kafkaClient.BalanceLeaders(controllers.LeaderBalancingSettings{
+		SkewThresholdPercent: r.kafkaProvider.GetLeaderSkewThresholdPercent(),
+		MaxElections:         r.kafkaProvider.GetMaxLeaderElectionsPerCheck(),
+		ReorderReplicas:      r.kafkaProvider.IsLeaderBalancingReorderReplicasEnabled(),
+	})
+	if err != nil {
+		r.logger.Error(err, "Cannot balance partition leaders")
+	}
+	if result == nil {
+		return nil
+	}
+	updateLeaderBalancingMetrics(r.cr.Namespace, r.cr.Name, result)
+	return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
+		instance.Status.LeaderBalancingStatus = kafka.LeaderBalancingStatus{
+			LastCheckTime:       time.Now().UTC().Format(time.RFC3339),
+			MaxLeaderSkew:       result.MaxSkewPercent,
+			ElectedPartitions:   result.ElectedPartitions,
+			ReorderedPartitions: result.ReorderedPartitions,
+		}
+	})
+}
+
+// isPeriodicCheckDue returns true if the check was never performed or the interval passed since the last check
+func isPeriodicCheckDue(lastCheckTime string, interval time.Duration, now time.Time) bool {
+	if lastCheckTime == "" {
+		return true
+	}
+	lastCheck, err := time.Parse(time.RFC3339, lastCheckTime)
+	if err != nil {
+		return true
+	}
+	// the interval is shortened by a second, so the check is not skipped when reconciliation is requeued a bit earlier
+	return now.Sub(lastCheck) >= interval-time.Second
+}
+
+// getPeriodicCheckInterval returns the shortest interval of periodic checks enabled for custom resource
+// or 0 if periodic checks are disabled
+func getPeriodicCheckInterval(instance *kafka.Kafka) time.Duration {
+	var interval time.Duration
+	if instance.Spec.Ssl.Enabled && instance.Spec.Ssl.CertificateAuthority.Enabled {
+		// certificates issued by operator are checked periodically to renew them before expiry
+		interval = certificateRenewalCheckInterval
+	}
+	kafkaProvider := provider.NewKafkaResourceProvider(instance, log)
+	if kafkaProvider.IsLeaderBalancingEnabled() {
+		leaderBalancingInterval := time.Duration(kafkaProvider.GetLeaderBalancingIntervalSeconds()) * time.Second
+		if interval == 0 || leaderBalancingInterval < interval {
+			interval = leaderBalancingInterval
+		}
+	}
+	if kafkaProvider.IsRackAuditEnabled() {
+		rackAuditInterval := time.Duration(kafkaProvider.GetRackAuditIntervalSeconds()) * time.Second
+		if interval == 0 || rackAuditInterval < interval {
+			interval = rackAuditInterval
+		}
+	}
+	return interval
+}
@@ -0,0 +1,54 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPeriodicCheckDue(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.True(t, isPeriodicCheckDue("", 5*time.Minute, now))
+	assert.True(t, isPeriodicCheckDue("unknown", 5*time.Minute, now))
+	assert.False(t, isPeriodicCheckDue("2025-01-01T11:57:00Z", 5*time.Minute, now))
+	assert.True(t, isPeriodicCheckDue("2025-01-01T11:55:00Z", 5*time.Minute, now))
+	// reconciliation can be requeued slightly earlier than the interval
+	assert.True(t, isPeriodicCheckDue("2025-01-01T11:55:01Z", 5*time.Minute, now))
+}
+
+func TestGetPeriodicCheckInterval(t *testing.T) {
+	instance := &kafka.Kafka{}
+	assert.Equal(t, time.Duration(0), getPeriodicCheckInterval(instance))
+
+	instance.Spec.Ssl.Enabled = true
+	instance.Spec.Ssl.CertificateAuthority.Enabled = true
+	assert.Equal(t, certificateRenewalCheckInterval, getPeriodicCheckInterval(instance))
+
+	instance.Spec.Scaling.LeaderBalancing.Enabled = true
+	assert.Equal(t, 5*time.Minute, getPeriodicCheckInterval(instance))
+
+	instance.Spec.Scaling.LeaderBalancing.IntervalSeconds = 7200
+	assert.Equal(t, certificateRenewalCheckInterval, getPeriodicCheckInterval(instance))
+
+	instance.Spec.Ssl.Enabled = false
+	assert.Equal(t, 2*time.Hour, getPeriodicCheckInterval(instance))
+
+	instance.Spec.Scaling.RackAudit.Enabled = true
+	assert.Equal(t, time.Hour, getPeriodicCheckInterval(instance))
+}
@@ -0,0 +1,87 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
+)
+
+var listenerNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9]{0,14}$`)
+
+// reservedListenerPorts are ports of inter-broker, external and controller listeners, Jolokia and Prometheus exporter
+var reservedListenerPorts = map[int32]bool{9093: true, 9094: true, 9096: true, 9087: true, 8080: true}
+
+// reservedListenerNames are names of listeners configured by operator
+var reservedListenerNames = map[string]bool{"external": true, "controller": true}
+
+// checkListeners checks that client listeners have unique names and ports and their security settings are consistent.
+// The listener on 9092 port is used by operator and supplementary services, so it must exist and use default security settings.
+func (r *ReconcileKafka) checkListeners() error {
+	if len(r.cr.Spec.Listeners) == 0 {
+		return nil
+	}
+	names := map[string]bool{}
+	ports := map[int32]bool{}
+	internalListenerFound := false
+	for _, listener := range r.cr.Spec.Listeners {
+		if !listenerNameRegexp.MatchString(listener.Name) || reservedListenerNames[listener.Name] {
+			return fmt.Errorf("listener name '%s' must consist of at most 15 lower case alphanumeric characters "+
+				"and must not be 'external' or 'controller'", listener.Name)
+		}
+		if names[listener.Name] {
+			return fmt.Errorf("listener name '%s' is not unique", listener.Name)
+		}
+		names[listener.Name] = true
+		if listener.Port <= 0 || reservedListenerPorts[listener.Port] || ports[listener.Port] {
+			return fmt.Errorf("port %d of listener '%s' is invalid, reserved or not unique", listener.Port, listener.Name)
+		}
+		ports[listener.Port] = true
+
+		authentication := r.kafkaProvider.GetListenerAuthentication(listener)
+		switch authentication {
+		case provider.ListenerAuthenticationScram, provider.ListenerAuthenticationOAuth, provider.ListenerAuthenticationNone:
+		case provider.ListenerAuthenticationMTLS:
+			if !listener.TLS {
+				return fmt.Errorf("listener '%s' with mtls authentication must have TLS enabled", listener.Name)
+			}
+		default:
+			return fmt.Errorf("authentication type '%s' of listener '%s' is not supported", listener.Authentication, listener.Name)
+		}
+		if listener.TLS && !r.cr.Spec.Ssl.Enabled {
+			return fmt.Errorf("listener '%s' with TLS requires TLS enabled for Kafka", listener.Name)
+		}
+		if r.kafkaProvider.IsSecurityDisabled() && authentication != provider.ListenerAuthenticationNone {
+			return fmt.Errorf("listener '%s' cannot use %s authentication when security is disabled", listener.Name, authentication)
+		}
+		if listener.Port == provider.InternalListenerPort {
+			expectedAuthentication := provider.ListenerAuthenticationScram
+			if r.kafkaProvider.IsSecurityDisabled() {
+				expectedAuthentication = provider.ListenerAuthenticationNone
+			}
+			if authentication != expectedAuthentication || listener.TLS != r.cr.Spec.Ssl.Enabled {
+				return fmt.Errorf("listener '%s' on port %d must use %s authentication and TLS=%t, because it is used by operator and services",
+					listener.Name, listener.Port, expectedAuthentication, r.cr.Spec.Ssl.Enabled)
+			}
+			internalListenerFound = true
+		}
+	}
+	if !internalListenerFound {
+		return fmt.Errorf("listeners must contain listener on port %d", provider.InternalListenerPort)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestListenersReconcile(sslEnabled bool, disableSecurity bool, listeners ...kafka.Listener) *ReconcileKafka {
+	cr := &kafka.Kafka{}
+	cr.Spec.Ssl.Enabled = sslEnabled
+	cr.Spec.DisableSecurity = &disableSecurity
+	cr.Spec.Listeners = listeners
+	return &ReconcileKafka{cr: cr, kafkaProvider: provider.NewKafkaResourceProvider(cr, logr.Discard())}
+}
+
+func TestCheckListeners(t *testing.T) {
+	assert.NoError(t, newTestListenersReconcile(false, false).checkListeners())
+	assert.NoError(t, newTestListenersReconcile(true, false,
+		kafka.Listener{Name: "internal", Port: 9092, TLS: true},
+		kafka.Listener{Name: "mtls", Port: 9098, TLS: true, Authentication: "mtls"},
+		kafka.Listener{Name: "plain", Port: 9095, Authentication: "none"},
+	).checkListeners())
+	assert.NoError(t, newTestListenersReconcile(false, true,
+		kafka.Listener{Name: "internal", Port: 9092},
+	).checkListeners())
+
+	// listener on 9092 port is required
+	assert.Error(t, newTestListenersReconcile(false, false,
+		kafka.Listener{Name: "client", Port: 9098},
+	).checkListeners())
+	// listener on 9092 port must use default security settings
+	assert.Error(t, newTestListenersReconcile(true, false,
+		kafka.Listener{Name: "internal", Port: 9092},
+	).checkListeners())
+	assert.Error(t, newTestListenersReconcile(false, false,
+		kafka.Listener{Name: "internal", Port: 9092, Authentication: "oauth"},
+	).checkListeners())
+	// names and ports must be valid and unique
+	assert.Error(t, newTestListenersReconcile(false, false,
+		kafka.Listener{Name: "internal", Port: 9092},
+		kafka.Listener{Name: "internal", Port: 9098},
+	).checkListeners())
+	assert.Error(t, newTestListenersReconcile(false, false,
+		kafka.Listener{Name: "internal", Port: 9092},
+		kafka.Listener{Name: "client", Port: 9092},
+	).checkListeners())
+	assert.Error(t, newTestListenersReconcile(false, false,
+		kafka.Listener{Name: "internal", Port: 9092},
+		kafka.Listener{Name: "external", Port: 9098},
+	).checkListeners())
+	assert.Error(t, newTestListenersReconcile(false, false,
+		kafka.Listener{Name: "internal", Port: 9092},
+		kafka.Listener{Name: "Client_1", Port: 9098},
+	).checkListeners())
+	assert.Error(t, newTestListenersReconcile(false, false,
+		kafka.Listener{Name: "internal", Port: 9092},
+		kafka.Listener{Name: "client", Port: 9093},
+	).checkListeners())
+	// security settings must be consistent
+	assert.Error(t, newTestListenersReconcile(true, false,
+		kafka.Listener{Name: "internal", Port: 9092, TLS: true},
+		kafka.Listener{Name: "mtls", Port: 9098, Authentication: "mtls"},
+	).checkListeners())
+	assert.Error(t, newTestListenersReconcile(false, false,
+		kafka.Listener{Name: "internal", Port: 9092},
+		kafka.Listener{Name: "tls", Port: 9098, TLS: true},
+	).checkListeners())
+	assert.Error(t, newTestListenersReconcile(false, false,
+		kafka.Listener{Name: "internal", Port: 9092},
+		kafka.Listener{Name: "client", Port: 9098, Authentication: "kerberos"},
+	).checkListeners())
+	assert.Error(t, newTestListenersReconcile(false, true,
+		kafka.Listener{Name: "internal", Port: 9092},
+		kafka.Listener{Name: "client", Port: 9098, Authentication: "scram"},
+	).checkListeners())
+}
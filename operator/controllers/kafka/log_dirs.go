@@ -0,0 +1,349 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	replicaLogDirsScript                 = "${KAFKA_HOME}/bin/kafka-replica-log-dirs.sh"
+	replicaLogDirsMovementTimeoutSeconds = 3600
+	// metadataTopic is Kraft metadata log which is always located in the first log directory
+	metadataTopic = "__cluster_metadata"
+)
+
+var dataVolumeNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// ReplicaLogDir describes partition replica located in broker log directory
+type ReplicaLogDir struct {
+	Topic     string
+	Partition int32
+	Size      int64
+}
+
+// ReplicaLogDirMove describes movement of partition replica to another log directory of the same broker
+type ReplicaLogDirMove struct {
+	Topic     string
+	Partition int32
+	LogDir    string
+}
+
+// isLogDirAdded returns true if broker has several log directories and some of them do not contain any replicas,
+// while others do, that is a new data volume was added to broker
+func isLogDirAdded(logDirs map[string][]ReplicaLogDir) bool {
+	empty, used := 0, 0
+	for _, replicas := range logDirs {
+		if len(replicas) == 0 {
+			empty++
+		} else {
+			used++
+		}
+	}
+	return empty > 0 && used > 0
+}
+
+// planLogDirsRebalance distributes replicas of broker between its log directories by size.
+// On each step the replica which is the best to equalize the most and the least loaded directories is moved,
+// until there is no replica which decreases the difference between them. Each replica is moved at most once.
+func planLogDirsRebalance(logDirs map[string][]ReplicaLogDir) []ReplicaLogDirMove {
+	paths := make([]string, 0, len(logDirs))
+	loads := map[string]int64{}
+	for path, replicas := range logDirs {
+		paths = append(paths, path)
+		for _, replica := range replicas {
+			loads[path] += replica.Size
+		}
+	}
+	sort.Strings(paths)
+	if len(paths) < 2 {
+		return nil
+	}
+	current := map[string][]ReplicaLogDir{}
+	for path, replicas := range logDirs {
+		current[path] = append([]ReplicaLogDir{}, replicas...)
+	}
+
+	var moves []ReplicaLogDirMove
+	for {
+		mostLoaded, leastLoaded := paths[0], paths[0]
+		for _, path := range paths {
+			if loads[path] > loads[mostLoaded] {
+				mostLoaded = path
+			}
+			if loads[path] < loads[leastLoaded] {
+				leastLoaded = path
+			}
+		}
+		difference := loads[mostLoaded] - loads[leastLoaded]
+		candidate := -1
+		for i, replica := range current[mostLoaded] {
+			if replica.Size <= 0 || replica.Size >= difference || isReplicaMoved(moves, replica) {
+				continue
+			}
+			if candidate < 0 || abs64(difference-2*replica.Size) < abs64(difference-2*current[mostLoaded][candidate].Size) {
+				candidate = i
+			}
+		}
+		if candidate < 0 {
+			return moves
+		}
+		replica := current[mostLoaded][candidate]
+		current[mostLoaded] = append(current[mostLoaded][:candidate], current[mostLoaded][candidate+1:]...)
+		current[leastLoaded] = append(current[leastLoaded], replica)
+		loads[mostLoaded] -= replica.Size
+		loads[leastLoaded] += replica.Size
+		moves = append(moves, ReplicaLogDirMove{Topic: replica.Topic, Partition: replica.Partition, LogDir: leastLoaded})
+	}
+}
+
+func isReplicaMoved(moves []ReplicaLogDirMove, replica ReplicaLogDir) bool {
+	for _, move := range moves {
+		if move.Topic == replica.Topic && move.Partition == replica.Partition {
+			return true
+		}
+	}
+	return false
+}
+
+func abs64(x int64) int64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// checkDataVolumes checks that additional data volumes of brokers have unique valid names and sizes
+func (r ReconcileKafka) checkDataVolumes() error {
+	if err := checkStorageDataVolumes(r.cr.Spec.Storage); err != nil {
+		return err
+	}
+	for _, pool := range r.cr.Spec.NodePools {
+		if pool.Storage == nil {
+			continue
+		}
+		if err := checkStorageDataVolumes(*pool.Storage); err != nil {
+			return fmt.Errorf("node pool '%s': %v", pool.Name, err)
+		}
+	}
+	return nil
+}
+
+func checkStorageDataVolumes(storage kafka.Storage) error {
+	names := map[string]bool{}
+	for _, dataVolume := range storage.DataVolumes {
+		if !dataVolumeNameRegexp.MatchString(dataVolume.Name) {
+			return fmt.Errorf("data volume name '%s' must consist of lower case alphanumeric characters or '-'", dataVolume.Name)
+		}
+		if names[dataVolume.Name] {
+			return fmt.Errorf("data volume name '%s' is not unique", dataVolume.Name)
+		}
+		names[dataVolume.Name] = true
+		if dataVolume.Size == "" {
+			return fmt.Errorf("size of data volume '%s' is not specified", dataVolume.Name)
+		}
+	}
+	return nil
+}
+
+// rebalanceLogDirs moves replicas of each broker to its newly added log directories
+func (r ReconcileKafka) rebalanceLogDirs(brokerIds []int) error {
+	var brokersWithDataVolumes []int
+	for _, brokerId := range brokerIds {
+		if len(r.kafkaProvider.GetLogDirs(brokerId)) > 1 {
+			brokersWithDataVolumes = append(brokersWithDataVolumes, brokerId)
+		}
+	}
+	if len(brokersWithDataVolumes) == 0 {
+		return nil
+	}
+	adminClient, err := r.newKafkaAdminClient()
+	if err != nil {
+		return err
+	}
+	defer adminClient.Close()
+	for _, brokerId := range brokersWithDataVolumes {
+		if err := r.waitUntilBrokerIsReady(brokerId, 300); err != nil {
+			return err
+		}
+		logDirs, err := describeBrokerLogDirs(adminClient, int32(brokerId))
+		if err != nil {
+			return err
+		}
+		if !isLogDirAdded(logDirs) {
+			continue
+		}
+		moves := planLogDirsRebalance(logDirs)
+		if len(moves) == 0 {
+			continue
+		}
+		r.logger.Info(fmt.Sprintf("Moving %d replicas of broker %d to new log directories", len(moves), brokerId))
+		plan, err := newReplicaLogDirsPlan(adminClient, int32(brokerId), moves)
+		if err != nil {
+			return err
+		}
+		if _, err := r.runReplicaLogDirsCommand(brokerId, "execute", fmt.Sprintf("'%s'", plan)); err != nil {
+			return err
+		}
+		if err := r.waitUntilReplicasMoved(adminClient, int32(brokerId), moves); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newReplicaLogDirsPlan builds reassignment JSON which keeps current replicas of partitions
+// and specifies target log directory only for replicas of the given broker
+func newReplicaLogDirsPlan(adminClient sarama.ClusterAdmin, brokerId int32, moves []ReplicaLogDirMove) (string, error) {
+	type partitionPlan struct {
+		Topic     string   `json:"topic"`
+		Partition int32    `json:"partition"`
+		Replicas  []int32  `json:"replicas"`
+		LogDirs   []string `json:"log_dirs"`
+	}
+	topics := map[string]bool{}
+	var topicNames []string
+	for _, move := range moves {
+		if !topics[move.Topic] {
+			topics[move.Topic] = true
+			topicNames = append(topicNames, move.Topic)
+		}
+	}
+	metadata, err := adminClient.DescribeTopics(topicNames)
+	if err != nil {
+		return "", err
+	}
+	replicasByPartition := map[string][]int32{}
+	for _, topic := range metadata {
+		for _, partition := range topic.Partitions {
+			replicasByPartition[fmt.Sprintf("%s-%d", topic.Name, partition.ID)] = partition.Replicas
+		}
+	}
+	var partitions []partitionPlan
+	for _, move := range moves {
+		partitionReplicas, found := replicasByPartition[fmt.Sprintf("%s-%d", move.Topic, move.Partition)]
+		if !found {
+			return "", fmt.Errorf("partition %s-%d is not found", move.Topic, move.Partition)
+		}
+		logDirs := make([]string, len(partitionReplicas))
+		for i, replica := range partitionReplicas {
+			logDirs[i] = "any"
+			if replica == brokerId {
+				logDirs[i] = move.LogDir
+			}
+		}
+		partitions = append(partitions, partitionPlan{Topic: move.Topic, Partition: move.Partition, Replicas: partitionReplicas, LogDirs: logDirs})
+	}
+	plan, err := json.Marshal(map[string]interface{}{"version": 1, "partitions": partitions})
+	return string(plan), err
+}
+
+func describeBrokerLogDirs(adminClient sarama.ClusterAdmin, brokerId int32) (map[string][]ReplicaLogDir, error) {
+	allLogDirs, err := adminClient.DescribeLogDirs([]int32{brokerId})
+	if err != nil {
+		return nil, err
+	}
+	logDirs := map[string][]ReplicaLogDir{}
+	for _, logDir := range allLogDirs[brokerId] {
+		if logDir.ErrorCode != sarama.ErrNoError {
+			return nil, fmt.Errorf("cannot describe log directory %s of broker %d: %v", logDir.Path, brokerId, logDir.ErrorCode)
+		}
+		replicas := []ReplicaLogDir{}
+		for _, topic := range logDir.Topics {
+			if topic.Topic == metadataTopic {
+				continue
+			}
+			for _, partition := range topic.Partitions {
+				if partition.IsTemporary {
+					continue
+				}
+				replicas = append(replicas, ReplicaLogDir{Topic: topic.Topic, Partition: partition.PartitionID, Size: partition.Size})
+			}
+		}
+		logDirs[logDir.Path] = replicas
+	}
+	return logDirs, nil
+}
+
+func (r ReconcileKafka) waitUntilReplicasMoved(adminClient sarama.ClusterAdmin, brokerId int32, moves []ReplicaLogDirMove) error {
+	err := wait.PollImmediate(waitingInterval, replicaLogDirsMovementTimeoutSeconds*time.Second, func() (done bool, err error) {
+		logDirs, err := describeBrokerLogDirs(adminClient, brokerId)
+		if err != nil {
+			r.logger.Info(fmt.Sprintf("Cannot describe log directories of broker %d: %v", brokerId, err))
+			return false, nil
+		}
+		for _, move := range moves {
+			if !containsReplica(logDirs[move.LogDir], move.Topic, move.Partition) {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("replicas of broker %d were not moved to new log directories: %v", brokerId, err)
+	}
+	return nil
+}
+
+func containsReplica(replicas []ReplicaLogDir, topic string, partition int32) bool {
+	for _, replica := range replicas {
+		if replica.Topic == topic && replica.Partition == partition {
+			return true
+		}
+	}
+	return false
+}
+
+func (r ReconcileKafka) newKafkaAdminClient() (sarama.ClusterAdmin, error) {
+	username, password, err := r.getKafkaCredentials()
+	if err != nil {
+		return nil, err
+	}
+	sslCertificates, err := r.getKafkaCertificates()
+	if err != nil {
+		return nil, err
+	}
+	saslSettings := &controllers.SaslSettings{
+		Mechanism: sarama.SASLTypeSCRAMSHA512,
+		Username:  username,
+		Password:  password,
+	}
+	return controllers.NewKafkaAdminClient(fmt.Sprintf("%s:9092", r.kafkaProvider.GetServiceName()), saslSettings,
+		r.cr.Spec.Ssl.Enabled, sslCertificates)
+}
+
+func (r ReconcileKafka) runReplicaLogDirsCommand(brokerId int, args ...string) (string, error) {
+	labels := r.kafkaProvider.GetBrokerPodSelectorLabels(brokerId)
+	podList, err := r.reconciler.FindPodList(r.cr.Namespace, labels)
+	if err != nil {
+		return "", err
+	}
+	pod := controllers.GetFirstAvailablePod(podList)
+	if pod == nil {
+		return "", fmt.Errorf("there is no available pod for %s", labels["name"])
+	}
+	command := append([]string{replicaLogDirsScript}, args...)
+	return r.runCommandInPod(pod.Name, "kafka", r.cr.Namespace, []string{"/bin/sh", "-c", strings.Join(command, " ")})
+}
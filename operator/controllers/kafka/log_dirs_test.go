@@ -0,0 +1,65 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsLogDirAdded(t *testing.T) {
+	assert.True(t, isLogDirAdded(map[string][]ReplicaLogDir{
+		"/var/opt/kafka/data/1":     {{Topic: "orders", Partition: 0, Size: 100}},
+		"/var/opt/kafka/data-ssd/1": {},
+	}))
+	assert.False(t, isLogDirAdded(map[string][]ReplicaLogDir{
+		"/var/opt/kafka/data/1":     {},
+		"/var/opt/kafka/data-ssd/1": {},
+	}))
+	assert.False(t, isLogDirAdded(map[string][]ReplicaLogDir{
+		"/var/opt/kafka/data/1": {{Topic: "orders", Partition: 0, Size: 100}},
+	}))
+}
+
+func TestPlanLogDirsRebalance(t *testing.T) {
+	moves := planLogDirsRebalance(map[string][]ReplicaLogDir{
+		"/var/opt/kafka/data/1": {
+			{Topic: "orders", Partition: 0, Size: 400},
+			{Topic: "orders", Partition: 1, Size: 300},
+			{Topic: "payments", Partition: 0, Size: 200},
+			{Topic: "payments", Partition: 1, Size: 100},
+		},
+		"/var/opt/kafka/data-ssd/1": {},
+	})
+	assert.Equal(t, []ReplicaLogDirMove{
+		{Topic: "orders", Partition: 0, LogDir: "/var/opt/kafka/data-ssd/1"},
+		{Topic: "payments", Partition: 1, LogDir: "/var/opt/kafka/data-ssd/1"},
+	}, moves)
+}
+
+func TestPlanLogDirsRebalanceSkipsUselessMoves(t *testing.T) {
+	assert.Empty(t, planLogDirsRebalance(map[string][]ReplicaLogDir{
+		"/var/opt/kafka/data/1":     {{Topic: "orders", Partition: 0, Size: 400}},
+		"/var/opt/kafka/data-ssd/1": {},
+	}))
+	assert.Empty(t, planLogDirsRebalance(map[string][]ReplicaLogDir{
+		"/var/opt/kafka/data/1":     {{Topic: "orders", Partition: 0, Size: 0}, {Topic: "orders", Partition: 1, Size: 0}},
+		"/var/opt/kafka/data-ssd/1": {},
+	}))
+	assert.Empty(t, planLogDirsRebalance(map[string][]ReplicaLogDir{
+		"/var/opt/kafka/data/1": {{Topic: "orders", Partition: 0, Size: 400}},
+	}))
+}
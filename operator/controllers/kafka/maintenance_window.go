@@ -0,0 +1,52 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+)
+
+// isMaintenanceWindowOpen returns true if disruptive operations can be performed now. Operations cannot disrupt
+// brokers which do not exist yet, so they are always allowed for new cluster. If maintenance window is closed,
+// its start is recorded to reconcile custom resource again when the window opens.
+func (r ReconcileKafka) isMaintenanceWindowOpen(currentBrokerIds []int) (bool, error) {
+	open, nextStart, err := r.checkMaintenanceWindows()
+	if err != nil {
+		return false, err
+	}
+	if open || len(currentBrokerIds) == 0 {
+		return true, nil
+	}
+	r.logger.Info(fmt.Sprintf("Disruptive operations are postponed until maintenance window at %s", nextStart.Format(time.RFC3339)))
+	r.reconciler.maintenanceWindowStart = nextStart
+	return false, nil
+}
+
+// checkMaintenanceWindows returns true if maintenance window is open now or the start of the next window
+func (r ReconcileKafka) checkMaintenanceWindows() (bool, time.Time, error) {
+	windows := make([]controllers.MaintenanceWindow, len(r.cr.Spec.MaintenanceWindows))
+	for i, window := range r.cr.Spec.MaintenanceWindows {
+		windows[i] = controllers.MaintenanceWindow(window)
+	}
+	return controllers.CheckMaintenanceWindows(windows, time.Now())
+}
+
+// isWaitingForMaintenanceWindow returns true if some operations were postponed during reconciliation
+func (r *KafkaReconciler) isWaitingForMaintenanceWindow() bool {
+	return !r.maintenanceWindowStart.IsZero()
+}
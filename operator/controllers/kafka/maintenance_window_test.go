@@ -0,0 +1,53 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsMaintenanceWindowOpen(t *testing.T) {
+	cr := &kafka.Kafka{ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka-service"}}
+	reconciler := &KafkaReconciler{}
+	r := ReconcileKafka{cr: cr, reconciler: reconciler, logger: logr.Discard()}
+
+	open, err := r.isMaintenanceWindowOpen([]int{1, 2, 3})
+	assert.NoError(t, err)
+	assert.True(t, open)
+	assert.False(t, reconciler.isWaitingForMaintenanceWindow())
+
+	// the window which is open during one minute of a year
+	cr.Spec.MaintenanceWindows = []kafka.MaintenanceWindow{{Schedule: "0 0 1 1 *", Duration: "1m"}}
+	open, err = r.isMaintenanceWindowOpen(nil)
+	assert.NoError(t, err)
+	assert.True(t, open)
+	assert.False(t, reconciler.isWaitingForMaintenanceWindow())
+
+	open, err = r.isMaintenanceWindowOpen([]int{1, 2, 3})
+	assert.NoError(t, err)
+	assert.False(t, open)
+	assert.True(t, reconciler.isWaitingForMaintenanceWindow())
+	assert.Equal(t, time.Date(time.Now().UTC().Year()+1, time.January, 1, 0, 0, 0, 0, time.UTC), reconciler.maintenanceWindowStart)
+
+	cr.Spec.MaintenanceWindows = []kafka.MaintenanceWindow{{Schedule: "0 0 1 1 *", Duration: "1 minute"}}
+	_, err = r.isMaintenanceWindowOpen([]int{1, 2, 3})
+	assert.Error(t, err)
+}
@@ -0,0 +1,111 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"strconv"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	brokerMetricLabels = []string{"namespace", "cluster", "broker"}
+
+	brokerDiskUsageBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kafka_operator_broker_disk_usage_bytes",
+		Help: "Size of partition replicas stored by Kafka broker",
+	}, brokerMetricLabels)
+	brokerReplicas = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kafka_operator_broker_replicas",
+		Help: "Number of partition replicas stored by Kafka broker",
+	}, brokerMetricLabels)
+	brokerLeaders = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kafka_operator_broker_leaders",
+		Help: "Number of partitions led by Kafka broker",
+	}, brokerMetricLabels)
+	brokerSkewPercent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kafka_operator_broker_skew_percent",
+		Help: "Deviation of Kafka broker load from the average load of brokers in its pool",
+	}, brokerMetricLabels)
+
+	clusterMetricLabels = []string{"namespace", "cluster"}
+
+	maxLeaderSkewPercent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kafka_operator_max_leader_skew_percent",
+		Help: "Maximum deviation of leaders number of Kafka broker from the average found by the last leader balancing check",
+	}, clusterMetricLabels)
+	preferredLeaderElections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_operator_preferred_leader_elections_total",
+		Help: "Number of partitions whose preferred replicas were elected as leaders by leader balancing",
+	}, clusterMetricLabels)
+	reorderedPartitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_operator_reordered_partitions_total",
+		Help: "Number of partitions whose replicas were reordered to change preferred leaders by leader balancing",
+	}, clusterMetricLabels)
+	rackViolatingPartitions = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kafka_operator_rack_violating_partitions",
+		Help: "Number of partitions whose replicas share a rack while other racks of the node pool are available",
+	}, clusterMetricLabels)
+	rackRepairedPartitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_operator_rack_repaired_partitions_total",
+		Help: "Number of partitions planned to be moved to other racks by rack audit",
+	}, clusterMetricLabels)
+)
+
+func init() {
+	metrics.Registry.MustRegister(brokerDiskUsageBytes, brokerReplicas, brokerLeaders, brokerSkewPercent,
+		maxLeaderSkewPercent, preferredLeaderElections, reorderedPartitions, rackViolatingPartitions, rackRepairedPartitions)
+}
+
+// updateBrokersBalanceMetrics exposes distribution of partitions between brokers of the cluster,
+// metrics of brokers which are absent in the new distribution are removed
+func updateBrokersBalanceMetrics(namespace string, cluster string, previous []kafka.BrokerBalanceStatus, current []kafka.BrokerBalanceStatus) {
+	currentBrokers := map[int32]bool{}
+	for _, broker := range current {
+		currentBrokers[broker.BrokerId] = true
+		labels := prometheus.Labels{"namespace": namespace, "cluster": cluster, "broker": strconv.Itoa(int(broker.BrokerId))}
+		brokerDiskUsageBytes.With(labels).Set(float64(broker.DiskUsageBytes))
+		brokerReplicas.With(labels).Set(float64(broker.Replicas))
+		brokerLeaders.With(labels).Set(float64(broker.Leaders))
+		brokerSkewPercent.With(labels).Set(float64(broker.Skew))
+	}
+	for _, broker := range previous {
+		if currentBrokers[broker.BrokerId] {
+			continue
+		}
+		labels := prometheus.Labels{"namespace": namespace, "cluster": cluster, "broker": strconv.Itoa(int(broker.BrokerId))}
+		for _, gauge := range []*prometheus.GaugeVec{brokerDiskUsageBytes, brokerReplicas, brokerLeaders, brokerSkewPercent} {
+			gauge.Delete(labels)
+		}
+	}
+}
+
+// updateLeaderBalancingMetrics exposes leader skew and the number of partitions changed by leader balancing check
+func updateLeaderBalancingMetrics(namespace string, cluster string, result *controllers.LeaderBalancingResult) {
+	labels := prometheus.Labels{"namespace": namespace, "cluster": cluster}
+	maxLeaderSkewPercent.With(labels).Set(float64(result.MaxSkewPercent))
+	preferredLeaderElections.With(labels).Add(float64(result.ElectedPartitions))
+	reorderedPartitions.With(labels).Add(float64(result.ReorderedPartitions))
+}
+
+// updateRackAuditMetrics exposes the number of partitions violating rack awareness and partitions planned to be repaired
+func updateRackAuditMetrics(namespace string, cluster string, violatingPartitions int, repairedPartitions int) {
+	labels := prometheus.Labels{"namespace": namespace, "cluster": cluster}
+	rackViolatingPartitions.With(labels).Set(float64(violatingPartitions))
+	rackRepairedPartitions.With(labels).Add(float64(repairedPartitions))
+}
@@ -0,0 +1,98 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"sort"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
+)
+
+// checkNodePools checks that node pools have unique names and non-overlapping ranges of broker IDs,
+// and that the total number of brokers in pools is equal to Replicas
+func checkNodePools(spec kafka.KafkaSpec, racksFromNodeLabels bool) error {
+	if len(spec.NodePools) == 0 {
+		return nil
+	}
+	names := make(map[string]bool, len(spec.NodePools))
+	pools := make([]kafka.NodePool, len(spec.NodePools))
+	copy(pools, spec.NodePools)
+	replicas := 0
+	for _, pool := range pools {
+		if pool.Name == "" {
+			return fmt.Errorf("node pool name must be specified")
+		}
+		if names[pool.Name] {
+			return fmt.Errorf("node pool '%s' is specified more than once", pool.Name)
+		}
+		names[pool.Name] = true
+		if pool.Replicas <= 0 {
+			return fmt.Errorf("node pool '%s': replicas must be greater than 0", pool.Name)
+		}
+		if pool.FirstBrokerId < 1 || pool.FirstBrokerId+pool.Replicas-1 > provider.MaxBrokerId {
+			return fmt.Errorf("node pool '%s': broker IDs must be in range from 1 to %d", pool.Name, provider.MaxBrokerId)
+		}
+		if len(pool.Racks) > 0 && len(pool.Racks) != pool.Replicas {
+			return fmt.Errorf("node pool '%s': the number of racks must be equal to replicas", pool.Name)
+		}
+		storage := spec.Storage
+		if pool.Storage != nil {
+			storage = *pool.Storage
+		} else if len(spec.Storage.Volumes) > 0 || len(spec.Storage.Nodes) > 0 || len(spec.Storage.Labels) > 0 {
+			return fmt.Errorf("node pool '%s': storage must be specified when Storage has volumes, nodes or labels", pool.Name)
+		}
+		if racksFromNodeLabels && len(storage.Nodes) != pool.Replicas {
+			return fmt.Errorf("node pool '%s': when GetRacksFromNodeLabels=true, the number of storage nodes must be equal to replicas", pool.Name)
+		}
+		replicas += pool.Replicas
+	}
+	sort.Slice(pools, func(i, j int) bool {
+		return pools[i].FirstBrokerId < pools[j].FirstBrokerId
+	})
+	for i := 1; i < len(pools); i++ {
+		if pools[i-1].FirstBrokerId+pools[i-1].Replicas > pools[i].FirstBrokerId {
+			return fmt.Errorf("broker IDs of node pools '%s' and '%s' overlap", pools[i-1].Name, pools[i].Name)
+		}
+	}
+	if replicas != spec.Replicas {
+		return fmt.Errorf("the total number of node pools replicas %d must be equal to replicas %d", replicas, spec.Replicas)
+	}
+	return nil
+}
+
+// subtractBrokerIds returns broker IDs which are present in the first list and absent in the second one
+func subtractBrokerIds(brokerIds []int, excludedIds []int) []int {
+	excluded := make(map[int]bool, len(excludedIds))
+	for _, id := range excludedIds {
+		excluded[id] = true
+	}
+	var result []int
+	for _, id := range brokerIds {
+		if !excluded[id] {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func toInt32BrokerIds(brokerIds []int) []int32 {
+	result := make([]int32, len(brokerIds))
+	for i, id := range brokerIds {
+		result[i] = int32(id)
+	}
+	return result
+}
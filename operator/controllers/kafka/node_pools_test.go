@@ -0,0 +1,62 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckNodePools(t *testing.T) {
+	spec := kafka.KafkaSpec{
+		Replicas: 4,
+		NodePools: []kafka.NodePool{
+			{Name: "general", Replicas: 3, FirstBrokerId: 1},
+			{Name: "large", Replicas: 1, FirstBrokerId: 100},
+		},
+	}
+	assert.NoError(t, checkNodePools(spec, false))
+	assert.NoError(t, checkNodePools(kafka.KafkaSpec{Replicas: 3}, false))
+
+	spec.Replicas = 5
+	assert.Error(t, checkNodePools(spec, false))
+	spec.Replicas = 4
+
+	spec.NodePools[1].FirstBrokerId = 3
+	assert.EqualError(t, checkNodePools(spec, false), "broker IDs of node pools 'general' and 'large' overlap")
+	spec.NodePools[1].FirstBrokerId = 2001
+	assert.Error(t, checkNodePools(spec, false))
+	spec.NodePools[1].FirstBrokerId = 100
+
+	spec.NodePools[1].Name = "general"
+	assert.Error(t, checkNodePools(spec, false))
+	spec.NodePools[1].Name = "large"
+
+	spec.NodePools[0].Racks = []string{"zone-a"}
+	assert.Error(t, checkNodePools(spec, false))
+	spec.NodePools[0].Racks = nil
+
+	assert.Error(t, checkNodePools(spec, true))
+	spec.Storage.Nodes = []string{"node-1", "node-2", "node-3", "node-4"}
+	assert.EqualError(t, checkNodePools(spec, false), "node pool 'general': storage must be specified when Storage has volumes, nodes or labels")
+}
+
+func TestSubtractBrokerIds(t *testing.T) {
+	assert.Equal(t, []int{100}, subtractBrokerIds([]int{1, 2, 100}, []int{1, 2, 3}))
+	assert.Equal(t, []int{3}, subtractBrokerIds([]int{1, 2, 3}, []int{1, 2, 100}))
+	assert.Nil(t, subtractBrokerIds([]int{1, 2}, []int{1, 2}))
+}
@@ -0,0 +1,487 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	reassignmentStateKey = "state.json"
+	// keys of proposal config map, reassignment and rollback are in the format of kafka-reassign-partitions tool
+	proposalReassignmentKey = "reassignment.json"
+	proposalRollbackKey     = "rollback.json"
+	proposalSummaryKey      = "summary.json"
+	// partitionsReassignmentCheckInterval is the interval of checking progress of partitions reassignment
+	partitionsReassignmentCheckInterval = 30 * time.Second
+	// partitionsReassignmentAnnotation on Kafka custom resource controls running partitions reassignment
+	partitionsReassignmentAnnotation = "kafkaservice.qubership.org/partitions-reassignment"
+	// cancelReassignmentAction cancels reassignment of topics being reassigned and discards the rest of the plan
+	cancelReassignmentAction = "cancel"
+	// pauseReassignmentAction waits for topics being reassigned and does not start the next ones until
+	// the annotation is removed
+	pauseReassignmentAction = "pause"
+)
+
+// reassignmentState is the state of partitions reassignment persisted in config map to resume reassignment
+// after operator restart. Plan is computed when all brokers are up and discarded if brokers or strategy change.
+type reassignmentState struct {
+	BrokerIds []int32                       `json:"brokerIds"`
+	Strategy  string                        `json:"strategy"`
+	StartTime time.Time                     `json:"startTime"`
+	Plan      *controllers.ReassignmentPlan `json:"plan,omitempty"`
+}
+
+func (s reassignmentState) matches(brokerIds []int32, strategy string) bool {
+	if s.Strategy != strategy || len(s.BrokerIds) != len(brokerIds) {
+		return false
+	}
+	for i := range brokerIds {
+		if s.BrokerIds[i] != brokerIds[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// reassignPartitions advances partitions reassignment by one step without waiting for brokers or moving replicas.
+// If reassignment is not finished, it is continued with the next reconciliation.
+func (r *ReconcileKafka) reassignPartitions(brokerIds []int, clusterScaling bool) error {
+	strategy := r.kafkaProvider.GetScalingStrategy()
+	dryRun := r.kafkaProvider.IsReassignPartitionsDryRun()
+	state, err := r.loadReassignmentState()
+	if err != nil {
+		return err
+	}
+	if state != nil && !state.matches(toInt32BrokerIds(brokerIds), strategy) {
+		r.logger.Info("Brokers or balancing strategy are changed, the previous reassignment plan is discarded")
+		if err = r.discardReassignmentState(brokerIds, state); err != nil {
+			return err
+		}
+		state = nil
+	}
+	// reassignment started for cluster scaling is continued after new brokers become current ones,
+	// dry run does not move replicas, so the proposal is computed even if reassignment is disabled
+	reassignPartitionsEnabled := dryRun || r.kafkaProvider.IsReassignPartitionsEnabled(clusterScaling || state != nil)
+	allBrokersStartTimeoutSeconds := r.kafkaProvider.GetAllBrokersStartTimeoutSeconds()
+	topicReassignmentTimeoutSeconds := r.kafkaProvider.GetTopicReassignmentTimeoutSeconds()
+	if !reassignPartitionsEnabled {
+		r.logger.Info("Partitions reassignment is disabled")
+		if err = r.discardReassignmentState(brokerIds, state); err != nil {
+			return err
+		}
+		return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
+			instance.Status.PartitionsReassignmentStatus.Status = "Disabled"
+		})
+	}
+	if state == nil {
+		// for cluster scaling we run reassignment without taking into account Status,
+		// because we can scale a cluster several times and always want to reassign
+		// despite the Finished status from previous reassignment
+		if !clusterScaling && !dryRun && r.cr.Status.PartitionsReassignmentStatus.Status == "Finished" {
+			r.logger.Info("Partitions are already reassigned. Skip reassignment")
+			return r.deleteReassignmentState()
+		}
+		if !clusterScaling && dryRun && r.cr.Status.PartitionsReassignmentStatus.Status == "Proposed" {
+			r.logger.Info("Partitions reassignment is already proposed. Skip reassignment")
+			return r.deleteReassignmentState()
+		}
+		if action := r.getReassignmentAction(); action != "" {
+			r.logger.Info(fmt.Sprintf("Partitions reassignment is not started, because %s action is requested with %s annotation",
+				action, partitionsReassignmentAnnotation))
+			return nil
+		}
+		r.logger.Info(fmt.Sprintf("Partitions reassignment is enabled, allBrokersStartTimeoutSeconds is %d, topicReassignmentTimeoutSeconds is %d", allBrokersStartTimeoutSeconds, topicReassignmentTimeoutSeconds))
+		err = r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
+			instance.Status.PartitionsReassignmentStatus.Status = "In Progress"
+			instance.Status.PartitionsReassignmentStatus.Progress = nil
+		})
+		if err != nil {
+			return err
+		}
+		state = &reassignmentState{BrokerIds: toInt32BrokerIds(brokerIds), Strategy: strategy, StartTime: time.Now()}
+		if err = r.saveReassignmentState(state); err != nil {
+			return err
+		}
+	}
+
+	switch r.getStateReassignmentAction(state, dryRun) {
+	case cancelReassignmentAction:
+		return r.cancelPartitionsReassignment(brokerIds, state)
+	case pauseReassignmentAction:
+		return r.pausePartitionsReassignment(brokerIds, state)
+	}
+
+	kafkaClient, err := r.newReassignmentKafkaClient(brokerIds)
+	if err != nil {
+		return err
+	}
+	defer kafkaClient.Close()
+	if state.Plan == nil {
+		allBrokersAreUp, err := kafkaClient.CheckAllBrokersAreUp()
+		if err != nil {
+			r.logger.Error(err, "cannot get active brokers")
+		}
+		if !allBrokersAreUp {
+			if time.Since(state.StartTime) > time.Duration(allBrokersStartTimeoutSeconds)*time.Second {
+				if err = r.deleteReassignmentState(); err != nil {
+					return err
+				}
+				return fmt.Errorf("not all brokers are started, timeout is expired")
+			}
+			r.logger.Info("Waiting for all brokers are up...")
+			r.reconciler.partitionsReassignmentInProgress = true
+			return nil
+		}
+		plan, err := kafkaClient.PlanPartitionsReassignment()
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			return r.proposePartitionsReassignment(kafkaClient, plan)
+		}
+		state.Plan = plan
+		if err = r.saveReassignmentState(state); err != nil {
+			return err
+		}
+	}
+
+	done, err := kafkaClient.AdvanceReassignmentPlan(state.Plan, time.Now(), func() error {
+		return r.saveReassignmentState(state)
+	})
+	// progress is saved even if the step failed to not repeat already started topics
+	if saveErr := r.saveReassignmentState(state); saveErr != nil {
+		return saveErr
+	}
+	if err != nil {
+		return err
+	}
+	if !done {
+		r.reconciler.partitionsReassignmentInProgress = true
+		return r.updateReassignmentProgress("In Progress", state.Plan)
+	}
+	if err = r.deleteReassignmentState(); err != nil {
+		return err
+	}
+	// the proposal is outdated when partitions are reassigned
+	if err = r.reconciler.DeleteConfigMapByName(r.getReassignmentProposalConfigMapName(), r.cr.Namespace, r.logger); err != nil {
+		return err
+	}
+	brokersBalance := r.cr.Status.PartitionsReassignmentStatus.Brokers
+	if balances, err := kafkaClient.DescribeBrokersBalance(); err != nil {
+		r.logger.Error(err, "Cannot describe distribution of partitions between brokers")
+	} else {
+		brokersBalance = toBrokerBalanceStatuses(balances)
+		updateBrokersBalanceMetrics(r.cr.Namespace, r.cr.Name, r.cr.Status.PartitionsReassignmentStatus.Brokers, brokersBalance)
+	}
+	progress := toReassignmentProgressStatus(state.Plan.Progress())
+	return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
+		instance.Status.PartitionsReassignmentStatus.Status = "Finished"
+		instance.Status.PartitionsReassignmentStatus.Brokers = brokersBalance
+		instance.Status.PartitionsReassignmentStatus.Proposal = nil
+		instance.Status.PartitionsReassignmentStatus.Progress = progress
+	})
+}
+
+// getReassignmentAction returns action requested for partitions reassignment with annotation of custom resource
+func (r *ReconcileKafka) getReassignmentAction() string {
+	action := r.cr.Annotations[partitionsReassignmentAnnotation]
+	if action != cancelReassignmentAction && action != pauseReassignmentAction {
+		if action != "" {
+			r.logger.Info(fmt.Sprintf("Unknown value '%s' of %s annotation is ignored", action, partitionsReassignmentAnnotation))
+		}
+		return ""
+	}
+	return action
+}
+
+// getStateReassignmentAction returns action requested for started partitions reassignment. Reassignment with
+// saved plan is paused in dry-run mode, because dry run must not move replicas, and resumed when dry run is disabled.
+func (r *ReconcileKafka) getStateReassignmentAction(state *reassignmentState, dryRun bool) string {
+	action := r.getReassignmentAction()
+	if action == "" && dryRun && state.Plan != nil {
+		r.logger.Info("Partitions reassignment is paused, because dry run is enabled")
+		return pauseReassignmentAction
+	}
+	return action
+}
+
+// cancelPartitionsReassignment cancels reassignment of topics which are being reassigned and discards the plan,
+// partitions of cancelled topics are returned to their current replicas
+func (r *ReconcileKafka) cancelPartitionsReassignment(brokerIds []int, state *reassignmentState) error {
+	var progress *kafka.ReassignmentProgressStatus
+	if state.Plan != nil {
+		kafkaClient, err := r.newReassignmentKafkaClient(brokerIds)
+		if err != nil {
+			return err
+		}
+		defer kafkaClient.Close()
+		err = kafkaClient.CancelReassignmentPlan(state.Plan)
+		// progress is saved even if cancellation failed to not cancel already cancelled topics
+		if saveErr := r.saveReassignmentState(state); saveErr != nil {
+			return saveErr
+		}
+		if err != nil {
+			return err
+		}
+		progress = toReassignmentProgressStatus(state.Plan.Progress())
+	}
+	if err := r.deleteReassignmentState(); err != nil {
+		return err
+	}
+	r.logger.Info("Partitions reassignment is cancelled")
+	return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
+		instance.Status.PartitionsReassignmentStatus.Status = "Cancelled"
+		instance.Status.PartitionsReassignmentStatus.Progress = progress
+	})
+}
+
+// pausePartitionsReassignment waits for topics which are being reassigned without starting the next ones.
+// The plan is kept, so reassignment is resumed when the annotation is removed or dry run is disabled.
+func (r *ReconcileKafka) pausePartitionsReassignment(brokerIds []int, state *reassignmentState) error {
+	if state.Plan != nil {
+		kafkaClient, err := r.newReassignmentKafkaClient(brokerIds)
+		if err != nil {
+			return err
+		}
+		defer kafkaClient.Close()
+		paused, err := kafkaClient.PauseReassignmentPlan(state.Plan, time.Now())
+		if saveErr := r.saveReassignmentState(state); saveErr != nil {
+			return saveErr
+		}
+		if err != nil {
+			return err
+		}
+		if !paused {
+			r.logger.Info("Partitions reassignment is pausing, waiting for topics being reassigned")
+			r.reconciler.partitionsReassignmentInProgress = true
+			return r.updateReassignmentProgress("In Progress", state.Plan)
+		}
+	}
+	r.logger.Info("Partitions reassignment is paused")
+	r.reconciler.partitionsReassignmentPaused = true
+	return r.updateReassignmentProgress("Paused", state.Plan)
+}
+
+// updateReassignmentProgress updates status of partitions reassignment only if it is changed,
+// because progress is checked on each reconciliation
+func (r *ReconcileKafka) updateReassignmentProgress(status string, plan *controllers.ReassignmentPlan) error {
+	var progress *kafka.ReassignmentProgressStatus
+	if plan != nil {
+		progress = toReassignmentProgressStatus(plan.Progress())
+	}
+	current := r.cr.Status.PartitionsReassignmentStatus
+	if current.Status == status && reflect.DeepEqual(current.Progress, progress) {
+		return nil
+	}
+	return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
+		instance.Status.PartitionsReassignmentStatus.Status = status
+		instance.Status.PartitionsReassignmentStatus.Progress = progress
+	})
+}
+
+// proposePartitionsReassignment stores reassignment plan in config map for review instead of moving replicas
+func (r *ReconcileKafka) proposePartitionsReassignment(kafkaClient *controllers.KafkaClient, plan *controllers.ReassignmentPlan) error {
+	proposal, err := kafkaClient.ProposePartitionsReassignment(plan)
+	if err != nil {
+		return err
+	}
+	data := map[string]string{}
+	for key, value := range map[string]interface{}{
+		proposalReassignmentKey: proposal.Reassignment,
+		proposalRollbackKey:     proposal.CurrentAssignment,
+		proposalSummaryKey:      proposal.Summary,
+	} {
+		content, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		data[key] = string(content)
+	}
+	configMap := r.kafkaProvider.NewKafkaConfigMap(r.getReassignmentProposalConfigMapName(), data)
+	if err = r.reconciler.SetControllerReference(r.cr, configMap, r.reconciler.Scheme); err != nil {
+		return err
+	}
+	if err = r.reconciler.CreateOrUpdateConfigMap(configMap, r.logger); err != nil {
+		return err
+	}
+	if err = r.deleteReassignmentState(); err != nil {
+		return err
+	}
+	r.logger.Info(fmt.Sprintf("Partitions reassignment is proposed in dry-run mode: %d partitions of %d topics, %d bytes to move",
+		proposal.Summary.Partitions, len(proposal.Summary.Topics), proposal.Summary.BytesToMove))
+	proposalStatus := toReassignmentProposalStatus(configMap.Name, proposal.Summary)
+	return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
+		instance.Status.PartitionsReassignmentStatus.Status = "Proposed"
+		instance.Status.PartitionsReassignmentStatus.Proposal = proposalStatus
+	})
+}
+
+func (r *ReconcileKafka) newReassignmentKafkaClient(brokerIds []int) (*controllers.KafkaClient, error) {
+	username, password, err := r.getKafkaCredentials()
+	if err != nil {
+		return nil, err
+	}
+	sslCertificates, err := r.getKafkaCertificates()
+	if err != nil {
+		return nil, err
+	}
+	return controllers.NewKafkaClient(
+		r.kafkaProvider.GetServiceName(),
+		username,
+		password,
+		r.cr.Spec.Ssl.Enabled,
+		sslCertificates,
+		toInt32BrokerIds(brokerIds),
+		r.kafkaProvider.GetBrokerPools(),
+		r.kafkaProvider.GetAllBrokersStartTimeoutSeconds(),
+		r.kafkaProvider.GetTopicReassignmentTimeoutSeconds(),
+		r.kafkaProvider.GetReplicationThrottleBytesPerSec(),
+		r.kafkaProvider.GetMaxConcurrentPartitionMoves(),
+		r.kafkaProvider.GetMaxBytesInFlight(),
+		controllers.BalancingSettings{
+			Strategy:                  r.kafkaProvider.GetScalingStrategy(),
+			BrokerCapacityBytes:       r.kafkaProvider.GetBrokersStorageCapacity(),
+			MaxBrokerDiskUsagePercent: r.kafkaProvider.GetMaxBrokerDiskUsagePercent(),
+		})
+}
+
+func (r ReconcileKafka) getReassignmentStateConfigMapName() string {
+	return fmt.Sprintf("%s-partitions-reassignment", r.cr.Name)
+}
+
+func (r ReconcileKafka) getReassignmentProposalConfigMapName() string {
+	return fmt.Sprintf("%s-partitions-reassignment-proposal", r.cr.Name)
+}
+
+// loadReassignmentState returns the state of partitions reassignment or nil if reassignment is not started
+func (r ReconcileKafka) loadReassignmentState() (*reassignmentState, error) {
+	configMap, err := r.reconciler.FindConfigMap(r.getReassignmentStateConfigMapName(), r.cr.Namespace, r.logger)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	state := &reassignmentState{}
+	if err = json.Unmarshal([]byte(configMap.Data[reassignmentStateKey]), state); err != nil {
+		r.logger.Error(err, "Cannot parse the state of partitions reassignment, it is started from the beginning")
+		return nil, nil
+	}
+	return state, nil
+}
+
+func (r ReconcileKafka) saveReassignmentState(state *reassignmentState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	configMap := r.kafkaProvider.NewKafkaConfigMap(r.getReassignmentStateConfigMapName(), map[string]string{reassignmentStateKey: string(data)})
+	if err = r.reconciler.SetControllerReference(r.cr, configMap, r.reconciler.Scheme); err != nil {
+		return err
+	}
+	return r.reconciler.CreateOrUpdateConfigMap(configMap, r.logger)
+}
+
+func (r ReconcileKafka) deleteReassignmentState() error {
+	return r.reconciler.DeleteConfigMapByName(r.getReassignmentStateConfigMapName(), r.cr.Namespace, r.logger)
+}
+
+// discardReassignmentState removes replication throttles recorded in the plan of discarded reassignment and deletes
+// its state. Throttles which cannot be removed are logged, so that they do not block the next reassignment.
+func (r *ReconcileKafka) discardReassignmentState(brokerIds []int, state *reassignmentState) error {
+	if state != nil && state.Plan != nil {
+		if err := r.removeStaleReplicationThrottles(brokerIds, state.Plan); err != nil {
+			r.logger.Error(err, "Cannot remove replication throttles of discarded partitions reassignment")
+		}
+	}
+	return r.deleteReassignmentState()
+}
+
+func (r *ReconcileKafka) removeStaleReplicationThrottles(brokerIds []int, plan *controllers.ReassignmentPlan) error {
+	kafkaClient, err := r.newReassignmentKafkaClient(brokerIds)
+	if err != nil {
+		return err
+	}
+	defer kafkaClient.Close()
+	return kafkaClient.RemoveStaleReplicationThrottles(plan)
+}
+
+// isPartitionsReassignmentInProgress returns true if partitions reassignment is continued with the next reconciliation
+func (r *KafkaReconciler) isPartitionsReassignmentInProgress() bool {
+	return r.partitionsReassignmentInProgress
+}
+
+// isPartitionsReassignmentPaused returns true if partitions reassignment is paused until the annotation is removed
+// or dry run is disabled
+func (r *KafkaReconciler) isPartitionsReassignmentPaused() bool {
+	return r.partitionsReassignmentPaused
+}
+
+func toBrokerBalanceStatuses(balances []controllers.BrokerBalance) []kafka.BrokerBalanceStatus {
+	statuses := make([]kafka.BrokerBalanceStatus, 0, len(balances))
+	for _, balance := range balances {
+		statuses = append(statuses, kafka.BrokerBalanceStatus{
+			BrokerId:       balance.BrokerId,
+			Pool:           balance.Pool,
+			Replicas:       balance.Replicas,
+			Leaders:        balance.Leaders,
+			DiskUsageBytes: balance.DiskUsageBytes,
+			Skew:           balance.Skew,
+		})
+	}
+	return statuses
+}
+
+func toReassignmentProposalStatus(configMapName string, summary controllers.ReassignmentProposalSummary) *kafka.ReassignmentProposalStatus {
+	status := &kafka.ReassignmentProposalStatus{
+		ConfigMapName: configMapName,
+		Topics:        len(summary.Topics),
+		Partitions:    summary.Partitions,
+		BytesToMove:   summary.BytesToMove,
+	}
+	for _, broker := range summary.Brokers {
+		status.MaxSkewBefore = maxAbsInt32(status.MaxSkewBefore, broker.Before.Skew)
+		status.MaxSkewAfter = maxAbsInt32(status.MaxSkewAfter, broker.After.Skew)
+	}
+	return status
+}
+
+func toReassignmentProgressStatus(progress controllers.ReassignmentProgress) *kafka.ReassignmentProgressStatus {
+	return &kafka.ReassignmentProgressStatus{
+		CompletedTopics:    progress.Completed,
+		FailedTopics:       progress.Failed,
+		RemainingTopics:    progress.Remaining,
+		CancelledTopics:    progress.Cancelled,
+		TimedOutPartitions: progress.TimedOut,
+	}
+}
+
+func maxAbsInt32(current int32, value int32) int32 {
+	if value < 0 {
+		value = -value
+	}
+	if value > current {
+		return value
+	}
+	return current
+}
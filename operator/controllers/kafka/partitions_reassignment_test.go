@@ -0,0 +1,165 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReassignmentStateMatches(t *testing.T) {
+	state := reassignmentState{BrokerIds: []int32{1, 2, 3}, Strategy: controllers.LeaderSkewStrategy}
+	assert.True(t, state.matches([]int32{1, 2, 3}, controllers.LeaderSkewStrategy))
+	assert.False(t, state.matches([]int32{1, 2, 3}, controllers.DiskWeightedStrategy))
+	assert.False(t, state.matches([]int32{1, 2}, controllers.LeaderSkewStrategy))
+	assert.False(t, state.matches([]int32{1, 2, 4}, controllers.LeaderSkewStrategy))
+}
+
+func TestReassignmentStateIsPersisted(t *testing.T) {
+	r, _ := newTestReconcileKafka(t, kafka.KafkaSpec{})
+	state, err := r.loadReassignmentState()
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+
+	startTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	expected := &reassignmentState{
+		BrokerIds: []int32{1, 2, 3},
+		Strategy:  controllers.LeaderSkewStrategy,
+		StartTime: startTime,
+		Plan: &controllers.ReassignmentPlan{Topics: []controllers.TopicReassignment{{
+			Topic:           "test",
+			CurrentReplicas: [][]int32{{1, 2}},
+			Replicas:        [][]int32{{3, 2}},
+			Status:          controllers.TopicReassignmentInProgress,
+			StartTime:       &startTime,
+		}}},
+	}
+	assert.NoError(t, r.saveReassignmentState(expected))
+	state, err = r.loadReassignmentState()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, state)
+
+	assert.NoError(t, r.deleteReassignmentState())
+	state, err = r.loadReassignmentState()
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+}
+
+func TestToReassignmentProposalStatus(t *testing.T) {
+	summary := controllers.ReassignmentProposalSummary{
+		Partitions:  3,
+		BytesToMove: 1024,
+		Topics:      []controllers.TopicMoves{{Topic: "first"}, {Topic: "second"}},
+		Brokers: []controllers.BrokerSkew{
+			{BrokerId: 1, Before: controllers.LoadSummary{Skew: 20}, After: controllers.LoadSummary{Skew: -3}},
+			{BrokerId: 2, Before: controllers.LoadSummary{Skew: -40}, After: controllers.LoadSummary{Skew: 2}},
+		},
+	}
+	assert.Equal(t, &kafka.ReassignmentProposalStatus{
+		ConfigMapName: "kafka-partitions-reassignment-proposal",
+		Topics:        2,
+		Partitions:    3,
+		BytesToMove:   1024,
+		MaxSkewBefore: 40,
+		MaxSkewAfter:  3,
+	}, toReassignmentProposalStatus("kafka-partitions-reassignment-proposal", summary))
+}
+
+func TestGetReassignmentAction(t *testing.T) {
+	r, cr := newTestReconcileKafka(t, kafka.KafkaSpec{})
+	assert.Equal(t, "", r.getReassignmentAction())
+	cr.Annotations = map[string]string{partitionsReassignmentAnnotation: "pause"}
+	assert.Equal(t, pauseReassignmentAction, r.getReassignmentAction())
+	cr.Annotations[partitionsReassignmentAnnotation] = "cancel"
+	assert.Equal(t, cancelReassignmentAction, r.getReassignmentAction())
+	cr.Annotations[partitionsReassignmentAnnotation] = "stop"
+	assert.Equal(t, "", r.getReassignmentAction())
+}
+
+func TestGetStateReassignmentAction(t *testing.T) {
+	r, cr := newTestReconcileKafka(t, kafka.KafkaSpec{})
+	state := &reassignmentState{BrokerIds: []int32{1, 2}, Strategy: controllers.LeaderSkewStrategy, StartTime: time.Now()}
+	// the proposal is computed in dry-run mode if the plan is not saved yet
+	assert.Equal(t, "", r.getStateReassignmentAction(state, true))
+	state.Plan = &controllers.ReassignmentPlan{}
+	assert.Equal(t, "", r.getStateReassignmentAction(state, false))
+	// saved plan does not move replicas in dry-run mode
+	assert.Equal(t, pauseReassignmentAction, r.getStateReassignmentAction(state, true))
+	cr.Annotations = map[string]string{partitionsReassignmentAnnotation: cancelReassignmentAction}
+	assert.Equal(t, cancelReassignmentAction, r.getStateReassignmentAction(state, true))
+}
+
+func TestPartitionsReassignmentIsPausedAndCancelledBeforePlanning(t *testing.T) {
+	r, cr := newTestReconcileKafka(t, kafka.KafkaSpec{})
+	state := &reassignmentState{BrokerIds: []int32{1, 2}, Strategy: controllers.LeaderSkewStrategy, StartTime: time.Now()}
+	assert.NoError(t, r.saveReassignmentState(state))
+
+	assert.NoError(t, r.pausePartitionsReassignment([]int{1, 2}, state))
+	assert.True(t, r.reconciler.isPartitionsReassignmentPaused())
+	status, err := r.reconciler.StatusUpdater.GetStatus()
+	assert.NoError(t, err)
+	assert.Equal(t, "Paused", status.PartitionsReassignmentStatus.Status)
+	saved, err := r.loadReassignmentState()
+	assert.NoError(t, err)
+	assert.NotNil(t, saved)
+
+	cr.Status = *status
+	assert.NoError(t, r.cancelPartitionsReassignment([]int{1, 2}, state))
+	status, err = r.reconciler.StatusUpdater.GetStatus()
+	assert.NoError(t, err)
+	assert.Equal(t, "Cancelled", status.PartitionsReassignmentStatus.Status)
+	saved, err = r.loadReassignmentState()
+	assert.NoError(t, err)
+	assert.Nil(t, saved)
+}
+
+func TestContinuePartitionsReassignment(t *testing.T) {
+	r, cr := newTestReconcileKafka(t, kafka.KafkaSpec{})
+	// nothing is done if reassignment is not started
+	assert.NoError(t, r.continuePartitionsReassignment([]int{1}))
+	assert.False(t, r.reconciler.isPartitionsReassignmentInProgress())
+	assert.False(t, r.reconciler.isWaitingForMaintenanceWindow())
+
+	// started reassignment is continued only in maintenance window as with changed configuration
+	replicas := int32(1)
+	assert.NoError(t, r.reconciler.Client.Create(context.TODO(), &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka-1", Namespace: "kafka-service", Labels: controllers.GetKafkaLabels("kafka")},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	}))
+	state := &reassignmentState{BrokerIds: []int32{1}, Strategy: controllers.LeaderSkewStrategy, StartTime: time.Now()}
+	assert.NoError(t, r.saveReassignmentState(state))
+	cr.Spec.MaintenanceWindows = []kafka.MaintenanceWindow{{Schedule: "0 0 1 1 *", Duration: "1m"}}
+	assert.NoError(t, r.continuePartitionsReassignment([]int{1}))
+	assert.True(t, r.reconciler.isWaitingForMaintenanceWindow())
+	saved, err := r.loadReassignmentState()
+	assert.NoError(t, err)
+	assert.NotNil(t, saved)
+}
+
+func TestToReassignmentProgressStatus(t *testing.T) {
+	assert.Equal(t, &kafka.ReassignmentProgressStatus{
+		CompletedTopics: 2,
+		FailedTopics:    1,
+		RemainingTopics: 3,
+		CancelledTopics: []string{"test"},
+	}, toReassignmentProgressStatus(controllers.ReassignmentProgress{Completed: 2, Failed: 1, Remaining: 3, Cancelled: []string{"test"}}))
+}
@@ -0,0 +1,37 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
+)
+
+// reconcilePodDisruptionBudgets creates PodDisruptionBudgets which allow only one unavailable broker
+// and one unavailable dedicated Kraft controller during node drain
+func (r ReconcileKafka) reconcilePodDisruptionBudgets(kraft bool) error {
+	enabled := provider.IsPodDisruptionBudgetEnabled(r.cr.Spec.PodDisruptionBudget.Enabled)
+	if err := r.reconciler.ReconcilePodDisruptionBudget(r.cr, r.kafkaProvider.NewKafkaBrokerPodDisruptionBudgetForCR(),
+		enabled, r.logger); err != nil {
+		return err
+	}
+	return r.reconciler.ReconcilePodDisruptionBudget(r.cr, r.kafkaProvider.NewKafkaQuorumControllerPodDisruptionBudgetForCR(),
+		enabled && kraft && r.kafkaProvider.IsQuorumControllersEnabled(), r.logger)
+}
+
+// reconcileKraftControllerPodDisruptionBudget creates PodDisruptionBudget for Kraft controller used for migration
+func (r ReconcileKafka) reconcileKraftControllerPodDisruptionBudget() error {
+	return r.reconciler.ReconcilePodDisruptionBudget(r.cr, r.kafkaProvider.NewKafkaKraftControllerPodDisruptionBudgetForCR(),
+		provider.IsPodDisruptionBudgetEnabled(r.cr.Spec.MigrationController.PodDisruptionBudget.Enabled), r.logger)
+}
@@ -0,0 +1,123 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"time"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+)
+
+// auditRackAwareness checks once per interval that replicas of partitions are spread across racks of brokers.
+// If repair is enabled, replicas sharing a rack are moved to other racks by partitions reassignment which is
+// started in maintenance window and continued with the next reconciliations like reassignment for cluster scaling.
+// Errors are logged without failing reconciliation, the check is repeated after the interval.
+func (r ReconcileKafka) auditRackAwareness(brokerIds []int) error {
+	if !r.kafkaProvider.IsRackAuditEnabled() || !r.isRackConfigured(brokerIds) {
+		return nil
+	}
+	interval := time.Duration(r.kafkaProvider.GetRackAuditIntervalSeconds()) * time.Second
+	if !isPeriodicCheckDue(r.cr.Status.RackAuditStatus.LastCheckTime, interval, time.Now()) {
+		return nil
+	}
+	repair, err := r.isRackRepairAllowed()
+	if err != nil {
+		return err
+	}
+	kafkaClient, err := r.newReassignmentKafkaClient(brokerIds)
+	if err != nil {
+		r.logger.Error(err, "Cannot connect to Kafka to check placement of replicas across racks")
+		return nil
+	}
+	defer kafkaClient.Close()
+	// racks of brokers are known only when all brokers are registered
+	if allBrokersAreUp, err := kafkaClient.CheckAllBrokersAreUp(); err != nil || !allBrokersAreUp {
+		r.logger.Info("Placement of replicas across racks is not checked, because not all brokers are up")
+		return nil
+	}
+	result, err := kafkaClient.AuditRackAwareness(repair)
+	if err != nil {
+		r.logger.Error(err, "Cannot check placement of replicas across racks")
+		return nil
+	}
+	repairedPartitions := 0
+	if result.RepairPlan != nil && len(result.RepairPlan.Topics) > 0 {
+		for _, topic := range result.RepairPlan.Topics {
+			repairedPartitions += len(topic.Partitions)
+		}
+		state := &reassignmentState{
+			BrokerIds: toInt32BrokerIds(brokerIds),
+			Strategy:  r.kafkaProvider.GetScalingStrategy(),
+			StartTime: time.Now(),
+			Plan:      result.RepairPlan,
+		}
+		if err = r.saveReassignmentState(state); err != nil {
+			return err
+		}
+		r.logger.Info(fmt.Sprintf("Partitions reassignment is started to move replicas of %d partitions to other racks", repairedPartitions))
+		r.reconciler.partitionsReassignmentInProgress = true
+	}
+	updateRackAuditMetrics(r.cr.Namespace, r.cr.Name, len(result.ViolatingPartitions), repairedPartitions)
+	return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
+		instance.Status.RackAuditStatus = kafka.RackAuditStatus{
+			LastCheckTime:       time.Now().UTC().Format(time.RFC3339),
+			ViolatingPartitions: len(result.ViolatingPartitions),
+			RepairedPartitions:  repairedPartitions,
+		}
+		if repairedPartitions > 0 {
+			instance.Status.PartitionsReassignmentStatus.Status = "In Progress"
+			instance.Status.PartitionsReassignmentStatus.Progress = nil
+		}
+	})
+}
+
+// isRackRepairAllowed returns true if repair is enabled and partitions can be reassigned now: reassignment is not
+// disabled, proposed only or controlled with annotation, another reassignment is not started and maintenance window is open
+func (r ReconcileKafka) isRackRepairAllowed() (bool, error) {
+	if !r.kafkaProvider.IsRackAuditRepairEnabled() {
+		return false, nil
+	}
+	if !r.kafkaProvider.IsReassignPartitionsEnabled(true) || r.kafkaProvider.IsReassignPartitionsDryRun() ||
+		r.getReassignmentAction() != "" {
+		r.logger.Info("Replicas are not moved to other racks, because partitions reassignment is disabled, dry run or controlled with annotation")
+		return false, nil
+	}
+	state, err := r.loadReassignmentState()
+	if err != nil || state != nil {
+		return false, err
+	}
+	open, nextStart, err := r.checkMaintenanceWindows()
+	if err != nil {
+		return false, err
+	}
+	if !open {
+		r.logger.Info(fmt.Sprintf("Moving replicas to other racks is postponed until maintenance window at %s", nextStart.Format(time.RFC3339)))
+	}
+	return open, nil
+}
+
+// isRackConfigured returns true if racks of brokers are taken from node labels or specified for some of brokers
+func (r ReconcileKafka) isRackConfigured(brokerIds []int) bool {
+	if r.isGetRacksFromNodeLabelsEnabled() {
+		return true
+	}
+	for _, brokerId := range brokerIds {
+		if r.kafkaProvider.GetBrokerConfiguredRack(brokerId) != "" {
+			return true
+		}
+	}
+	return false
+}
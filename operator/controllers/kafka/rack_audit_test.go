@@ -0,0 +1,59 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRackConfigured(t *testing.T) {
+	r, cr := newTestReconcileKafka(t, kafka.KafkaSpec{})
+	assert.False(t, r.isRackConfigured([]int{1, 2}))
+	cr.Spec.Racks = []string{"zone-a", "zone-b"}
+	r.kafkaProvider = provider.NewKafkaResourceProvider(cr, logr.Discard())
+	assert.True(t, r.isRackConfigured([]int{1, 2}))
+}
+
+func TestIsRackRepairAllowed(t *testing.T) {
+	r, cr := newTestReconcileKafka(t, kafka.KafkaSpec{})
+	allowed, err := r.isRackRepairAllowed()
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+
+	cr.Spec.Scaling.RackAudit.Repair = true
+	allowed, err = r.isRackRepairAllowed()
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+
+	cr.Annotations = map[string]string{partitionsReassignmentAnnotation: pauseReassignmentAction}
+	allowed, err = r.isRackRepairAllowed()
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+	cr.Annotations = nil
+
+	// reassignment which is already started is not replaced
+	state := &reassignmentState{BrokerIds: []int32{1, 2}, Strategy: controllers.LeaderSkewStrategy, StartTime: time.Now()}
+	assert.NoError(t, r.saveReassignmentState(state))
+	allowed, err = r.isRackRepairAllowed()
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+}
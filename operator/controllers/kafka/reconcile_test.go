@@ -0,0 +1,50 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"testing"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newTestReconcileKafka returns reconciler of "kafka" custom resource with given spec. Fake client contains
+// the custom resource and given objects, so that tests create only the state they check.
+func newTestReconcileKafka(t *testing.T, spec kafka.KafkaSpec, objects ...client.Object) (*ReconcileKafka, *kafka.Kafka) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, kafka.AddToScheme(scheme))
+	cr := &kafka.Kafka{
+		TypeMeta:   metav1.TypeMeta{APIVersion: kafka.GroupVersion.String(), Kind: "Kafka"},
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka-service", UID: "kafka-uid"},
+		Spec:       spec,
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, cr.DeepCopy())...).Build()
+	reconciler := &KafkaReconciler{
+		Reconciler:    controllers.Reconciler{Client: fakeClient, Scheme: scheme},
+		StatusUpdater: NewStatusUpdater(fakeClient, cr),
+	}
+	return &ReconcileKafka{cr: cr, reconciler: reconciler, logger: logr.Discard(),
+		kafkaProvider: provider.NewKafkaResourceProvider(cr, logr.Discard())}, cr
+}
@@ -0,0 +1,321 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"time"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const statefulSetMigrationTimeout = 300 * time.Second
+
+// statefulSetMinServerVersion is the first Kubernetes version which supports start ordinal of stateful set
+// and sets pod index label used as broker ID by default
+var statefulSetMinServerVersion = version.MustParseGeneric("1.28.0")
+
+// checkStatefulSet checks that Kafka configuration does not contain per-broker parameters which cannot be applied
+// to identical pods of stateful set
+func checkStatefulSet(spec kafka.KafkaSpec, externalAccessType string, deploymentCertificates bool) error {
+	if spec.PodManagement != provider.StatefulSetPodManagement {
+		return nil
+	}
+	var unsupported []string
+	if len(spec.NodePools) > 0 {
+		unsupported = append(unsupported, "nodePools")
+	}
+	if len(spec.ExternalHostNames) > 0 || externalAccessType != "" {
+		unsupported = append(unsupported, "external access")
+	}
+	if len(spec.Racks) > 0 || (spec.GetRacksFromNodeLabels != nil && *spec.GetRacksFromNodeLabels) {
+		unsupported = append(unsupported, "racks")
+	}
+	storage := spec.Storage
+	if len(storage.Volumes) > 0 || len(storage.Nodes) > 0 || len(storage.Labels) > 0 || len(storage.ClassName) > 1 {
+		unsupported = append(unsupported, "storage volumes, nodes, labels or several class names")
+	}
+	if len(storage.DataVolumes) > 0 {
+		unsupported = append(unsupported, "storage dataVolumes")
+	}
+	if deploymentCertificates {
+		unsupported = append(unsupported, "certificates issued for each broker")
+	}
+	if spec.Kraft.Migration {
+		unsupported = append(unsupported, "Kraft migration")
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("podManagement '%s' does not support %v", provider.StatefulSetPodManagement, unsupported)
+	}
+	return nil
+}
+
+// rolloutBrokerStatefulSet migrates broker deployments to stateful set if they exist, updates the stateful set
+// and restarts outdated broker pods one by one. Excess brokers are kept until their partitions are reassigned.
+func (r ReconcileKafka) rolloutBrokerStatefulSet(brokerIds []int, currentBrokerIds []int, kraft bool, kafkaSecret *corev1.Secret) error {
+	deploymentBrokerIds, err := r.getDeploymentBrokerIds()
+	if err != nil {
+		return err
+	}
+	if len(deploymentBrokerIds) > 0 {
+		if err = r.migrateBrokersToStatefulSet(deploymentBrokerIds, kraft, kafkaSecret); err != nil {
+			return err
+		}
+	}
+	replicas := len(brokerIds)
+	if len(currentBrokerIds) > replicas {
+		replicas = len(currentBrokerIds)
+	}
+	for brokerId := 1; brokerId <= replicas; brokerId++ {
+		brokerService := r.kafkaProvider.NewKafkaBrokerServiceForCR(brokerId)
+		if err = r.reconciler.SetControllerReference(r.cr, brokerService, r.reconciler.Scheme); err != nil {
+			return err
+		}
+		if err = r.reconciler.CreateOrUpdateService(brokerService, r.logger); err != nil {
+			return err
+		}
+	}
+	if err = r.updateBrokerStatefulSet(replicas, kraft, kafkaSecret); err != nil {
+		return err
+	}
+	return r.restartOutdatedBrokerPods(replicas)
+}
+
+func (r ReconcileKafka) updateBrokerStatefulSet(replicas int, kraft bool, kafkaSecret *corev1.Secret) error {
+	statefulSet := r.kafkaProvider.NewKafkaBrokerStatefulSetForCR(replicas, kraft, "")
+	if err := r.reconciler.SetControllerReference(r.cr, statefulSet, r.reconciler.Scheme); err != nil {
+		return err
+	}
+	if kafkaSecret.Annotations != nil && kafkaSecret.Annotations[autoRestartAnnotation] == "true" {
+		if statefulSet.Spec.Template.Annotations == nil {
+			statefulSet.Spec.Template.Annotations = map[string]string{}
+		}
+		statefulSet.Spec.Template.Annotations[fmt.Sprintf(resourceVersionAnnotationTemplate, kafkaSecret.Name)] = kafkaSecret.ResourceVersion
+	}
+	return r.reconciler.CreateOrUpdateStatefulSet(statefulSet, provider.StatefulSetStartOrdinal, r.logger)
+}
+
+// migrateBrokersToStatefulSet moves brokers from deployments to stateful set one by one. Each deployment is deleted
+// before the stateful set is scaled to create pod with the same name, so the pod reuses persistent volume claim
+// of the deployment and the broker keeps its ID.
+func (r ReconcileKafka) migrateBrokersToStatefulSet(deploymentBrokerIds []int, kraft bool, kafkaSecret *corev1.Secret) error {
+	// stateful set may already contain brokers migrated during previous reconciliation
+	statefulSetBrokerIds, err := r.getStatefulSetBrokerIds()
+	if err != nil {
+		return err
+	}
+	firstBrokerId := len(statefulSetBrokerIds) + provider.StatefulSetStartOrdinal
+	for i, brokerId := range deploymentBrokerIds {
+		if brokerId != firstBrokerId+i {
+			return fmt.Errorf("brokers %v cannot be migrated to stateful set, their IDs must be contiguous starting from %d",
+				deploymentBrokerIds, firstBrokerId)
+		}
+	}
+	// stateful set is applied with already migrated brokers and checked before any deployment is deleted
+	if err = r.updateBrokerStatefulSet(firstBrokerId-provider.StatefulSetStartOrdinal, kraft, kafkaSecret); err != nil {
+		return err
+	}
+	if err = r.checkStatefulSetOrdinals(); err != nil {
+		return err
+	}
+	r.logger.Info(fmt.Sprintf("Migrating brokers %v from deployments to stateful set", deploymentBrokerIds))
+	for _, brokerId := range deploymentBrokerIds {
+		deploymentName := fmt.Sprintf("%s-%d", r.cr.Name, brokerId)
+		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: deploymentName, Namespace: r.cr.Namespace}}
+		if err := r.reconciler.DeleteDeployment(deployment, r.logger); err != nil {
+			return err
+		}
+		if err := r.waitUntilDeploymentPodsDeleted(deploymentName); err != nil {
+			return err
+		}
+		if err := r.updateBrokerStatefulSet(brokerId, kraft, kafkaSecret); err != nil {
+			return err
+		}
+		if err := r.waitUntilBrokerIsReady(brokerId, int(statefulSetMigrationTimeout.Seconds())); err != nil {
+			return err
+		}
+	}
+	// deployments of brokers scaled down earlier do not have pods and are just removed
+	deployments, err := r.reconciler.FindKafkaDeployments(r.cr)
+	if err != nil {
+		return err
+	}
+	for i := range deployments.Items {
+		if _, ok := parseBrokerId(r.cr.Name, deployments.Items[i].Name); !ok {
+			continue
+		}
+		if err = r.reconciler.DeleteDeployment(&deployments.Items[i], r.logger); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkStatefulSetOrdinals checks that Kubernetes keeps start ordinal of broker stateful set and sets pod index label,
+// otherwise pods created instead of deleted deployments would get other names and broker IDs
+func (r ReconcileKafka) checkStatefulSetOrdinals() error {
+	name := r.kafkaProvider.GetBrokerStatefulSetName()
+	startOrdinal, found, err := r.reconciler.GetStatefulSetStartOrdinal(name, r.cr.Namespace)
+	if err != nil {
+		return err
+	}
+	if !found || startOrdinal != provider.StatefulSetStartOrdinal {
+		return fmt.Errorf("stateful set %s does not keep start ordinal %d, Kubernetes %s or later is required "+
+			"to migrate brokers from deployments", name, provider.StatefulSetStartOrdinal, statefulSetMinServerVersion)
+	}
+	serverVersion, err := r.reconciler.GetServerVersion()
+	if err != nil {
+		return err
+	}
+	if !serverVersion.AtLeast(statefulSetMinServerVersion) {
+		return fmt.Errorf("Kubernetes %s does not set pod index label, Kubernetes %s or later is required "+
+			"to migrate brokers from deployments", serverVersion, statefulSetMinServerVersion)
+	}
+	return nil
+}
+
+func (r ReconcileKafka) waitUntilDeploymentPodsDeleted(deploymentName string) error {
+	labels := r.kafkaProvider.GetSelectorLabels()
+	labels["name"] = deploymentName
+	return wait.PollImmediate(waitingInterval, statefulSetMigrationTimeout, func() (bool, error) {
+		podList, err := r.reconciler.FindPodList(r.cr.Namespace, labels)
+		if err != nil {
+			return false, err
+		}
+		return len(podList.Items) == 0, nil
+	})
+}
+
+// restartOutdatedBrokerPods deletes broker pods created from previous revision of stateful set, so that they are
+// recreated with the current one. With rolling update the next pod is deleted after the previous broker is ready.
+func (r ReconcileKafka) restartOutdatedBrokerPods(replicas int) error {
+	name := r.kafkaProvider.GetBrokerStatefulSetName()
+	var updateRevision string
+	err := wait.PollImmediate(waitingInterval, statefulSetMigrationTimeout, func() (bool, error) {
+		statefulSet, err := r.reconciler.FindStatefulSet(name, r.cr.Namespace, r.logger)
+		if err != nil {
+			return false, err
+		}
+		updateRevision = statefulSet.Status.UpdateRevision
+		return statefulSet.Status.ObservedGeneration >= statefulSet.Generation, nil
+	})
+	if err != nil {
+		return err
+	}
+	for brokerId := 1; brokerId <= replicas; brokerId++ {
+		podList, err := r.reconciler.FindPodList(r.cr.Namespace, r.kafkaProvider.GetBrokerPodSelectorLabels(brokerId))
+		if err != nil {
+			return err
+		}
+		restarted := false
+		for i := range podList.Items {
+			pod := &podList.Items[i]
+			if updateRevision == "" || pod.Labels[appsv1.StatefulSetRevisionLabel] == updateRevision {
+				continue
+			}
+			if err = r.reconciler.DeletePod(pod, r.logger); err != nil {
+				return err
+			}
+			restarted = true
+		}
+		if restarted && r.cr.Spec.RollingUpdate {
+			if err = r.waitUntilBrokerIsReady(brokerId, 300); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getDeploymentBrokerIds returns sorted IDs of brokers whose deployments are not scaled down
+func (r ReconcileKafka) getDeploymentBrokerIds() ([]int, error) {
+	deployments, err := r.reconciler.FindKafkaDeployments(r.cr)
+	if err != nil {
+		return nil, err
+	}
+	var brokerIds []int
+	for _, deployment := range deployments.Items {
+		if *deployment.Spec.Replicas == 0 {
+			continue
+		}
+		if brokerId, ok := parseBrokerId(r.cr.Name, deployment.Name); ok {
+			brokerIds = append(brokerIds, brokerId)
+		}
+	}
+	return brokerIds, nil
+}
+
+// getStatefulSetBrokerIds returns IDs of brokers in stateful set
+func (r ReconcileKafka) getStatefulSetBrokerIds() ([]int, error) {
+	statefulSet, err := r.reconciler.FindStatefulSet(r.kafkaProvider.GetBrokerStatefulSetName(), r.cr.Namespace, r.logger)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var brokerIds []int
+	for i := 0; i < int(*statefulSet.Spec.Replicas); i++ {
+		brokerIds = append(brokerIds, i+provider.StatefulSetStartOrdinal)
+	}
+	return brokerIds, nil
+}
+
+// isBrokerPodReady checks that broker pod exists, is not terminating and is ready
+func (r ReconcileKafka) isBrokerPodReady(brokerId int) bool {
+	podList, err := r.reconciler.FindPodList(r.cr.Namespace, r.kafkaProvider.GetBrokerPodSelectorLabels(brokerId))
+	if err != nil {
+		r.logger.Error(err, "Cannot check broker pod status")
+		return false
+	}
+	if len(podList.Items) == 0 {
+		return false
+	}
+	for _, pod := range podList.Items {
+		if pod.DeletionTimestamp != nil || !isPodReady(pod) {
+			r.logger.Info(fmt.Sprintf("%s pod is not ready yet", pod.Name))
+			return false
+		}
+	}
+	return true
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// getBrokerPodNames returns names of broker deployments or stateful set pods which are the same for each broker
+func getBrokerPodNames(pods []corev1.Pod) []string {
+	var names []string
+	for _, pod := range pods {
+		if podName := pod.Labels[appsv1.StatefulSetPodNameLabel]; podName != "" {
+			names = append(names, podName)
+		} else {
+			names = append(names, pod.Labels["name"])
+		}
+	}
+	return names
+}
@@ -0,0 +1,131 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+	"github.com/Netcracker/qubership-kafka/operator/controllers/provider"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCheckStatefulSet(t *testing.T) {
+	spec := kafka.KafkaSpec{Replicas: 3, Storage: kafka.Storage{ClassName: []string{"standard"}, Size: "10Gi"}}
+	spec.Racks = []string{"zone-a", "zone-b", "zone-c"}
+	// per-broker parameters are allowed for deployments
+	assert.NoError(t, checkStatefulSet(spec, "nodeport", true))
+
+	spec.PodManagement = provider.StatefulSetPodManagement
+	spec.Racks = nil
+	assert.NoError(t, checkStatefulSet(spec, "", false))
+	assert.EqualError(t, checkStatefulSet(spec, "nodeport", true),
+		"podManagement 'statefulset' does not support [external access certificates issued for each broker]")
+	spec.Storage.Volumes = []string{"pv-1", "pv-2", "pv-3"}
+	spec.Storage.DataVolumes = []kafka.DataVolume{{Name: "ssd", Size: "10Gi"}}
+	assert.EqualError(t, checkStatefulSet(spec, "", false),
+		"podManagement 'statefulset' does not support [storage volumes, nodes, labels or several class names storage dataVolumes]")
+}
+
+func newTestStatefulSetReconcile(t *testing.T, objects ...client.Object) *ReconcileKafka {
+	r, _ := newTestReconcileKafka(t, kafka.KafkaSpec{Replicas: 3, PodManagement: provider.StatefulSetPodManagement}, objects...)
+	return r
+}
+
+func TestGetCurrentBrokerIdsDuringMigrationToStatefulSet(t *testing.T) {
+	statefulSetReplicas, deploymentReplicas := int32(2), int32(1)
+	r := newTestStatefulSetReconcile(t,
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka-service"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: &statefulSetReplicas},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "kafka-3", Namespace: "kafka-service", Labels: controllers.GetKafkaLabels("kafka")},
+			Spec:       appsv1.DeploymentSpec{Replicas: &deploymentReplicas},
+		})
+	brokerIds, err := r.getCurrentBrokerIds()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, brokerIds)
+
+	deploymentBrokerIds, err := r.getDeploymentBrokerIds()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3}, deploymentBrokerIds)
+}
+
+func TestRestartOutdatedBrokerPods(t *testing.T) {
+	replicas := int32(2)
+	newPod := func(name string, revision string) *corev1.Pod {
+		labels := controllers.GetKafkaLabels("kafka")
+		labels[appsv1.StatefulSetPodNameLabel] = name
+		labels[appsv1.StatefulSetRevisionLabel] = revision
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kafka-service", Labels: labels}}
+	}
+	r := newTestStatefulSetReconcile(t,
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka-service"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+			Status:     appsv1.StatefulSetStatus{UpdateRevision: "kafka-2"},
+		},
+		newPod("kafka-1", "kafka-1"),
+		newPod("kafka-2", "kafka-2"))
+	assert.NoError(t, r.restartOutdatedBrokerPods(2))
+
+	pods := &corev1.PodList{}
+	assert.NoError(t, r.reconciler.Client.List(context.TODO(), pods))
+	assert.Equal(t, []string{"kafka-2"}, getBrokerPodNames(pods.Items))
+}
+
+// ordinalsDroppingClient drops start ordinal of stateful sets as Kubernetes without StatefulSetStartOrdinal feature does
+type ordinalsDroppingClient struct {
+	client.Client
+}
+
+func (c ordinalsDroppingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if object, ok := obj.(*unstructured.Unstructured); ok {
+		unstructured.RemoveNestedField(object.Object, "spec", "ordinals")
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c ordinalsDroppingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if object, ok := obj.(*unstructured.Unstructured); ok {
+		unstructured.RemoveNestedField(object.Object, "spec", "ordinals")
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func TestMigrateBrokersToStatefulSetAbortsWithoutStartOrdinal(t *testing.T) {
+	replicas := int32(1)
+	r := newTestStatefulSetReconcile(t, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka-1", Namespace: "kafka-service", Labels: controllers.GetKafkaLabels("kafka")},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	})
+	r.reconciler.Client = ordinalsDroppingClient{Client: r.reconciler.Client}
+	err := r.migrateBrokersToStatefulSet([]int{1}, true, &corev1.Secret{})
+	assert.ErrorContains(t, err, "does not keep start ordinal 1")
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.reconciler.Client.Get(context.TODO(), client.ObjectKey{Name: "kafka-1", Namespace: "kafka-service"}, deployment))
+	statefulSet := &appsv1.StatefulSet{}
+	assert.NoError(t, r.reconciler.Client.Get(context.TODO(), client.ObjectKey{Name: "kafka", Namespace: "kafka-service"}, statefulSet))
+	assert.Equal(t, int32(0), *statefulSet.Spec.Replicas)
+}
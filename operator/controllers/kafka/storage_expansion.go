@@ -0,0 +1,236 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"fmt"
+	"time"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	volumeResizeTimeoutSeconds = 300
+	// onlineFileSystemResizeTimeoutSeconds is the time given to kubelet to expand file system of mounted volume
+	// before broker is restarted to finish resize offline
+	onlineFileSystemResizeTimeoutSeconds = 120
+
+	volumeResizedStatus                 = "Resized"
+	volumeResizingStatus                = "Resizing"
+	volumeFileSystemResizePendingStatus = "FileSystemResizePending"
+	volumeExpansionNotSupportedStatus   = "ExpansionNotSupported"
+)
+
+// isVolumeExpansionRequired returns true if persistent volume claim requests less storage than desired
+func isVolumeExpansionRequired(pvc *corev1.PersistentVolumeClaim, desiredSize resource.Quantity) bool {
+	requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	return requested.Cmp(desiredSize) < 0
+}
+
+// isFileSystemResizePending returns true if volume is expanded, but file system on it is not
+func isFileSystemResizePending(pvc *corev1.PersistentVolumeClaim) bool {
+	for _, condition := range pvc.Status.Conditions {
+		if condition.Type == corev1.PersistentVolumeClaimFileSystemResizePending && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// getVolumeResizeStatus returns resize status of persistent volume claim comparing its actual capacity with requested one
+func getVolumeResizeStatus(pvc *corev1.PersistentVolumeClaim) string {
+	requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	capacity, found := pvc.Status.Capacity[corev1.ResourceStorage]
+	if found && capacity.Cmp(requested) >= 0 {
+		return volumeResizedStatus
+	}
+	if isFileSystemResizePending(pvc) {
+		return volumeFileSystemResizePendingStatus
+	}
+	return volumeResizingStatus
+}
+
+// expandBrokersStorage increases size of brokers persistent volume claims up to sizes from specification.
+// Brokers are restarted one by one only if their file systems cannot be expanded online.
+func (r ReconcileKafka) expandBrokersStorage(brokerIds []int) error {
+	notSupported := map[string]bool{}
+	expandedClaims := map[int][]string{}
+	for _, brokerId := range brokerIds {
+		for _, claim := range r.getBrokerPersistentVolumeClaims(brokerId) {
+			desiredSize := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+			pvc, err := r.reconciler.FindPersistentVolumeClaim(claim.Name, claim.Namespace, r.logger)
+			if err != nil {
+				return err
+			}
+			if !isVolumeExpansionRequired(pvc, desiredSize) {
+				continue
+			}
+			allowed, err := r.isVolumeExpansionAllowed(pvc)
+			if errors.IsForbidden(err) {
+				// the storage class is unknown, so the change is validated by Kubernetes API server
+				r.logger.Error(err, fmt.Sprintf("Cannot check storage class of persistent volume claim %s, trying to expand it", pvc.Name))
+			} else if err != nil && !errors.IsNotFound(err) {
+				return err
+			} else if !allowed {
+				r.logger.Info(fmt.Sprintf("Storage class of persistent volume claim %s does not allow volume expansion", pvc.Name))
+				notSupported[pvc.Name] = true
+				continue
+			}
+			r.logger.Info(fmt.Sprintf("Expanding persistent volume claim %s to %s", pvc.Name, desiredSize.String()))
+			if pvc.Spec.Resources.Requests == nil {
+				pvc.Spec.Resources.Requests = corev1.ResourceList{}
+			}
+			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = desiredSize
+			if err := r.reconciler.UpdatePersistentVolumeClaim(pvc, r.logger); err != nil {
+				if errors.IsForbidden(err) || errors.IsInvalid(err) {
+					r.logger.Error(err, fmt.Sprintf("Persistent volume claim %s cannot be expanded", pvc.Name))
+					notSupported[pvc.Name] = true
+					continue
+				}
+				return err
+			}
+			expandedClaims[brokerId] = append(expandedClaims[brokerId], pvc.Name)
+		}
+	}
+
+	for _, brokerId := range brokerIds {
+		if len(expandedClaims[brokerId]) > 0 {
+			if err := r.waitForBrokerVolumesResize(brokerId, expandedClaims[brokerId]); err != nil {
+				return err
+			}
+		}
+	}
+	return r.updateStorageStatus(brokerIds, notSupported)
+}
+
+// getBrokerPersistentVolumeClaims returns desired persistent volume claims of the main and additional data volumes of broker
+func (r ReconcileKafka) getBrokerPersistentVolumeClaims(brokerId int) []*corev1.PersistentVolumeClaim {
+	var claims []*corev1.PersistentVolumeClaim
+	if claim := r.kafkaProvider.NewKafkaPersistentVolumeClaimForCR(brokerId); claim != nil {
+		claims = append(claims, claim)
+	}
+	return append(claims, r.kafkaProvider.NewKafkaDataVolumePersistentVolumeClaimsForCR(brokerId)...)
+}
+
+// isVolumeExpansionAllowed checks storage class of persistent volume claim, volumes without storage class
+// are not expanded. It returns error if storage class cannot be read.
+func (r ReconcileKafka) isVolumeExpansionAllowed(pvc *corev1.PersistentVolumeClaim) (bool, error) {
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return false, nil
+	}
+	return r.reconciler.IsVolumeExpansionAllowed(*pvc.Spec.StorageClassName, r.logger)
+}
+
+// waitForBrokerVolumesResize waits until volumes of broker are expanded and restarts broker
+// if kubelet does not expand their file systems online
+func (r ReconcileKafka) waitForBrokerVolumesResize(brokerId int, claimNames []string) error {
+	restartRequired := false
+	for _, claimName := range claimNames {
+		status, err := r.waitForVolumeResizeStatus(claimName, volumeResizeTimeoutSeconds, volumeResizedStatus, volumeFileSystemResizePendingStatus)
+		if err != nil {
+			return fmt.Errorf("persistent volume claim %s was not expanded: %v", claimName, err)
+		}
+		if status == volumeResizedStatus {
+			continue
+		}
+		if _, err = r.waitForVolumeResizeStatus(claimName, onlineFileSystemResizeTimeoutSeconds, volumeResizedStatus); err != nil {
+			r.logger.Info(fmt.Sprintf("File system of persistent volume claim %s was not expanded online", claimName))
+			restartRequired = true
+		}
+	}
+	if !restartRequired {
+		return nil
+	}
+	r.logger.Info(fmt.Sprintf("Restarting kafka-%d to finish file system resize", brokerId))
+	if err := r.restartBroker(brokerId); err != nil {
+		return err
+	}
+	if err := r.waitUntilBrokerIsReady(brokerId, 300); err != nil {
+		return err
+	}
+	for _, claimName := range claimNames {
+		if _, err := r.waitForVolumeResizeStatus(claimName, volumeResizeTimeoutSeconds, volumeResizedStatus); err != nil {
+			return fmt.Errorf("file system of persistent volume claim %s was not expanded: %v", claimName, err)
+		}
+	}
+	return nil
+}
+
+func (r ReconcileKafka) waitForVolumeResizeStatus(claimName string, timeoutSeconds int, expectedStatuses ...string) (string, error) {
+	var status string
+	err := wait.PollImmediate(waitingInterval, time.Duration(timeoutSeconds)*time.Second, func() (done bool, err error) {
+		pvc, err := r.reconciler.FindPersistentVolumeClaim(claimName, r.cr.Namespace, r.logger)
+		if err != nil {
+			r.logger.Info(fmt.Sprintf("Cannot get persistent volume claim %s: %v", claimName, err))
+			return false, nil
+		}
+		status = getVolumeResizeStatus(pvc)
+		for _, expectedStatus := range expectedStatuses {
+			if status == expectedStatus {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	return status, err
+}
+
+func (r ReconcileKafka) restartBroker(brokerId int) error {
+	labels := r.kafkaProvider.GetBrokerPodSelectorLabels(brokerId)
+	podList, err := r.reconciler.FindPodList(r.cr.Namespace, labels)
+	if err != nil {
+		return err
+	}
+	for i := range podList.Items {
+		if err := r.reconciler.DeletePod(&podList.Items[i], r.logger); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r ReconcileKafka) updateStorageStatus(brokerIds []int, notSupported map[string]bool) error {
+	var brokers []kafka.BrokerStorageStatus
+	for _, brokerId := range brokerIds {
+		for _, claim := range r.getBrokerPersistentVolumeClaims(brokerId) {
+			pvc, err := r.reconciler.FindPersistentVolumeClaim(claim.Name, claim.Namespace, r.logger)
+			if err != nil {
+				return err
+			}
+			brokerStatus := kafka.BrokerStorageStatus{
+				Broker:                fmt.Sprintf("%s-%d", r.cr.Name, brokerId),
+				PersistentVolumeClaim: pvc.Name,
+				Status:                getVolumeResizeStatus(pvc),
+			}
+			if requested, found := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; found {
+				brokerStatus.Requested = requested.String()
+			}
+			if capacity, found := pvc.Status.Capacity[corev1.ResourceStorage]; found {
+				brokerStatus.Capacity = capacity.String()
+			}
+			if notSupported[pvc.Name] {
+				brokerStatus.Status = volumeExpansionNotSupportedStatus
+			}
+			brokers = append(brokers, brokerStatus)
+		}
+	}
+	return r.reconciler.StatusUpdater.UpdateStatusWithRetry(func(instance *kafka.Kafka) {
+		instance.Status.StorageStatus.Brokers = brokers
+	})
+}
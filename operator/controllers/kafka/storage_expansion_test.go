@@ -0,0 +1,80 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	kafka "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestPersistentVolumeClaim(requested string, capacity string) *corev1.PersistentVolumeClaim {
+	pvc := &corev1.PersistentVolumeClaim{}
+	pvc.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(requested)}
+	if capacity != "" {
+		pvc.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(capacity)}
+	}
+	return pvc
+}
+
+func TestIsVolumeExpansionRequired(t *testing.T) {
+	pvc := newTestPersistentVolumeClaim("10Gi", "10Gi")
+	assert.True(t, isVolumeExpansionRequired(pvc, resource.MustParse("20Gi")))
+	assert.False(t, isVolumeExpansionRequired(pvc, resource.MustParse("10240Mi")))
+	assert.False(t, isVolumeExpansionRequired(pvc, resource.MustParse("5Gi")))
+	assert.True(t, isVolumeExpansionRequired(&corev1.PersistentVolumeClaim{}, resource.MustParse("1Gi")))
+}
+
+func TestGetVolumeResizeStatus(t *testing.T) {
+	assert.Equal(t, volumeResizedStatus, getVolumeResizeStatus(newTestPersistentVolumeClaim("20Gi", "20Gi")))
+	assert.Equal(t, volumeResizingStatus, getVolumeResizeStatus(newTestPersistentVolumeClaim("20Gi", "10Gi")))
+	assert.Equal(t, volumeResizingStatus, getVolumeResizeStatus(newTestPersistentVolumeClaim("20Gi", "")))
+
+	pvc := newTestPersistentVolumeClaim("20Gi", "10Gi")
+	pvc.Status.Conditions = []corev1.PersistentVolumeClaimCondition{
+		{Type: corev1.PersistentVolumeClaimFileSystemResizePending, Status: corev1.ConditionTrue},
+	}
+	assert.Equal(t, volumeFileSystemResizePendingStatus, getVolumeResizeStatus(pvc))
+}
+
+func TestIsVolumeExpansionAllowed(t *testing.T) {
+	r, _ := newTestReconcileKafka(t, kafka.KafkaSpec{})
+	allowVolumeExpansion := true
+	assert.NoError(t, r.reconciler.Client.Create(context.TODO(), &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "expandable"}, AllowVolumeExpansion: &allowVolumeExpansion}))
+	assert.NoError(t, r.reconciler.Client.Create(context.TODO(), &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "fixed"}}))
+
+	pvc := newTestPersistentVolumeClaim("10Gi", "10Gi")
+	allowed, err := r.isVolumeExpansionAllowed(pvc)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+	for className, expected := range map[string]bool{"expandable": true, "fixed": false} {
+		pvc.Spec.StorageClassName = &className
+		allowed, err = r.isVolumeExpansionAllowed(pvc)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, allowed, className)
+	}
+	className := "unknown"
+	pvc.Spec.StorageClassName = &className
+	_, err = r.isVolumeExpansionAllowed(pvc)
+	assert.True(t, errors.IsNotFound(err))
+}
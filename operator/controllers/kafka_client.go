@@ -21,7 +21,6 @@ import (
 	"math"
 	"sort"
 	"strings"
-	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/Netcracker/qubership-kafka/operator/util"
@@ -31,9 +30,14 @@ type KafkaClient struct {
 	serviceName                     string
 	clientUsername                  string
 	clientPassword                  string
-	newBrokersCount                 int32
+	brokerIds                       []int32
+	brokerPools                     map[int32]string
 	allBrokersStartTimeoutSeconds   int
 	topicReassignmentTimeoutSeconds int
+	replicationThrottleBytesPerSec  int64
+	maxConcurrentPartitionMoves     int
+	maxBytesInFlight                int64
+	balancingSettings               BalancingSettings
 	adminClient                     sarama.ClusterAdmin
 	controllerId                    int32
 	racksEnabled                    bool
@@ -50,12 +54,15 @@ type SslCertificates struct {
 	CaCert  []byte
 	TlsCert []byte
 	TlsKey  []byte
+	// AdditionalCaCerts are trusted along with CaCert, for example, previous CA during CA rotation
+	AdditionalCaCerts [][]byte
 }
 
 type BrokerInfo struct {
 	brokerId        int32
 	partitionsCount int64
 	rack            string
+	pool            string
 	skew            int32
 }
 
@@ -64,20 +71,20 @@ type TopicInfo struct {
 	configs   sarama.TopicDetail
 }
 
-const (
-	MaxRetryAttempts = 5
-	RetryDelay       = 60 * time.Second
-)
-
 func NewKafkaClient(
 	serviceName string,
 	clientUsername string,
 	clientPassword string,
 	sslEnabled bool,
 	sslCertificates *SslCertificates,
-	newBrokersCount int32,
+	brokerIds []int32,
+	brokerPools map[int32]string,
 	allBrokersStartTimeoutSeconds int,
-	topicReassignmentTimeoutSeconds int) (*KafkaClient, error) {
+	topicReassignmentTimeoutSeconds int,
+	replicationThrottleBytesPerSec int64,
+	maxConcurrentPartitionMoves int,
+	maxBytesInFlight int64,
+	balancingSettings BalancingSettings) (*KafkaClient, error) {
 	saslSettings := &SaslSettings{
 		Mechanism: sarama.SASLTypeSCRAMSHA512,
 		Username:  clientUsername,
@@ -91,13 +98,23 @@ func NewKafkaClient(
 		serviceName:                     serviceName,
 		clientUsername:                  clientUsername,
 		clientPassword:                  clientPassword,
-		newBrokersCount:                 newBrokersCount,
+		brokerIds:                       brokerIds,
+		brokerPools:                     brokerPools,
 		allBrokersStartTimeoutSeconds:   allBrokersStartTimeoutSeconds,
 		topicReassignmentTimeoutSeconds: topicReassignmentTimeoutSeconds,
+		replicationThrottleBytesPerSec:  replicationThrottleBytesPerSec,
+		maxConcurrentPartitionMoves:     maxConcurrentPartitionMoves,
+		maxBytesInFlight:                maxBytesInFlight,
+		balancingSettings:               balancingSettings,
 		adminClient:                     adminClient,
 	}, nil
 }
 
+// Close closes connections of admin client
+func (kc *KafkaClient) Close() error {
+	return kc.adminClient.Close()
+}
+
 func NewKafkaAdminClient(
 	bootstrapServers string, saslSettings *SaslSettings, sslEnabled bool, sslCertificates *SslCertificates) (sarama.ClusterAdmin, error) {
 	address := strings.Split(bootstrapServers, ",")
@@ -136,6 +153,9 @@ func NewKafkaClientConfig(saslSettings *SaslSettings, sslEnabled bool, sslCertif
 		if len(sslCertificates.CaCert) != 0 {
 			caCertPool := x509.NewCertPool()
 			caCertPool.AppendCertsFromPEM(sslCertificates.CaCert)
+			for _, additionalCaCert := range sslCertificates.AdditionalCaCerts {
+				caCertPool.AppendCertsFromPEM(additionalCaCert)
+			}
 			tlsConfig := &tls.Config{
 				RootCAs: caCertPool,
 			}
@@ -157,114 +177,42 @@ func NewKafkaClientConfig(saslSettings *SaslSettings, sslEnabled bool, sslCertif
 	return config, nil
 }
 
-func (kc *KafkaClient) ReassignPartitions() error {
-	err := kc.WaitUntilAllBrokersAreUp()
+// CheckAllBrokersAreUp returns true if all expected brokers are registered in the cluster and remembers their racks.
+// Broker IDs are not required to be contiguous, registered brokers which are not expected are not balanced.
+func (kc *KafkaClient) CheckAllBrokersAreUp() (bool, error) {
+	brokers, controller, err := kc.GetActiveBrokers()
 	if err != nil {
-		return err
+		return false, err
 	}
-
-	topics, err := kc.adminClient.ListTopics()
-	if err != nil {
-		return err
+	registeredRacks := make(map[int32]string, len(brokers))
+	for _, broker := range brokers {
+		registeredRacks[broker.ID()] = broker.Rack()
 	}
-
-	topicsCount := len(topics)
-	var counter = 1
-	for topic, config := range topics {
-		log.Info(fmt.Sprintf("%d of %d: Trying to reassign partitions for topic %s...", counter, topicsCount, topic))
-
-		globalPartitionCount, brokersInfo, err := kc.calculatePartitionsCount(topics)
-		if err != nil {
-			log.Error(err, "Failed to calculate broker partitions")
-		}
-
-		topicWithConfig := TopicInfo{topicName: topic, configs: config}
-
-		kc.calculateBrokersSkew(globalPartitionCount, brokersInfo)
-		skewIsNormal := checkBrokersSkewIsNormal(brokersInfo)
-		if skewIsNormal {
-			log.Info("Partitions are evenly distributed between all brokers.")
-			return nil
-		}
-		err = kc.ReassignPartitionsForTopic(topicWithConfig, brokersInfo, globalPartitionCount)
-		if err != nil {
-			log.Error(err, fmt.Sprintf("Cannot reassign partitions for topic [%s]", topic))
-		}
-		counter++
+	if missingBrokerIds := subtractBrokers(kc.brokerIds, registeredRacks); len(missingBrokerIds) > 0 {
+		log.Info(fmt.Sprintf("Brokers %v are not registered in the cluster yet", missingBrokerIds))
+		return false, nil
 	}
-	return nil
-}
-
-func (kc *KafkaClient) calculatePartitionsCount(topics map[string]sarama.TopicDetail) (int64, []*BrokerInfo, error) {
-	brokersPartitions := make(map[int32]int32, kc.newBrokersCount)
-	brokersInfo := make([]*BrokerInfo, kc.newBrokersCount)
-	globalPartitionCount := 0
-	topicsTitles := make([]string, 0, len(topics))
-	for topic := range topics {
-		topicsTitles = append(topicsTitles, topic)
-	}
-	var metadata []*sarama.TopicMetadata
-	var err error
-	var attempt int
-	for {
-		metadata, err = kc.adminClient.DescribeTopics(topicsTitles)
-		if err == nil {
-			break
-		}
-		attempt++
-		if attempt > MaxRetryAttempts {
-			log.Error(err, "Maximum attempts exceeded when trying to describe topics")
-		}
-		time.Sleep(RetryDelay)
+	kc.controllerId = controller
+	log.Info(fmt.Sprintf("Brokers count is %d, controller id is %d", len(brokers), controller))
+	expectedBrokers := make(map[int32]string, len(kc.brokerIds))
+	for _, brokerId := range kc.brokerIds {
+		expectedBrokers[brokerId] = ""
 	}
-	for _, topic := range metadata {
-		for _, partition := range topic.Partitions {
-			globalPartitionCount++
-			brokersPartitions[partition.Leader]++
-		}
+	if excessBrokerIds := subtractBrokers(sortedBrokerIds(registeredRacks), expectedBrokers); len(excessBrokerIds) > 0 {
+		log.Info(fmt.Sprintf("Brokers %v are not expected, partitions are not moved to them", excessBrokerIds))
 	}
-	for brokerId := 1; brokerId <= int(kc.newBrokersCount); brokerId++ {
-		brokersInfo[brokerId-1] = kc.newBrokerInfo(int32(brokerId), int64(brokersPartitions[int32(brokerId)]))
-		log.Info(fmt.Sprintf("PartitionCount for broker %d is %v", brokerId, brokersInfo[brokerId-1]))
-	}
-	log.Info(fmt.Sprintf("GlobalPartitionCount = %d", globalPartitionCount))
-
-	return int64(globalPartitionCount), brokersInfo, nil
+	kc.setBrokerRacks(registeredRacks)
+	return true, nil
 }
 
-func (kc *KafkaClient) WaitUntilAllBrokersAreUp() error {
-	log.Info("Waiting for all brokers are up...")
-	count := 0
-	remainingTime := kc.allBrokersStartTimeoutSeconds
-	for {
-		brokers, controller, err := kc.GetActiveBrokers()
-		if err != nil {
-			log.Error(err, "cannot get active brokers")
-			count = -1
-		} else {
-			count = len(brokers)
-		}
-		if count >= int(kc.newBrokersCount) {
-			kc.controllerId = controller
-			log.Info(fmt.Sprintf("Brokers count is %d, controller id is %d", count, controller))
-
-			racksEnabled := false
-			brokerRacks := make(map[int32]string)
-			for _, broker := range brokers {
-				brokerRacks[broker.ID()] = broker.Rack()
-				if broker.Rack() != "" {
-					racksEnabled = true
-				}
-			}
-			kc.brokerRacks = brokerRacks
-			kc.racksEnabled = racksEnabled
-			return nil
-		} else {
-			time.Sleep(10 * time.Second)
-			remainingTime = remainingTime - 10
-			if remainingTime <= 0 {
-				return fmt.Errorf("not all brokers are started, timeout is expired")
-			}
+// setBrokerRacks remembers racks of expected brokers, rack awareness is enabled if any of them has a rack
+func (kc *KafkaClient) setBrokerRacks(registeredRacks map[int32]string) {
+	kc.brokerRacks = make(map[int32]string, len(kc.brokerIds))
+	kc.racksEnabled = false
+	for _, brokerId := range kc.brokerIds {
+		kc.brokerRacks[brokerId] = registeredRacks[brokerId]
+		if registeredRacks[brokerId] != "" {
+			kc.racksEnabled = true
 		}
 	}
 }
@@ -273,7 +221,9 @@ func (kc *KafkaClient) GetActiveBrokers() ([]*sarama.Broker, int32, error) {
 	return kc.adminClient.DescribeCluster()
 }
 
-func (kc *KafkaClient) ReassignPartitionsForTopic(topic TopicInfo, brokersInfo []*BrokerInfo, globalPartitionCount int64) error {
+// PlanPartitionsReassignmentForTopic computes new assignment of topic partitions which moves replicas from brokers
+// with the most partitions to brokers with the least ones, brokers info is updated with the new distribution
+func (kc *KafkaClient) PlanPartitionsReassignmentForTopic(topic TopicInfo, brokersInfo []*BrokerInfo, globalPartitionCount int64) [][]int32 {
 	newReplicaAssignment := CopyCurrentReplicaAssignment(topic)
 	previousAssignment := -1
 	for partition := 0; partition < int(topic.configs.NumPartitions); partition++ {
@@ -305,15 +255,8 @@ func (kc *KafkaClient) ReassignPartitionsForTopic(topic TopicInfo, brokersInfo [
 		newReplicaAssignment[partition][idx] = int32(brokerWithLeastPartitionsToSwap)
 		UpdateBrokersInfoWithNewPartitionsDistribution(brokersInfo, brokerWithMostPartitionsToSwap, brokerWithLeastPartitionsToSwap)
 	}
-
 	log.Info(fmt.Sprintf("New assignment for topic %s is: %v", topic.topicName, newReplicaAssignment))
-	err := kc.adminClient.AlterPartitionReassignments(topic.topicName, newReplicaAssignment)
-	if err != nil {
-		log.Error(err, fmt.Sprintf("Cannot reassign partitions for topic [%s]", topic.topicName))
-	}
-
-	kc.WaitUntilPartitionsReassignmentFinished(topic)
-	return nil
+	return newReplicaAssignment
 }
 
 func UpdateBrokersInfoWithNewPartitionsDistribution(brokersInfo []*BrokerInfo, brokerWithMostPartitionsToSwap int, brokerWithLeastPartitionsToSwap int) {
@@ -326,42 +269,20 @@ func UpdateBrokersInfoWithNewPartitionsDistribution(brokersInfo []*BrokerInfo, b
 	}
 }
 
-func (kc *KafkaClient) WaitUntilPartitionsReassignmentFinished(topic TopicInfo) {
-	partitions := make([]int32, topic.configs.NumPartitions)
-	for i := 0; i < int(topic.configs.NumPartitions); i++ {
-		partitions[i] = int32(i)
-	}
-
-	remaining := kc.topicReassignmentTimeoutSeconds
-	for {
-		reassignmentStatus, err := kc.adminClient.ListPartitionReassignments(topic.topicName, partitions)
-		if err == nil && len(reassignmentStatus[topic.topicName]) == 0 {
-			return
-		}
-		time.Sleep(2 * time.Second)
-		remaining = remaining - 2
-		if remaining <= 0 {
-			return
-		}
-	}
-}
-
+// CopyCurrentReplicaAssignment copies replicas of topic partitions, partitions can have different
+// number of replicas, for example, when replication factor of a topic is being changed
 func CopyCurrentReplicaAssignment(topic TopicInfo) [][]int32 {
 	newReplicaAssignment := make([][]int32, topic.configs.NumPartitions)
-	rf := int(topic.configs.ReplicationFactor)
 	log.Info(fmt.Sprintf("Current assignment for topic %s is: %v", topic.topicName, topic.configs.ReplicaAssignment))
 	for partition := 0; partition < int(topic.configs.NumPartitions); partition++ {
-		newReplicaAssignment[partition] = make([]int32, rf)
-		for replica := 0; replica < rf; replica++ {
-			newReplicaAssignment[partition][replica] = topic.configs.ReplicaAssignment[int32(partition)][replica]
-		}
+		newReplicaAssignment[partition] = append([]int32{}, topic.configs.ReplicaAssignment[int32(partition)]...)
 	}
 	return newReplicaAssignment
 }
 
 func (kc *KafkaClient) PrepareBrokersWithMostAndLeastPartitions(brokersInfo []*BrokerInfo) ([]*BrokerInfo, []*BrokerInfo) {
-	threshold := int(kc.newBrokersCount)
-	for i := 0; i < int(kc.newBrokersCount); i++ {
+	threshold := len(brokersInfo)
+	for i := 0; i < len(brokersInfo); i++ {
 		if brokersInfo[i].skew < 0 {
 			threshold = i
 			break
@@ -372,8 +293,8 @@ func (kc *KafkaClient) PrepareBrokersWithMostAndLeastPartitions(brokersInfo []*B
 	for i := 0; i < threshold; i++ {
 		brokersWithMostPartitions[i] = brokersInfo[i]
 	}
-	brokersWithLeastPartitions := make([]*BrokerInfo, int(kc.newBrokersCount)-threshold)
-	for i := threshold; i < int(kc.newBrokersCount); i++ {
+	brokersWithLeastPartitions := make([]*BrokerInfo, len(brokersInfo)-threshold)
+	for i := threshold; i < len(brokersInfo); i++ {
 		brokersWithLeastPartitions[i-threshold] = brokersInfo[i]
 	}
 
@@ -403,7 +324,7 @@ func (kc *KafkaClient) CalcBrokerWithLeastPartitionsToSwap(brokersWithLeastParti
 		currentRacks := kc.getRacksForBrokers(newReplicaAssignment[currentPartition])
 
 		for _, broker := range brokersWithLeastPartitions {
-			if !containsString(currentRacks, broker.rack) &&
+			if !containsString(currentRacks, broker.rack) && kc.isSamePool(brokerWithMostPartitionsToSwap, broker) &&
 				!containsInt32(newReplicaAssignment[currentPartition], broker.brokerId) {
 				brokerWithLeastPartitionsToSwap = int(broker.brokerId)
 				return brokerWithLeastPartitionsToSwap
@@ -412,18 +333,21 @@ func (kc *KafkaClient) CalcBrokerWithLeastPartitionsToSwap(brokersWithLeastParti
 
 		if brokerWithLeastPartitionsToSwap == -1 {
 			for _, broker := range brokersWithLeastPartitions {
-				if kc.brokerRacks[brokerWithMostPartitionsToSwap] == broker.rack &&
+				if kc.brokerRacks[brokerWithMostPartitionsToSwap] == broker.rack && kc.isSamePool(brokerWithMostPartitionsToSwap, broker) &&
 					!containsInt32(newReplicaAssignment[currentPartition], broker.brokerId) {
 					brokerWithLeastPartitionsToSwap = int(broker.brokerId)
 					return brokerWithLeastPartitionsToSwap
 				}
 			}
 		}
+		// replica is not moved to another rack if it reduces the number of racks of partition
+		return brokerWithLeastPartitionsToSwap
 	}
 
 	if brokerWithLeastPartitionsToSwap == -1 {
 		for _, broker := range brokersWithLeastPartitions {
-			if !containsInt32(newReplicaAssignment[currentPartition], broker.brokerId) {
+			if kc.isSamePool(brokerWithMostPartitionsToSwap, broker) &&
+				!containsInt32(newReplicaAssignment[currentPartition], broker.brokerId) {
 				brokerWithLeastPartitionsToSwap = int(broker.brokerId)
 				return brokerWithLeastPartitionsToSwap
 			}
@@ -435,17 +359,24 @@ func (kc *KafkaClient) CalcBrokerWithLeastPartitionsToSwap(brokersWithLeastParti
 
 func (kc *KafkaClient) getRacksForBrokers(brokers []int32) []string {
 	racks := make([]string, len(brokers))
-	for broker := 0; broker < len(brokers); broker++ {
-		racks[broker] = kc.brokerRacks[int32(broker)]
+	for i, brokerId := range brokers {
+		racks[i] = kc.brokerRacks[brokerId]
 	}
 	return racks
 }
 
+// isSamePool checks that broker belongs to the same node pool as source broker, so that replicas
+// are not moved between pools with different resources and storage
+func (kc *KafkaClient) isSamePool(sourceBrokerId int32, broker *BrokerInfo) bool {
+	return kc.brokerPools[sourceBrokerId] == broker.pool
+}
+
 func (kc *KafkaClient) newBrokerInfo(brokerId int32, partitionsCount int64) *BrokerInfo {
 	return &BrokerInfo{
 		brokerId:        brokerId,
 		partitionsCount: partitionsCount,
 		rack:            kc.brokerRacks[brokerId],
+		pool:            kc.brokerPools[brokerId],
 	}
 }
 
@@ -457,7 +388,7 @@ func (kc *KafkaClient) calculateBrokersSkew(globalPartitionCount int64, brokersI
 		partitionsOnBrokersSum = partitionsOnBrokersSum + broker.partitionsCount
 	}
 
-	averageNumOfPartitionsPerBroker := RoundFloatTo2Decimals(float64(partitionsOnBrokersSum) / float64(kc.newBrokersCount))
+	averageNumOfPartitionsPerBroker := RoundFloatTo2Decimals(float64(partitionsOnBrokersSum) / float64(len(brokersInfo)))
 	maxPossibleSkew := Max(float64(globalPartitionCount)-averageNumOfPartitionsPerBroker, averageNumOfPartitionsPerBroker)
 
 	for _, broker := range brokersInfo {
@@ -517,3 +448,25 @@ func containsInt32(array []int32, element int32) bool {
 	}
 	return false
 }
+
+// subtractBrokers returns broker IDs which are not contained in brokers map
+func subtractBrokers(brokerIds []int32, brokers map[int32]string) []int32 {
+	var result []int32
+	for _, brokerId := range brokerIds {
+		if _, found := brokers[brokerId]; !found {
+			result = append(result, brokerId)
+		}
+	}
+	return result
+}
+
+func sortedBrokerIds(brokers map[int32]string) []int32 {
+	brokerIds := make([]int32, 0, len(brokers))
+	for brokerId := range brokers {
+		brokerIds = append(brokerIds, brokerId)
+	}
+	sort.Slice(brokerIds, func(i, j int) bool {
+		return brokerIds[i] < brokerIds[j]
+	})
+	return brokerIds
+}
@@ -15,10 +15,12 @@
 package controllers
 
 import (
+	"crypto/x509"
 	"fmt"
+	"testing"
+
 	"github.com/IBM/sarama"
 	"github.com/stretchr/testify/assert"
-	"testing"
 )
 
 const (
@@ -99,6 +101,19 @@ func TestNewKafkaClientConfigWhenSaslIsDisabledAndCaCertExists(t *testing.T) {
 	assert.Len(t, config.Net.TLS.Config.Certificates, 0)
 }
 
+func TestNewKafkaClientConfigWhenAdditionalCaCertsExist(t *testing.T) {
+	sslCertificates := &SslCertificates{
+		CaCert:            []byte(caCrt),
+		AdditionalCaCerts: [][]byte{[]byte(tlsCert)},
+	}
+	config, err := NewKafkaClientConfig(&SaslSettings{}, true, sslCertificates)
+	assert.Nil(t, err)
+	expectedCaCertPool := x509.NewCertPool()
+	expectedCaCertPool.AppendCertsFromPEM([]byte(caCrt))
+	expectedCaCertPool.AppendCertsFromPEM([]byte(tlsCert))
+	assert.True(t, expectedCaCertPool.Equal(config.Net.TLS.Config.RootCAs))
+}
+
 func TestNewKafkaClientConfigWhenSaslIsDisabledAndTlsEnabled(t *testing.T) {
 	config, err := NewKafkaClientConfig(&SaslSettings{}, true, &SslCertificates{})
 	assert.Nil(t, err)
@@ -163,3 +178,33 @@ func TestNewKafkaClientConfigWhenSaslIsEnabledAndCaCertAndTlsCertExist(t *testin
 	assert.NotNil(t, config.Net.TLS.Config.RootCAs)
 	assert.Len(t, config.Net.TLS.Config.Certificates, 1)
 }
+
+func TestCalcBrokerWithLeastPartitionsToSwapKeepsNodePool(t *testing.T) {
+	kc := &KafkaClient{
+		brokerRacks: map[int32]string{},
+		brokerPools: map[int32]string{1: "general", 2: "general", 3: "general", 100: "large"},
+	}
+	brokers := []*BrokerInfo{kc.newBrokerInfo(100, 0), kc.newBrokerInfo(3, 1)}
+	assignment := [][]int32{{1, 2}}
+	assert.Equal(t, 3, kc.CalcBrokerWithLeastPartitionsToSwap(brokers, assignment, 0, 1))
+	assert.Equal(t, -1, kc.CalcBrokerWithLeastPartitionsToSwap(brokers[:1], assignment, 0, 1))
+}
+
+func TestGetRacksForBrokers(t *testing.T) {
+	kc := &KafkaClient{brokerRacks: map[int32]string{0: "zone-0", 10: "zone-a", 20: "zone-b"}}
+	assert.Equal(t, []string{"zone-b", "zone-a"}, kc.getRacksForBrokers([]int32{20, 10}))
+}
+
+func TestSetBrokerRacks(t *testing.T) {
+	kc := &KafkaClient{brokerIds: []int32{5, 42}}
+	registeredRacks := map[int32]string{5: "zone-a", 42: "zone-b", 7: "zone-c"}
+	assert.Empty(t, subtractBrokers(kc.brokerIds, registeredRacks))
+	assert.Equal(t, []int32{7}, subtractBrokers(sortedBrokerIds(registeredRacks), map[int32]string{5: "", 42: ""}))
+	kc.setBrokerRacks(registeredRacks)
+	assert.True(t, kc.racksEnabled)
+	assert.Equal(t, map[int32]string{5: "zone-a", 42: "zone-b"}, kc.brokerRacks)
+
+	kc.setBrokerRacks(map[int32]string{5: "", 42: ""})
+	assert.False(t, kc.racksEnabled)
+	assert.Equal(t, []int32{42}, subtractBrokers(kc.brokerIds, map[int32]string{5: ""}))
+}
@@ -152,6 +152,10 @@ func (r ReconcileAkhq) Reconcile() error {
 		if err := r.reconciler.CreateOrUpdateDeployment(deployment, r.logger); err != nil {
 			return err
 		}
+		if err := r.reconciler.ReconcilePodDisruptionBudget(r.cr, r.akhqProvider.NewAkhqPodDisruptionBudget(),
+			provider.IsPodDisruptionBudgetEnabled(r.cr.Spec.Akhq.PodDisruptionBudget.Enabled), r.logger); err != nil {
+			return err
+		}
 
 		r.logger.Info("Updating AKHQ status")
 		if err := r.updateAkhqStatus(akhqLabels); err != nil {
@@ -28,6 +28,8 @@ const (
 	typeReady       = "Ready"
 	typeSuccessful  = "Successful"
 	waitingInterval = 10 * time.Second
+
+	typeWaitingForMaintenanceWindow = "WaitingForMaintenanceWindow"
 )
 
 func NewCondition(conditionStatus string, conditionType string, conditionReason string, conditionMessage string) kafkaservice.StatusCondition {
@@ -74,6 +74,7 @@ func (r *KafkaServiceReconciler) Reconcile(ctx context.Context, request ctrl.Req
 		return reconcile.Result{}, err
 	}
 	r.StatusUpdater = NewStatusUpdater(r.Client, instance)
+	r.maintenanceWindowStart = time.Time{}
 
 	specHash, err := util.Hash(instance.Spec)
 	if err != nil {
@@ -160,6 +161,19 @@ func (r *KafkaServiceReconciler) Reconcile(ctx context.Context, request ctrl.Req
 		}
 	}
 
+	if r.isWaitingForMaintenanceWindow() {
+		// hashes are not saved to continue reconciliation when the window opens
+		if err = r.updateConditions(NewCondition(statusFalse,
+			typeWaitingForMaintenanceWindow,
+			kafkaServiceConditionReason,
+			fmt.Sprintf("Disruptive operations are postponed until maintenance window at %s",
+				r.maintenanceWindowStart.Format(time.RFC3339)))); err != nil {
+			return reconcile.Result{}, err
+		}
+		reqLogger.Info("Reconciliation cycle is waiting for maintenance window")
+		return reconcile.Result{RequeueAfter: time.Until(r.maintenanceWindowStart)}, nil
+	}
+
 	if isCustomResourceChanged {
 		if instance.Spec.Global != nil && instance.Spec.Global.WaitForPodsReady {
 			if err = r.updateConditions(NewCondition(statusFalse,
@@ -15,6 +15,8 @@
 package kafkaservice
 
 import (
+	"time"
+
 	"github.com/Netcracker/qubership-kafka/operator/controllers"
 	_ "github.com/Netcracker/qubership-kafka/operator/controllers"
 	_ "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -24,4 +26,6 @@ import (
 type KafkaServiceReconciler struct {
 	controllers.Reconciler
 	StatusUpdater StatusUpdater
+	// maintenanceWindowStart is the start of maintenance window for operations postponed during reconciliation
+	maintenanceWindowStart time.Time
 }
@@ -0,0 +1,40 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaservice
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-kafka/operator/controllers"
+)
+
+// getMaintenanceWindowStart returns the start of the next maintenance window or zero time if the window is open now.
+// Disaster recovery switchover is not postponed, so the window is considered open during switchover.
+func (r ReconcileMirrorMaker) getMaintenanceWindowStart() (time.Time, error) {
+	if r.cr.Spec.Global == nil || r.drChecked {
+		return time.Time{}, nil
+	}
+	windows := make([]controllers.MaintenanceWindow, len(r.cr.Spec.Global.MaintenanceWindows))
+	for i, window := range r.cr.Spec.Global.MaintenanceWindows {
+		windows[i] = controllers.MaintenanceWindow(window)
+	}
+	_, nextStart, err := controllers.CheckMaintenanceWindows(windows, time.Now())
+	return nextStart, err
+}
+
+// isWaitingForMaintenanceWindow returns true if some operations were postponed during reconciliation
+func (r *KafkaServiceReconciler) isWaitingForMaintenanceWindow() bool {
+	return !r.maintenanceWindowStart.IsZero()
+}
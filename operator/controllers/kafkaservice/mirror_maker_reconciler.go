@@ -101,6 +101,10 @@ func (r ReconcileMirrorMaker) Reconcile() error {
 			r.drChecked ||
 			secret.Name != "" && r.reconciler.ResourceVersions[secretKey] != secretVersion ||
 			r.reconciler.ResourceVersions[configurationKey] != configurationVersion {
+			maintenanceWindowStart, err := r.getMaintenanceWindowStart()
+			if err != nil {
+				return err
+			}
 			serviceAccount := provider.NewServiceAccount(r.mirrorMakerProvider.GetServiceAccountName(), r.cr.Namespace, r.cr.Spec.Global.DefaultLabels)
 			if err := r.reconciler.CreateOrUpdateServiceAccount(serviceAccount, r.logger); err != nil {
 				return err
@@ -108,14 +112,14 @@ func (r ReconcileMirrorMaker) Reconcile() error {
 
 			if mirrorMakerSpec.RegionName == "" {
 				for _, cluster := range mirrorMakerSpec.Clusters {
-					if err := r.createDeployment(cluster, secretVersion, configurationVersion); err != nil {
+					if err := r.createDeployment(cluster, secretVersion, configurationVersion, maintenanceWindowStart); err != nil {
 						return err
 					}
 				}
 			} else {
 				for _, cluster := range mirrorMakerSpec.Clusters {
 					if cluster.Name == mirrorMakerSpec.RegionName {
-						if err := r.createDeployment(cluster, secretVersion, configurationVersion); err != nil {
+						if err := r.createDeployment(cluster, secretVersion, configurationVersion, maintenanceWindowStart); err != nil {
 							return err
 						}
 						break
@@ -123,6 +127,11 @@ func (r ReconcileMirrorMaker) Reconcile() error {
 				}
 			}
 
+			if err := r.reconciler.ReconcilePodDisruptionBudget(r.cr, mirrorMakerProvider.NewMirrorMakerPodDisruptionBudget(),
+				provider.IsPodDisruptionBudgetEnabled(mirrorMakerSpec.PodDisruptionBudget.Enabled), r.logger); err != nil {
+				return err
+			}
+
 			r.logger.Info("Updating Kafka Mirror Maker status")
 			if err := r.updateMirrorMakerStatus(r.cr); err != nil {
 				return err
@@ -130,6 +139,9 @@ func (r ReconcileMirrorMaker) Reconcile() error {
 		} else {
 			r.logger.Info("Kafka mirror maker configuration didn't change, skipping reconcile loop")
 		}
+		if r.reconciler.isWaitingForMaintenanceWindow() {
+			return nil
+		}
 		r.reconciler.ResourceVersions[secretKey] = secretVersion
 		r.reconciler.ResourceVersions[configurationKey] = configurationVersion
 		r.reconciler.ResourceHashes[mirrorMakerHashName] = mirrorMakerHash
@@ -156,8 +168,10 @@ func (r ReconcileMirrorMaker) Status() error {
 	return r.reconciler.updateConditions(NewCondition(statusTrue, typeReady, mirrorMakerConditionReason, "Kafka Mirror Maker pods are ready"))
 }
 
+// createDeployment creates or updates Mirror Maker deployment and its service. Update of existing deployment
+// is postponed if maintenance window start is specified.
 func (r ReconcileMirrorMaker) createDeployment(cluster kafkaservice.Cluster, secretVersion string,
-	configurationVersion string) error {
+	configurationVersion string, maintenanceWindowStart time.Time) error {
 	mirrorMakerProvider := r.mirrorMakerProvider
 	mirrorMakerSpec := r.cr.Spec.MirrorMaker
 
@@ -177,6 +191,15 @@ func (r ReconcileMirrorMaker) createDeployment(cluster kafkaservice.Cluster, sec
 	if err := r.reconciler.CreateOrUpdateService(mirrorMakerService, r.logger); err != nil {
 		return err
 	}
+	if !maintenanceWindowStart.IsZero() {
+		if _, err := r.reconciler.FindDeployment(deploymentName, r.cr.Namespace, r.logger); err == nil {
+			r.logger.Info(fmt.Sprintf("Update of %s deployment is postponed until maintenance window", deploymentName))
+			r.reconciler.maintenanceWindowStart = maintenanceWindowStart
+			return nil
+		} else if !errors.IsNotFound(err) {
+			return err
+		}
+	}
 	if err := r.reconciler.CreateOrUpdateDeployment(mirrorMakerDeployment, r.logger); err != nil {
 		return err
 	}
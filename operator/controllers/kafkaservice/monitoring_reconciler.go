@@ -104,6 +104,10 @@ func (r ReconcileMonitoring) Reconcile() error {
 		if err := r.reconciler.CreateOrUpdateDeployment(deployment, r.logger); err != nil {
 			return err
 		}
+		if err := r.reconciler.ReconcilePodDisruptionBudget(r.cr, r.monitoringProvider.NewMonitoringPodDisruptionBudget(),
+			provider.IsPodDisruptionBudgetEnabled(r.cr.Spec.Monitoring.PodDisruptionBudget.Enabled), r.logger); err != nil {
+			return err
+		}
 		r.logger.Info("Monitoring deployment has been created or updated")
 
 		r.logger.Info("Updating Monitoring status")
@@ -0,0 +1,269 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/IBM/sarama"
+)
+
+// LeaderBalancingSettings defines when leaders of partitions are rebalanced and how many partitions can be changed at once
+type LeaderBalancingSettings struct {
+	// SkewThresholdPercent is the maximum deviation of leaders number of broker from the average which is tolerated
+	SkewThresholdPercent int
+	// MaxElections limits the number of partitions whose leaders or replicas order are changed by one run
+	MaxElections int
+	// ReorderReplicas allows to change preferred leaders of partitions without moving their replicas
+	ReorderReplicas bool
+}
+
+// LeaderBalancingResult describes leader skew found by leader balancing and partitions changed by it
+type LeaderBalancingResult struct {
+	MaxSkewPercent      int32
+	ElectedPartitions   int
+	ReorderedPartitions int
+}
+
+// leaderPartition is partition with its in-sync replicas, preferred replica can become leader only if it is in sync
+type leaderPartition struct {
+	*partitionReplicas
+	inSyncReplicas []int32
+}
+
+// BalanceLeaders elects preferred replicas as leaders of partitions if leader skew of brokers exceeds the threshold.
+// If reordering is enabled, preferred replicas of partitions are changed to even the number of preferred leaders,
+// leaders of reordered partitions are elected by the next run when the new order of replicas is applied.
+func (kc *KafkaClient) BalanceLeaders(settings LeaderBalancingSettings) (*LeaderBalancingResult, error) {
+	topics, err := kc.adminClient.ListTopics()
+	if err != nil {
+		return nil, err
+	}
+	partitions, err := kc.describeLeaders(topicNames(topics))
+	if err != nil {
+		return nil, err
+	}
+	brokers := kc.balancingBrokers()
+	result := &LeaderBalancingResult{MaxSkewPercent: maxLeaderSkew(brokers, partitions)}
+	if int(result.MaxSkewPercent) <= settings.SkewThresholdPercent {
+		log.Info(fmt.Sprintf("Leader skew %d%% does not exceed threshold %d%%", result.MaxSkewPercent, settings.SkewThresholdPercent))
+		return result, nil
+	}
+	elections, reorders := planLeaderBalancing(brokers, partitions, settings)
+	if len(reorders) > 0 {
+		if err = kc.reorderReplicas(topics, reorders); err != nil {
+			return result, err
+		}
+		result.ReorderedPartitions = len(reorders)
+	}
+	if len(elections) > 0 {
+		result.ElectedPartitions, err = kc.electPreferredLeaders(elections)
+		if err != nil {
+			return result, err
+		}
+	}
+	log.Info(fmt.Sprintf("Leader skew is %d%%, preferred leaders are elected for %d partitions, replicas are reordered for %d partitions",
+		result.MaxSkewPercent, result.ElectedPartitions, result.ReorderedPartitions))
+	return result, nil
+}
+
+// planLeaderBalancing returns partitions whose preferred replicas should be elected as leaders and partitions whose
+// replicas should be reordered. Preferred replica is elected only if it has at least two leaders less than the current
+// leader, so each election reduces leader skew. The total number of changed partitions is limited by MaxElections.
+func planLeaderBalancing(brokers []balancingBroker, partitions []leaderPartition,
+	settings LeaderBalancingSettings) ([]leaderPartition, []PartitionAssignment) {
+	known := make(map[int32]bool, len(brokers))
+	for _, broker := range brokers {
+		known[broker.id] = true
+	}
+	leaders := map[int32]int{}
+	for _, partition := range partitions {
+		leaders[partition.leader]++
+	}
+	var elections []leaderPartition
+	elected := map[*partitionReplicas]bool{}
+	for _, partition := range partitions {
+		if len(elections) >= settings.MaxElections {
+			break
+		}
+		if len(partition.replicas) == 0 {
+			continue
+		}
+		preferred := partition.replicas[0]
+		if preferred == partition.leader || !known[preferred] || !containsInt32(partition.inSyncReplicas, preferred) {
+			continue
+		}
+		// partitions without leader or led by unknown broker are always elected
+		if known[partition.leader] && leaders[partition.leader]-leaders[preferred] < 2 {
+			continue
+		}
+		leaders[partition.leader]--
+		leaders[preferred]++
+		elections = append(elections, partition)
+		elected[partition.partitionReplicas] = true
+	}
+	if !settings.ReorderReplicas {
+		return elections, nil
+	}
+
+	preferredLeaders := map[int32]int{}
+	for _, partition := range partitions {
+		if len(partition.replicas) > 0 {
+			preferredLeaders[partition.replicas[0]]++
+		}
+	}
+	var reorders []PartitionAssignment
+	for _, partition := range partitions {
+		if len(elections)+len(reorders) >= settings.MaxElections {
+			break
+		}
+		// preferred replicas of elected partitions are kept to not elect another replica after reordering
+		if len(partition.replicas) < 2 || elected[partition.partitionReplicas] {
+			continue
+		}
+		preferred := partition.replicas[0]
+		target := int32(-1)
+		for _, replica := range partition.replicas[1:] {
+			if !known[replica] || !containsInt32(partition.inSyncReplicas, replica) ||
+				preferredLeaders[preferred]-preferredLeaders[replica] < 2 {
+				continue
+			}
+			if target < 0 || preferredLeaders[replica] < preferredLeaders[target] {
+				target = replica
+			}
+		}
+		if target < 0 {
+			continue
+		}
+		replicas := []int32{target}
+		for _, replica := range partition.replicas {
+			if replica != target {
+				replicas = append(replicas, replica)
+			}
+		}
+		preferredLeaders[preferred]--
+		preferredLeaders[target]++
+		reorders = append(reorders, PartitionAssignment{Topic: partition.topic, Partition: partition.partition, Replicas: replicas})
+	}
+	return elections, reorders
+}
+
+// maxLeaderSkew returns the maximum absolute deviation of leaders number of broker from the average in its pool
+func maxLeaderSkew(brokers []balancingBroker, partitions []leaderPartition) int32 {
+	replicas := make([]*partitionReplicas, 0, len(partitions))
+	for _, partition := range partitions {
+		replicas = append(replicas, partition.partitionReplicas)
+	}
+	var result int32
+	for _, balance := range calculateBrokersBalance(brokers, replicas, nil, func(balance BrokerBalance) int64 {
+		return int64(balance.Leaders)
+	}) {
+		skew := balance.Skew
+		if skew < 0 {
+			skew = -skew
+		}
+		if skew > result {
+			result = skew
+		}
+	}
+	return result
+}
+
+// describeLeaders returns leaders and replicas of partitions of given topics in order of topics and partitions
+func (kc *KafkaClient) describeLeaders(topics []string) ([]leaderPartition, error) {
+	metadata, err := kc.adminClient.DescribeTopics(topics)
+	if err != nil {
+		return nil, err
+	}
+	var partitions []leaderPartition
+	for _, topic := range metadata {
+		if topic.Err != sarama.ErrNoError {
+			return nil, fmt.Errorf("cannot describe topic %s: %v", topic.Name, topic.Err)
+		}
+		for _, partition := range topic.Partitions {
+			partitions = append(partitions, leaderPartition{
+				partitionReplicas: &partitionReplicas{
+					topic:     topic.Name,
+					partition: partition.ID,
+					leader:    partition.Leader,
+					replicas:  append([]int32{}, partition.Replicas...),
+				},
+				inSyncReplicas: partition.Isr,
+			})
+		}
+	}
+	sort.Slice(partitions, func(i, j int) bool {
+		if partitions[i].topic != partitions[j].topic {
+			return partitions[i].topic < partitions[j].topic
+		}
+		return partitions[i].partition < partitions[j].partition
+	})
+	return partitions, nil
+}
+
+// reorderReplicas changes the order of replicas of partitions, other partitions of the same topics keep their replicas,
+// so replicas are not moved between brokers
+func (kc *KafkaClient) reorderReplicas(topics map[string]sarama.TopicDetail, reorders []PartitionAssignment) error {
+	byTopic := map[string][]PartitionAssignment{}
+	for _, reorder := range reorders {
+		byTopic[reorder.Topic] = append(byTopic[reorder.Topic], reorder)
+	}
+	for _, topic := range topicNames(topics) {
+		if len(byTopic[topic]) == 0 {
+			continue
+		}
+		assignment := CopyCurrentReplicaAssignment(TopicInfo{topicName: topic, configs: topics[topic]})
+		for _, reorder := range byTopic[topic] {
+			assignment[reorder.Partition] = reorder.Replicas
+		}
+		log.Info(fmt.Sprintf("Reorder replicas of topic %s to %v", topic, assignment))
+		if err := kc.adminClient.AlterPartitionReassignments(topic, assignment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// electPreferredLeaders triggers preferred replica elections and returns the number of partitions with changed leaders
+func (kc *KafkaClient) electPreferredLeaders(partitions []leaderPartition) (int, error) {
+	request := map[string][]int32{}
+	for _, partition := range partitions {
+		request[partition.topic] = append(request[partition.topic], partition.partition)
+	}
+	results, err := kc.adminClient.ElectLeaders(sarama.PreferredElection, request)
+	if err != nil {
+		return 0, err
+	}
+	elected := 0
+	var failed []string
+	for topic, topicResults := range results {
+		for partition, result := range topicResults {
+			switch {
+			case errors.Is(result.ErrorCode, sarama.ErrNoError):
+				elected++
+			case errors.Is(result.ErrorCode, sarama.ErrElectionNotNeeded):
+			default:
+				failed = append(failed, fmt.Sprintf("%s-%d: %v", topic, partition, result.ErrorCode))
+			}
+		}
+	}
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return elected, fmt.Errorf("cannot elect preferred leaders for partitions %v", failed)
+	}
+	return elected, nil
+}
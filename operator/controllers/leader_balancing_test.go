@@ -0,0 +1,181 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeLeadersAdmin keeps leaders of partitions of a single topic and elects preferred replicas on demand
+type fakeLeadersAdmin struct {
+	sarama.ClusterAdmin
+	topic       string
+	replicas    [][]int32
+	leaders     []int32
+	inSync      [][]int32
+	reordered   [][]int32
+	elected     map[string][]int32
+	electionErr sarama.KError
+}
+
+func (a *fakeLeadersAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
+	assignment := map[int32][]int32{}
+	for partition, replicas := range a.replicas {
+		assignment[int32(partition)] = replicas
+	}
+	return map[string]sarama.TopicDetail{a.topic: {
+		NumPartitions:     int32(len(a.replicas)),
+		ReplicationFactor: int16(len(a.replicas[0])),
+		ReplicaAssignment: assignment,
+	}}, nil
+}
+
+func (a *fakeLeadersAdmin) DescribeTopics(topics []string) ([]*sarama.TopicMetadata, error) {
+	metadata := &sarama.TopicMetadata{Name: a.topic}
+	for partition, replicas := range a.replicas {
+		isr := replicas
+		if a.inSync != nil {
+			isr = a.inSync[partition]
+		}
+		metadata.Partitions = append(metadata.Partitions,
+			&sarama.PartitionMetadata{ID: int32(partition), Leader: a.leaders[partition], Replicas: replicas, Isr: isr})
+	}
+	return []*sarama.TopicMetadata{metadata}, nil
+}
+
+func (a *fakeLeadersAdmin) AlterPartitionReassignments(topic string, assignment [][]int32) error {
+	a.reordered = assignment
+	return nil
+}
+
+func (a *fakeLeadersAdmin) ElectLeaders(electionType sarama.ElectionType, partitions map[string][]int32) (map[string]map[int32]*sarama.PartitionResult, error) {
+	a.elected = partitions
+	results := map[string]map[int32]*sarama.PartitionResult{}
+	for topic, topicPartitions := range partitions {
+		results[topic] = map[int32]*sarama.PartitionResult{}
+		for _, partition := range topicPartitions {
+			results[topic][partition] = &sarama.PartitionResult{ErrorCode: a.electionErr}
+		}
+	}
+	return results, nil
+}
+
+func newLeaderPartitions(replicas [][]int32, leaders []int32) []leaderPartition {
+	partitions := make([]leaderPartition, 0, len(replicas))
+	for i := range replicas {
+		partitions = append(partitions, leaderPartition{
+			partitionReplicas: &partitionReplicas{topic: "test", partition: int32(i), leader: leaders[i], replicas: replicas[i]},
+			inSyncReplicas:    replicas[i],
+		})
+	}
+	return partitions
+}
+
+func leaderBrokers(brokerIds ...int32) []balancingBroker {
+	brokers := make([]balancingBroker, 0, len(brokerIds))
+	for _, brokerId := range brokerIds {
+		brokers = append(brokers, balancingBroker{id: brokerId})
+	}
+	return brokers
+}
+
+func TestPlanLeaderBalancing(t *testing.T) {
+	brokers := leaderBrokers(1, 2, 3)
+	// broker 1 leads all partitions after restart of other brokers
+	partitions := newLeaderPartitions([][]int32{{1, 2}, {2, 3}, {3, 1}, {2, 1}, {3, 2}, {1, 3}}, []int32{1, 1, 1, 1, 1, 1})
+	// the preferred replica of partition 4 is not in sync
+	partitions[4].inSyncReplicas = []int32{2}
+
+	elections, reorders := planLeaderBalancing(brokers, partitions, LeaderBalancingSettings{MaxElections: 100})
+	assert.Nil(t, reorders)
+	var elected []int32
+	for _, partition := range elections {
+		elected = append(elected, partition.partition)
+	}
+	assert.Equal(t, []int32{1, 2, 3}, elected)
+
+	elections, _ = planLeaderBalancing(brokers, partitions, LeaderBalancingSettings{MaxElections: 2})
+	assert.Len(t, elections, 2)
+}
+
+func TestPlanLeaderBalancingElectsPartitionsWithoutLeader(t *testing.T) {
+	partitions := newLeaderPartitions([][]int32{{1, 2}, {2, 1}}, []int32{-1, 2})
+	elections, _ := planLeaderBalancing(leaderBrokers(1, 2), partitions, LeaderBalancingSettings{MaxElections: 100})
+	assert.Len(t, elections, 1)
+	assert.Equal(t, int32(0), elections[0].partition)
+}
+
+func TestPlanLeaderBalancingReordersReplicas(t *testing.T) {
+	brokers := leaderBrokers(1, 2, 3)
+	// broker 1 is the preferred leader of all partitions, so elections cannot even leaders
+	partitions := newLeaderPartitions([][]int32{{1, 2}, {1, 3}, {1, 2}, {1, 3}, {1, 2}, {1, 3}}, []int32{1, 1, 1, 1, 1, 1})
+
+	elections, reorders := planLeaderBalancing(brokers, partitions, LeaderBalancingSettings{MaxElections: 100, ReorderReplicas: true})
+	assert.Empty(t, elections)
+	preferredLeaders := map[int32]int{1: 6}
+	for _, reorder := range reorders {
+		original := partitions[reorder.Partition].replicas
+		assert.ElementsMatch(t, original, reorder.Replicas)
+		preferredLeaders[original[0]]--
+		preferredLeaders[reorder.Replicas[0]]++
+	}
+	assert.Equal(t, map[int32]int{1: 2, 2: 2, 3: 2}, preferredLeaders)
+
+	_, reorders = planLeaderBalancing(brokers, partitions, LeaderBalancingSettings{MaxElections: 1, ReorderReplicas: true})
+	assert.Len(t, reorders, 1)
+}
+
+func TestBalanceLeaders(t *testing.T) {
+	admin := &fakeLeadersAdmin{
+		topic:    "test",
+		replicas: [][]int32{{1, 2}, {2, 3}, {3, 1}, {2, 1}, {3, 2}, {1, 3}},
+		leaders:  []int32{1, 1, 1, 1, 1, 1},
+	}
+	kc := &KafkaClient{adminClient: admin, brokerIds: []int32{1, 2, 3}, brokerPools: map[int32]string{}}
+
+	result, err := kc.BalanceLeaders(LeaderBalancingSettings{SkewThresholdPercent: 500, MaxElections: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, &LeaderBalancingResult{MaxSkewPercent: 200}, result)
+	assert.Nil(t, admin.elected)
+
+	result, err = kc.BalanceLeaders(LeaderBalancingSettings{SkewThresholdPercent: 10, MaxElections: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, &LeaderBalancingResult{MaxSkewPercent: 200, ElectedPartitions: 4}, result)
+	assert.Equal(t, map[string][]int32{"test": {1, 2, 3, 4}}, admin.elected)
+	assert.Nil(t, admin.reordered)
+
+	admin.electionErr = sarama.ErrPreferredLeaderNotAvailable
+	_, err = kc.BalanceLeaders(LeaderBalancingSettings{SkewThresholdPercent: 10, MaxElections: 100})
+	assert.Error(t, err)
+}
+
+func TestBalanceLeadersReordersReplicasOfTopic(t *testing.T) {
+	admin := &fakeLeadersAdmin{
+		topic:    "test",
+		replicas: [][]int32{{1, 2}, {1, 2}, {1, 2}, {1, 2}},
+		leaders:  []int32{1, 1, 1, 1},
+	}
+	kc := &KafkaClient{adminClient: admin, brokerIds: []int32{1, 2}, brokerPools: map[int32]string{}}
+
+	result, err := kc.BalanceLeaders(LeaderBalancingSettings{SkewThresholdPercent: 10, MaxElections: 100, ReorderReplicas: true})
+	assert.NoError(t, err)
+	assert.Equal(t, &LeaderBalancingResult{MaxSkewPercent: 100, ReorderedPartitions: 2}, result)
+	// replicas of other partitions are kept, so the reassignment does not move them
+	assert.Equal(t, [][]int32{{2, 1}, {2, 1}, {1, 2}, {1, 2}}, admin.reordered)
+	assert.Nil(t, admin.elected)
+}
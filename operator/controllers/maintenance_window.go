@@ -0,0 +1,183 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxCronSearchPeriod limits the search of the next time matched by cron schedule,
+// schedules like "0 0 30 2 *" never match
+const maxCronSearchPeriod = 5 * 366 * 24 * time.Hour
+
+// MaintenanceWindow is a period of time when disruptive operations are allowed.
+// Windows start at times matched by Schedule and last for Duration.
+type MaintenanceWindow struct {
+	Schedule string
+	Duration string
+}
+
+// CronSchedule is a parsed cron expression with five fields: minute, hour, day of month, month and day of week
+type CronSchedule struct {
+	minutes     map[int]bool
+	hours       map[int]bool
+	daysOfMonth map[int]bool
+	months      map[int]bool
+	daysOfWeek  map[int]bool
+	// day of month and day of week are combined with OR if both of them are restricted
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+// ParseCronSchedule parses standard cron expression, for example, "0 2 * * 6".
+// Fields support "*", lists, ranges and steps. Sunday is 0 or 7 in day of week field.
+func ParseCronSchedule(expression string) (*CronSchedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression '%s' must contain 5 fields", expression)
+	}
+	bounds := [][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	values := make([]map[int]bool, len(fields))
+	for i, field := range fields {
+		parsed, err := parseCronField(field, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("cron expression '%s' is incorrect: %v", expression, err)
+		}
+		values[i] = parsed
+	}
+	if values[4][7] {
+		values[4][0] = true
+	}
+	return &CronSchedule{
+		minutes:       values[0],
+		hours:         values[1],
+		daysOfMonth:   values[2],
+		months:        values[3],
+		daysOfWeek:    values[4],
+		anyDayOfMonth: strings.HasPrefix(fields[2], "*"),
+		anyDayOfWeek:  strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+func parseCronField(field string, min int, max int) (map[int]bool, error) {
+	values := map[int]bool{}
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if index := strings.Index(part, "/"); index >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[index+1:]); err != nil || step <= 0 {
+				return nil, fmt.Errorf("step in '%s' must be a positive number", part)
+			}
+			rangePart = part[:index]
+		}
+		from, to := min, max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if from, err = strconv.Atoi(bounds[0]); err != nil {
+				return nil, fmt.Errorf("'%s' is not a number", bounds[0])
+			}
+			to = from
+			if len(bounds) == 2 {
+				if to, err = strconv.Atoi(bounds[1]); err != nil {
+					return nil, fmt.Errorf("'%s' is not a number", bounds[1])
+				}
+			} else if step > 1 {
+				to = max
+			}
+		}
+		if from < min || to > max || from > to {
+			return nil, fmt.Errorf("'%s' is out of range from %d to %d", part, min, max)
+		}
+		for value := from; value <= to; value += step {
+			values[value] = true
+		}
+	}
+	return values, nil
+}
+
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.daysOfMonth[t.Day()]
+	dayOfWeek := s.daysOfWeek[int(t.Weekday())]
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// Next returns the first time after given one which matches the schedule, or zero time if there is no such time
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.Add(maxCronSearchPeriod)
+	for t.Before(limit) {
+		if !s.months[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.hours[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.minutes[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// CheckMaintenanceWindows validates maintenance windows and returns true if the given time is within one of them
+// or windows are not specified. Otherwise, it returns the start of the nearest window. Schedules are evaluated in UTC.
+func CheckMaintenanceWindows(windows []MaintenanceWindow, now time.Time) (bool, time.Time, error) {
+	if len(windows) == 0 {
+		return true, time.Time{}, nil
+	}
+	now = now.UTC()
+	open := false
+	var nextStart time.Time
+	for _, window := range windows {
+		schedule, err := ParseCronSchedule(window.Schedule)
+		if err != nil {
+			return false, time.Time{}, err
+		}
+		duration, err := time.ParseDuration(window.Duration)
+		if err != nil || duration <= 0 {
+			return false, time.Time{}, fmt.Errorf("duration '%s' of maintenance window '%s' must be a positive duration, for example, '2h'",
+				window.Duration, window.Schedule)
+		}
+		// the window is open if it started within the last duration
+		if start := schedule.Next(now.Add(-duration)); !start.IsZero() && !start.After(now) {
+			open = true
+		}
+		if start := schedule.Next(now); !start.IsZero() && (nextStart.IsZero() || start.Before(nextStart)) {
+			nextStart = start
+		}
+	}
+	if open {
+		return true, time.Time{}, nil
+	}
+	if nextStart.IsZero() {
+		return false, time.Time{}, fmt.Errorf("maintenance windows %v never start", windows)
+	}
+	return false, nextStart, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCronSchedule_Next(t *testing.T) {
+	// Wednesday
+	now := time.Date(2025, time.January, 1, 10, 30, 0, 0, time.UTC)
+	next := func(expression string) time.Time {
+		schedule, err := ParseCronSchedule(expression)
+		assert.NoError(t, err)
+		return schedule.Next(now)
+	}
+	assert.Equal(t, time.Date(2025, time.January, 1, 10, 31, 0, 0, time.UTC), next("* * * * *"))
+	assert.Equal(t, time.Date(2025, time.January, 1, 10, 45, 0, 0, time.UTC), next("*/15 * * * *"))
+	assert.Equal(t, time.Date(2025, time.January, 2, 2, 0, 0, 0, time.UTC), next("0 2 * * *"))
+	assert.Equal(t, time.Date(2025, time.January, 4, 2, 0, 0, 0, time.UTC), next("0 2 * * 6"))
+	assert.Equal(t, time.Date(2025, time.January, 5, 22, 0, 0, 0, time.UTC), next("0 22 * * 7"))
+	assert.Equal(t, time.Date(2025, time.January, 3, 1, 0, 0, 0, time.UTC), next("0 1 * * 1-5/2,0"))
+	assert.Equal(t, time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), next("0 0 1 3,6 *"))
+	// day of month and day of week are combined with OR
+	assert.Equal(t, time.Date(2025, time.January, 3, 0, 0, 0, 0, time.UTC), next("0 0 15 * 5"))
+	assert.True(t, next("0 0 30 2 *").IsZero())
+
+	for _, expression := range []string{"* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "a * * * *", "5-1 * * * *"} {
+		_, err := ParseCronSchedule(expression)
+		assert.Error(t, err, expression)
+	}
+}
+
+func TestCheckMaintenanceWindows(t *testing.T) {
+	windows := []MaintenanceWindow{
+		{Schedule: "0 2 * * *", Duration: "2h"},
+		{Schedule: "0 22 * * 6", Duration: "30m"},
+	}
+	open, _, err := CheckMaintenanceWindows(nil, time.Now())
+	assert.NoError(t, err)
+	assert.True(t, open)
+
+	// Saturday
+	open, _, err = CheckMaintenanceWindows(windows, time.Date(2025, time.January, 4, 3, 59, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.True(t, open)
+
+	open, next, err := CheckMaintenanceWindows(windows, time.Date(2025, time.January, 4, 4, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.False(t, open)
+	assert.Equal(t, time.Date(2025, time.January, 4, 22, 0, 0, 0, time.UTC), next)
+
+	// the time is converted to UTC
+	open, _, err = CheckMaintenanceWindows(windows, time.Date(2025, time.January, 4, 23, 10, 0, 0, time.FixedZone("UTC+1", 3600)))
+	assert.NoError(t, err)
+	assert.True(t, open)
+
+	_, _, err = CheckMaintenanceWindows([]MaintenanceWindow{{Schedule: "0 2 * * *", Duration: "0s"}}, time.Now())
+	assert.Error(t, err)
+	_, _, err = CheckMaintenanceWindows([]MaintenanceWindow{{Schedule: "0 2 * *", Duration: "1h"}}, time.Now())
+	assert.Error(t, err)
+}
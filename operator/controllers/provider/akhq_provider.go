@@ -25,6 +25,7 @@ import (
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -301,12 +302,13 @@ func (arp AkhqResourceProvider) NewAkhqDeployment(protobufConfigMapVersion strin
 							SecurityContext: getDefaultContainerSecurityContext(),
 						},
 					},
-					SecurityContext:    &arp.spec.SecurityContext,
-					ServiceAccountName: arp.GetServiceAccountName(),
-					Hostname:           deploymentName,
-					Affinity:           &arp.spec.Affinity,
-					Tolerations:        arp.spec.Tolerations,
-					PriorityClassName:  arp.spec.PriorityClassName,
+					SecurityContext:           &arp.spec.SecurityContext,
+					ServiceAccountName:        arp.GetServiceAccountName(),
+					Hostname:                  deploymentName,
+					Affinity:                  &arp.spec.Affinity,
+					TopologySpreadConstraints: arp.spec.TopologySpreadConstraints,
+					Tolerations:               arp.spec.Tolerations,
+					PriorityClassName:         arp.spec.PriorityClassName,
 				},
 			},
 			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType},
@@ -605,3 +607,10 @@ func (arp *AkhqResourceProvider) GetLdapUsersProperties() string {
 	}
 	return strings.Join(ldapConfig, "\n")
 }
+
+// NewAkhqPodDisruptionBudget returns PodDisruptionBudget for AKHQ pods
+func (arp AkhqResourceProvider) NewAkhqPodDisruptionBudget() *policyv1.PodDisruptionBudget {
+	pdb := arp.spec.PodDisruptionBudget
+	return newPodDisruptionBudget(fmt.Sprintf("%s-pdb", arp.serviceName), arp.cr.Namespace,
+		arp.GetAkhqLabels(), arp.GetAkhqSelectorLabels(), pdb.MaxUnavailable, pdb.MinAvailable)
+}
@@ -0,0 +1,225 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	certmanagerv1 "github.com/Netcracker/qubership-kafka/operator/api/certmanager/v1"
+	"github.com/Netcracker/qubership-kafka/operator/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	certificateNamePattern       = "%s-tls-certificate"
+	certificateSecretNamePattern = "%s-tls-cert"
+	// CertificateLabel marks certificates and their secrets created by operator
+	CertificateLabel = "kafkaservice.qubership.org/certificate"
+
+	defaultCaValidityDays          = 1825
+	defaultCertificateValidityDays = 365
+	defaultCertificateRenewalDays  = 30
+)
+
+// IsCertManagerEnabled returns true if certificates of brokers are issued by cert-manager on behalf of operator
+func (krp KafkaResourceProvider) IsCertManagerEnabled() bool {
+	return krp.cr.Spec.Ssl.Enabled && krp.cr.Spec.Ssl.CertManager.Enabled
+}
+
+// IsCertificateAuthorityEnabled returns true if certificates of brokers are issued by operator acting as CA
+func (krp KafkaResourceProvider) IsCertificateAuthorityEnabled() bool {
+	return krp.cr.Spec.Ssl.Enabled && krp.cr.Spec.Ssl.CertificateAuthority.Enabled
+}
+
+// IsDeploymentCertificatesEnabled returns true if each broker and controller has its own certificate
+// issued by cert-manager or operator
+func (krp KafkaResourceProvider) IsDeploymentCertificatesEnabled() bool {
+	return krp.IsCertManagerEnabled() || krp.IsCertificateAuthorityEnabled()
+}
+
+// GetCertificateSecretName returns name of secret with certificate issued for deployment with specified name
+func (krp KafkaResourceProvider) GetCertificateSecretName(deploymentName string) string {
+	return fmt.Sprintf(certificateSecretNamePattern, deploymentName)
+}
+
+// GetClusterCaSecretName returns name of secret with CA which signs certificates of brokers and controllers
+func (krp KafkaResourceProvider) GetClusterCaSecretName() string {
+	return fmt.Sprintf("%s-cluster-ca", krp.cr.Name)
+}
+
+// GetClientsCaSecretName returns name of secret with CA which signs certificates of clients
+func (krp KafkaResourceProvider) GetClientsCaSecretName() string {
+	return fmt.Sprintf("%s-clients-ca", krp.cr.Name)
+}
+
+// GetClientCertificateSecretName returns name of secret with client certificate issued by operator for itself
+func (krp KafkaResourceProvider) GetClientCertificateSecretName() string {
+	return krp.GetCertificateSecretName(fmt.Sprintf("%s-client", krp.cr.Name))
+}
+
+// GetCaValidityDays returns validity period of CA certificates generated by operator
+func (krp KafkaResourceProvider) GetCaValidityDays() int {
+	if krp.cr.Spec.Ssl.CertificateAuthority.CaValidityDays > 0 {
+		return krp.cr.Spec.Ssl.CertificateAuthority.CaValidityDays
+	}
+	return defaultCaValidityDays
+}
+
+// GetCertificateValidityDays returns validity period of certificates issued by operator
+func (krp KafkaResourceProvider) GetCertificateValidityDays() int {
+	if krp.cr.Spec.Ssl.CertificateAuthority.ValidityDays > 0 {
+		return krp.cr.Spec.Ssl.CertificateAuthority.ValidityDays
+	}
+	return defaultCertificateValidityDays
+}
+
+// GetCertificateRenewalDays returns number of days before expiry when operator renews certificates
+func (krp KafkaResourceProvider) GetCertificateRenewalDays() int {
+	if krp.cr.Spec.Ssl.CertificateAuthority.RenewalDays > 0 {
+		return krp.cr.Spec.Ssl.CertificateAuthority.RenewalDays
+	}
+	return defaultCertificateRenewalDays
+}
+
+// getSslSecretName returns name of secret with TLS certificates mounted to deployment with specified name
+func (krp KafkaResourceProvider) getSslSecretName(deploymentName string) string {
+	if krp.IsDeploymentCertificatesEnabled() {
+		return krp.GetCertificateSecretName(deploymentName)
+	}
+	return krp.cr.Spec.Ssl.SecretName
+}
+
+// GetBrokerCertificateNames returns DNS names and IP addresses of broker certificate which cover its service DNS names
+// and external host names
+func (krp KafkaResourceProvider) GetBrokerCertificateNames(brokerId int) ([]string, []string) {
+	deploymentName := fmt.Sprintf("%s-%d", krp.cr.Name, brokerId)
+	dnsNames := append(krp.getServiceDnsNames(deploymentName),
+		fmt.Sprintf("%s.%s-broker", deploymentName, krp.cr.Name),
+		fmt.Sprintf("%s.%s-broker.%s", deploymentName, krp.cr.Name, krp.cr.Namespace),
+		fmt.Sprintf("%s.%s-broker.%s.svc", deploymentName, krp.cr.Name, krp.cr.Namespace))
+	dnsNames = append(dnsNames, krp.getServiceDnsNames(krp.GetServiceName())...)
+	var ipAddresses []string
+	if externalHost, _ := krp.getBrokerExternalAddress(brokerId); externalHost != "" {
+		if net.ParseIP(externalHost) != nil {
+			ipAddresses = append(ipAddresses, externalHost)
+		} else {
+			dnsNames = append(dnsNames, externalHost)
+		}
+	}
+	if krp.GetExternalAccessType() == ExternalAccessIngress {
+		dnsNames = append(dnsNames, krp.GetBrokerIngressHost(brokerId), krp.GetBootstrapIngressHost())
+	}
+	return krp.appendAdditionalCertificateNames(dnsNames, ipAddresses)
+}
+
+// GetQuorumControllerCertificateNames returns DNS names and IP addresses of dedicated Kraft controller certificate
+func (krp KafkaResourceProvider) GetQuorumControllerCertificateNames(controllerIndex int) ([]string, []string) {
+	return krp.appendAdditionalCertificateNames(krp.getServiceDnsNames(krp.GetQuorumControllerName(controllerIndex)), nil)
+}
+
+func (krp KafkaResourceProvider) appendAdditionalCertificateNames(dnsNames []string, ipAddresses []string) ([]string, []string) {
+	subjectAlternativeName := krp.cr.Spec.Ssl.SubjectAlternativeName
+	dnsNames = append(append(dnsNames, "localhost"), subjectAlternativeName.AdditionalDnsNames...)
+	ipAddresses = append([]string{"127.0.0.1"}, append(ipAddresses, subjectAlternativeName.AdditionalIpAddresses...)...)
+	return dnsNames, ipAddresses
+}
+
+func (krp KafkaResourceProvider) getServiceDnsNames(serviceName string) []string {
+	return []string{
+		serviceName,
+		fmt.Sprintf("%s.%s", serviceName, krp.cr.Namespace),
+		fmt.Sprintf("%s.%s.svc", serviceName, krp.cr.Namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", serviceName, krp.cr.Namespace),
+	}
+}
+
+// NewKafkaBrokerCertificateForCR returns cert-manager certificate for broker
+func (krp KafkaResourceProvider) NewKafkaBrokerCertificateForCR(brokerId int) *certmanagerv1.Certificate {
+	dnsNames, ipAddresses := krp.GetBrokerCertificateNames(brokerId)
+	return krp.newCertificate(fmt.Sprintf("%s-%d", krp.cr.Name, brokerId), dnsNames, ipAddresses)
+}
+
+// NewKafkaQuorumControllerCertificateForCR returns cert-manager certificate for dedicated Kraft controller
+func (krp KafkaResourceProvider) NewKafkaQuorumControllerCertificateForCR(controllerIndex int) *certmanagerv1.Certificate {
+	dnsNames, ipAddresses := krp.GetQuorumControllerCertificateNames(controllerIndex)
+	return krp.newCertificate(krp.GetQuorumControllerName(controllerIndex), dnsNames, ipAddresses)
+}
+
+// NewCertificateSecret returns secret with certificates issued by operator
+func (krp KafkaResourceProvider) NewCertificateSecret(secretName string, data map[string][]byte) *corev1.Secret {
+	secret := krp.NewEmptySecret(secretName)
+	secret.Labels[CertificateLabel] = "true"
+	secret.Type = corev1.SecretTypeOpaque
+	secret.Data = data
+	return secret
+}
+
+func (krp KafkaResourceProvider) newCertificate(deploymentName string, dnsNames []string, ipAddresses []string) *certmanagerv1.Certificate {
+	certManager := krp.cr.Spec.Ssl.CertManager
+	labels := util.JoinMaps(krp.GetKafkaLabels(), map[string]string{CertificateLabel: "true"})
+	issuerKind := certManager.IssuerRef.Kind
+	if issuerKind == "" {
+		issuerKind = certmanagerv1.IssuerKind
+	}
+	issuerGroup := certManager.IssuerRef.Group
+	if issuerGroup == "" {
+		issuerGroup = certmanagerv1.GroupVersion.Group
+	}
+	certificate := &certmanagerv1.Certificate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: certmanagerv1.GroupVersion.String(),
+			Kind:       "Certificate",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf(certificateNamePattern, deploymentName),
+			Namespace: krp.cr.Namespace,
+			Labels:    labels,
+		},
+		Spec: certmanagerv1.CertificateSpec{
+			CommonName:     deploymentName,
+			DNSNames:       dnsNames,
+			IPAddresses:    ipAddresses,
+			SecretName:     krp.GetCertificateSecretName(deploymentName),
+			SecretTemplate: &certmanagerv1.CertificateSecretTemplate{Labels: labels},
+			IssuerRef: certmanagerv1.ObjectReference{
+				Name:  certManager.IssuerRef.Name,
+				Kind:  issuerKind,
+				Group: issuerGroup,
+			},
+			Usages: []certmanagerv1.KeyUsage{
+				certmanagerv1.UsageDigitalSignature,
+				certmanagerv1.UsageKeyEncipherment,
+				certmanagerv1.UsageServerAuth,
+				certmanagerv1.UsageClientAuth,
+			},
+			PrivateKey: &certmanagerv1.CertificatePrivateKey{
+				RotationPolicy: "Always",
+				Encoding:       "PKCS1",
+				Algorithm:      "RSA",
+				Size:           2048,
+			},
+		},
+	}
+	if duration, err := time.ParseDuration(certManager.Duration); err == nil {
+		certificate.Spec.Duration = &metav1.Duration{Duration: duration}
+	}
+	if renewBefore, err := time.ParseDuration(certManager.RenewBefore); err == nil {
+		certificate.Spec.RenewBefore = &metav1.Duration{Duration: renewBefore}
+	}
+	return certificate
+}
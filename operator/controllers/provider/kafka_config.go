@@ -0,0 +1,159 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// PerBrokerConfigScope means that dynamic property is updated for each broker separately
+	PerBrokerConfigScope = "per-broker"
+	// ClusterWideConfigScope means that dynamic property is updated as cluster-wide default for all brokers
+	ClusterWideConfigScope = "cluster-wide"
+)
+
+// perBrokerConfigs contains broker properties which can be updated dynamically only for particular broker
+var perBrokerConfigs = map[string]bool{
+	"follower.replication.throttled.rate":            true,
+	"leader.replication.throttled.rate":              true,
+	"replica.alter.log.dirs.io.max.bytes.per.second": true,
+}
+
+// clusterWideConfigs contains broker properties which can be updated dynamically as cluster-wide default
+var clusterWideConfigs = map[string]bool{
+	"background.threads":                            true,
+	"compression.type":                              true,
+	"log.cleaner.backoff.ms":                        true,
+	"log.cleaner.dedupe.buffer.size":                true,
+	"log.cleaner.delete.retention.ms":               true,
+	"log.cleaner.io.buffer.load.factor":             true,
+	"log.cleaner.io.buffer.size":                    true,
+	"log.cleaner.io.max.bytes.per.second":           true,
+	"log.cleaner.max.compaction.lag.ms":             true,
+	"log.cleaner.min.cleanable.ratio":               true,
+	"log.cleaner.min.compaction.lag.ms":             true,
+	"log.cleaner.threads":                           true,
+	"log.cleanup.policy":                            true,
+	"log.flush.interval.messages":                   true,
+	"log.flush.interval.ms":                         true,
+	"log.index.interval.bytes":                      true,
+	"log.index.size.max.bytes":                      true,
+	"log.local.retention.bytes":                     true,
+	"log.local.retention.ms":                        true,
+	"log.message.timestamp.after.max.ms":            true,
+	"log.message.timestamp.before.max.ms":           true,
+	"log.message.timestamp.type":                    true,
+	"log.preallocate":                               true,
+	"log.retention.bytes":                           true,
+	"log.retention.ms":                              true,
+	"log.roll.jitter.ms":                            true,
+	"log.roll.ms":                                   true,
+	"log.segment.bytes":                             true,
+	"log.segment.delete.delay.ms":                   true,
+	"max.connection.creation.rate":                  true,
+	"max.connections":                               true,
+	"max.connections.per.ip":                        true,
+	"max.connections.per.ip.overrides":              true,
+	"message.max.bytes":                             true,
+	"metric.reporters":                              true,
+	"min.insync.replicas":                           true,
+	"num.io.threads":                                true,
+	"num.network.threads":                           true,
+	"num.recovery.threads.per.data.dir":             true,
+	"num.replica.alter.log.dirs.threads":            true,
+	"num.replica.fetchers":                          true,
+	"producer.id.expiration.ms":                     true,
+	"remote.log.index.file.cache.total.size.bytes":  true,
+	"remote.log.manager.copy.max.bytes.per.second":  true,
+	"remote.log.manager.fetch.max.bytes.per.second": true,
+	"transaction.partition.verification.enable":     true,
+	"unclean.leader.election.enable":                true,
+}
+
+// managedConfigs contains broker properties which are configured by operator and cannot be overridden in config
+var managedConfigs = []string{
+	"advertised.listeners", "broker.id", "broker.rack", "controller.listener.names", "controller.quorum.voters",
+	"inter.broker.listener.name", "listener.security.protocol.map", "listeners", "log.dirs", "node.id",
+	"process.roles", "zookeeper.connect",
+}
+
+// managedConfigPrefixes contains prefixes of broker properties configured by operator
+var managedConfigPrefixes = []string{"listener.name.", "sasl.", "ssl."}
+
+// GetConfigScope returns scope of dynamic broker property or empty string if the property is static
+func GetConfigScope(key string) string {
+	if perBrokerConfigs[key] {
+		return PerBrokerConfigScope
+	}
+	if clusterWideConfigs[key] {
+		return ClusterWideConfigScope
+	}
+	return ""
+}
+
+// IsManagedConfig checks if broker property is configured by operator
+func IsManagedConfig(key string) bool {
+	for _, managedConfig := range managedConfigs {
+		if key == managedConfig {
+			return true
+		}
+	}
+	for _, prefix := range managedConfigPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetStaticConfigs returns sorted keys of static properties from Kafka config
+func (krp KafkaResourceProvider) GetStaticConfigs() []string {
+	return krp.getConfigKeys(false)
+}
+
+// GetDynamicConfigs returns sorted keys of dynamic properties from Kafka config
+func (krp KafkaResourceProvider) GetDynamicConfigs() []string {
+	return krp.getConfigKeys(true)
+}
+
+func (krp KafkaResourceProvider) getConfigKeys(dynamic bool) []string {
+	var keys []string
+	for key := range krp.cr.Spec.Config {
+		if (GetConfigScope(key) != "") == dynamic {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// getStaticConfigEnvs returns environment variables for static properties from Kafka config,
+// so that brokers are restarted only when static properties are changed
+func (krp KafkaResourceProvider) getStaticConfigEnvs() []corev1.EnvVar {
+	var envVars []corev1.EnvVar
+	for _, key := range krp.GetStaticConfigs() {
+		envVars = append(envVars, corev1.EnvVar{Name: GetConfigEnvName(key), Value: krp.cr.Spec.Config[key]})
+	}
+	return envVars
+}
+
+// GetConfigEnvName returns name of environment variable which is converted to broker property by entrypoint
+func GetConfigEnvName(key string) string {
+	return "CONF_KAFKA_" + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
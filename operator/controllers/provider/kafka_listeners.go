@@ -0,0 +1,149 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	ListenerAuthenticationScram = "scram"
+	ListenerAuthenticationMTLS  = "mtls"
+	ListenerAuthenticationOAuth = "oauth"
+	ListenerAuthenticationNone  = "none"
+	// InternalListenerPort is the port of listener used by operator and supplementary services
+	InternalListenerPort = 9092
+)
+
+// GetListenerAuthentication returns authentication type of listener, SCRAM is used by default if security is enabled
+func (krp KafkaResourceProvider) GetListenerAuthentication(listener kafkaservice.Listener) string {
+	if listener.Authentication != "" {
+		return strings.ToLower(listener.Authentication)
+	}
+	if krp.IsSecurityDisabled() {
+		return ListenerAuthenticationNone
+	}
+	return ListenerAuthenticationScram
+}
+
+// GetListenerSecurityProtocol returns Kafka security protocol of listener according to its TLS and authentication settings
+func (krp KafkaResourceProvider) GetListenerSecurityProtocol(listener kafkaservice.Listener) string {
+	sasl := false
+	switch krp.GetListenerAuthentication(listener) {
+	case ListenerAuthenticationScram, ListenerAuthenticationOAuth:
+		sasl = true
+	}
+	switch {
+	case sasl && listener.TLS:
+		return "SASL_SSL"
+	case sasl:
+		return "SASL_PLAINTEXT"
+	case listener.TLS:
+		return "SSL"
+	default:
+		return "PLAINTEXT"
+	}
+}
+
+// getListenerName returns name of listener in Kafka configuration
+func getListenerName(listener kafkaservice.Listener) string {
+	return strings.ToUpper(listener.Name)
+}
+
+// getListenersEnvs returns client listeners, advertised listeners and security protocol map of broker,
+// as well as authentication properties of each listener
+func (krp KafkaResourceProvider) getListenersEnvs(hostName string) []corev1.EnvVar {
+	var listeners, advertisedListeners, protocols []string
+	var envVars []corev1.EnvVar
+	for _, listener := range krp.spec.Listeners {
+		name := getListenerName(listener)
+		listeners = append(listeners, fmt.Sprintf("%s://0.0.0.0:%d", name, listener.Port))
+		advertisedListeners = append(advertisedListeners, fmt.Sprintf("%s://%s:%d", name, hostName, listener.Port))
+		protocols = append(protocols, fmt.Sprintf("%s:%s", name, krp.GetListenerSecurityProtocol(listener)))
+
+		envPrefix := fmt.Sprintf("CONF_KAFKA_LISTENER_NAME_%s", strings.ToLower(listener.Name))
+		switch krp.GetListenerAuthentication(listener) {
+		case ListenerAuthenticationScram:
+			envVars = append(envVars, corev1.EnvVar{Name: envPrefix + "_SASL_ENABLED_MECHANISMS", Value: "SCRAM-SHA-512"})
+		case ListenerAuthenticationOAuth:
+			envVars = append(envVars, corev1.EnvVar{Name: envPrefix + "_SASL_ENABLED_MECHANISMS", Value: "OAUTHBEARER"})
+		}
+		if listener.TLS {
+			clientAuth := "none"
+			if krp.GetListenerAuthentication(listener) == ListenerAuthenticationMTLS {
+				clientAuth = "required"
+			}
+			envVars = append(envVars, corev1.EnvVar{Name: envPrefix + "_SSL_CLIENT_AUTH", Value: clientAuth})
+		}
+	}
+	return append([]corev1.EnvVar{
+		{Name: "CLIENT_LISTENERS", Value: strings.Join(listeners, ",")},
+		{Name: "CLIENT_ADVERTISED_LISTENERS", Value: strings.Join(advertisedListeners, ",")},
+		{Name: "CLIENT_LISTENER_SECURITY_PROTOCOL_MAP", Value: strings.Join(protocols, ",")},
+	}, envVars...)
+}
+
+// getClientServicePorts returns ports of client listeners exposed by Kafka services
+func (krp KafkaResourceProvider) getClientServicePorts() []corev1.ServicePort {
+	externalPort := corev1.ServicePort{
+		Name:     "external-kafka-client",
+		Port:     9094,
+		Protocol: corev1.ProtocolTCP,
+	}
+	if len(krp.spec.Listeners) == 0 {
+		return []corev1.ServicePort{
+			{
+				Name:     "kafka-client",
+				Port:     9092,
+				Protocol: corev1.ProtocolTCP,
+			},
+			externalPort,
+			{
+				Name:     "nonencrypted-kafka-client",
+				Port:     9095,
+				Protocol: corev1.ProtocolTCP,
+			},
+		}
+	}
+	var ports []corev1.ServicePort
+	for _, listener := range krp.spec.Listeners {
+		portName := "kafka-client"
+		if listener.Port != InternalListenerPort {
+			portName = strings.ToLower(listener.Name)
+		}
+		ports = append(ports, corev1.ServicePort{Name: portName, Port: listener.Port, Protocol: corev1.ProtocolTCP})
+	}
+	return append(ports, externalPort)
+}
+
+// appendListenersContainerPorts adds ports of client listeners which differ from default ones to container ports
+func (krp KafkaResourceProvider) appendListenersContainerPorts(ports []corev1.ContainerPort) []corev1.ContainerPort {
+	for _, listener := range krp.spec.Listeners {
+		found := false
+		for _, port := range ports {
+			if port.ContainerPort == listener.Port {
+				found = true
+			}
+		}
+		if !found {
+			ports = append(ports, corev1.ContainerPort{ContainerPort: listener.Port, Protocol: corev1.ProtocolTCP})
+		}
+	}
+	return ports
+}
@@ -0,0 +1,180 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"sort"
+
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// MaxBrokerId is the maximum ID of broker, greater IDs are reserved for Kraft controllers
+	MaxBrokerId   = quorumControllerIdOffset
+	NodePoolLabel = "pool"
+)
+
+// IsNodePoolsEnabled returns true if brokers are split into node pools
+func (krp KafkaResourceProvider) IsNodePoolsEnabled() bool {
+	return len(krp.spec.NodePools) > 0
+}
+
+// GetBrokerIds returns sorted IDs of all brokers. Without node pools brokers have IDs from 1 to Replicas,
+// otherwise each pool has its own range of IDs starting from FirstBrokerId.
+func (krp KafkaResourceProvider) GetBrokerIds() []int {
+	var brokerIds []int
+	if !krp.IsNodePoolsEnabled() {
+		for brokerId := 1; brokerId <= krp.spec.Replicas; brokerId++ {
+			brokerIds = append(brokerIds, brokerId)
+		}
+		return brokerIds
+	}
+	for _, pool := range krp.spec.NodePools {
+		for i := 0; i < pool.Replicas; i++ {
+			brokerIds = append(brokerIds, pool.FirstBrokerId+i)
+		}
+	}
+	sort.Ints(brokerIds)
+	return brokerIds
+}
+
+// GetBrokerPoolName returns name of node pool which broker belongs to, it is empty without node pools
+func (krp KafkaResourceProvider) GetBrokerPoolName(brokerId int) string {
+	pool, _ := krp.getBrokerPool(brokerId)
+	return pool.Name
+}
+
+// GetBrokerPools returns node pool names by broker IDs, it is nil without node pools
+func (krp KafkaResourceProvider) GetBrokerPools() map[int32]string {
+	if !krp.IsNodePoolsEnabled() {
+		return nil
+	}
+	pools := make(map[int32]string)
+	for _, brokerId := range krp.GetBrokerIds() {
+		pools[int32(brokerId)] = krp.GetBrokerPoolName(brokerId)
+	}
+	return pools
+}
+
+// GetNodePoolsStatus returns broker IDs of each node pool
+func (krp KafkaResourceProvider) GetNodePoolsStatus() []kafkaservice.NodePoolStatus {
+	var statuses []kafkaservice.NodePoolStatus
+	for _, pool := range krp.spec.NodePools {
+		status := kafkaservice.NodePoolStatus{Name: pool.Name}
+		for i := 0; i < pool.Replicas; i++ {
+			status.BrokerIds = append(status.BrokerIds, pool.FirstBrokerId+i)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// GetBrokerStorageNode returns node which broker is bound to with Storage.Nodes of its pool
+func (krp KafkaResourceProvider) GetBrokerStorageNode(brokerId int) string {
+	pool, index := krp.getBrokerPool(brokerId)
+	if index < len(pool.Storage.Nodes) {
+		return pool.Storage.Nodes[index]
+	}
+	return ""
+}
+
+// GetBrokerConfiguredRack returns rack of broker specified explicitly with Racks of its pool
+func (krp KafkaResourceProvider) GetBrokerConfiguredRack(brokerId int) string {
+	pool, index := krp.getBrokerPool(brokerId)
+	if index < len(pool.Racks) {
+		return pool.Racks[index]
+	}
+	return ""
+}
+
+// GetBrokersStorageCapacity returns total size of persistent volumes by broker IDs. Brokers without
+// persistent storage or with size which cannot be parsed are skipped.
+func (krp KafkaResourceProvider) GetBrokersStorageCapacity() map[int32]int64 {
+	capacity := make(map[int32]int64)
+	for _, brokerId := range krp.GetBrokerIds() {
+		if size := krp.getBrokerStorageCapacity(brokerId); size > 0 {
+			capacity[int32(brokerId)] = size
+		}
+	}
+	return capacity
+}
+
+func (krp KafkaResourceProvider) getBrokerStorageCapacity(brokerId int) int64 {
+	storage, _ := krp.getBrokerStorage(brokerId)
+	sizes := []string{storage.Size}
+	for _, dataVolume := range storage.DataVolumes {
+		sizes = append(sizes, dataVolume.Size)
+	}
+	var capacity int64
+	for _, size := range sizes {
+		quantity, err := resource.ParseQuantity(size)
+		if err != nil {
+			return 0
+		}
+		capacity += quantity.Value()
+	}
+	return capacity
+}
+
+// getBrokerStorage returns storage configuration of broker pool and index of broker in the pool
+func (krp KafkaResourceProvider) getBrokerStorage(brokerId int) (kafkaservice.Storage, int) {
+	pool, index := krp.getBrokerPool(brokerId)
+	return *pool.Storage, index
+}
+
+// getBrokerIndex returns position of broker in the sorted list of all brokers
+func (krp KafkaResourceProvider) getBrokerIndex(brokerId int) int {
+	for index, id := range krp.GetBrokerIds() {
+		if id == brokerId {
+			return index
+		}
+	}
+	return brokerId - 1
+}
+
+// getBrokerPool returns node pool which broker belongs to and index of broker in the pool.
+// Unspecified parameters of the pool are taken from Kafka specification. Without node pools
+// all brokers belong to one unnamed pool defined by Kafka specification.
+func (krp KafkaResourceProvider) getBrokerPool(brokerId int) (kafkaservice.NodePool, int) {
+	defaultPool := kafkaservice.NodePool{
+		Replicas:      krp.spec.Replicas,
+		FirstBrokerId: 1,
+		HeapSize:      krp.spec.HeapSize,
+		Resources:     krp.spec.Resources,
+		Storage:       &krp.spec.Storage,
+		Affinity:      &krp.spec.Affinity,
+		Racks:         krp.spec.Racks,
+	}
+	for _, pool := range krp.spec.NodePools {
+		if brokerId < pool.FirstBrokerId || brokerId >= pool.FirstBrokerId+pool.Replicas {
+			continue
+		}
+		if pool.HeapSize == 0 {
+			pool.HeapSize = defaultPool.HeapSize
+		}
+		if pool.Resources.Requests == nil && pool.Resources.Limits == nil {
+			pool.Resources = defaultPool.Resources
+		}
+		if pool.Storage == nil {
+			pool.Storage = defaultPool.Storage
+		}
+		if pool.Affinity == nil {
+			pool.Affinity = defaultPool.Affinity
+		}
+		return pool, brokerId - pool.FirstBrokerId
+	}
+	return defaultPool, brokerId - 1
+}
@@ -22,14 +22,20 @@ import (
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sort"
 	"strconv"
 	"strings"
 )
 
 const (
 	persistentVolumeClaimPattern           = "pvc-%s-%d"
+	dataVolumePersistentVolumeClaimPattern = "pvc-%s-%d-%s"
+	dataVolumeMountPathPattern             = "/var/opt/kafka/data-%s"
 	defaultClockSkew                       = 10
 	defaultJwkSourceType                   = "jwks"
 	defaultJwksConnectionTimeout           = 1000
@@ -42,7 +48,29 @@ const (
 	defaultAllBrokersStartTimeoutSeconds   = 600
 	defaultTopicReassignmentTimeoutSeconds = 300
 	defaultBrokerDeploymentScaleInEnabled  = false
+	defaultScalingStrategy                 = "leader-skew"
+	defaultMaxBrokerDiskUsagePercent       = 85
+	defaultLeaderBalancingIntervalSeconds  = 300
+	defaultMaxConcurrentPartitionMoves     = 20
+	defaultLeaderSkewThresholdPercent      = 10
+	defaultMaxLeaderElectionsPerCheck      = 100
+	defaultRackAuditIntervalSeconds        = 3600
 	zooKeeperClusterID                     = "U5tHX5uHQnmsniDS54EF_w"
+	quorumControllerIdOffset               = 2000
+	quorumControllerPort                   = 9092
+	zooKeeperMigrationControllerId         = 3000
+	defaultRemoteStorageManagerClassName   = "io.aiven.kafka.tieredstorage.RemoteStorageManager"
+	defaultRemoteStorageManagerClassPath   = "/opt/kafka/tiered-storage/core/*:/opt/kafka/tiered-storage/s3/*"
+	defaultRemoteStorageBackendClassName   = "io.aiven.kafka.tieredstorage.storage.s3.S3Storage"
+	defaultRemoteStorageRegion             = "us-east-1"
+	externalServicePattern                 = "external-%s-%d"
+	externalBootstrapServicePattern        = "external-%s-bootstrap"
+	externalListenerPort                   = 9094
+	ExternalAccessLabel                    = "kafkaservice.qubership.org/external-access"
+	ExternalAccessLoadBalancer             = "loadbalancer"
+	ExternalAccessNodePort                 = "nodeport"
+	ExternalAccessIngress                  = "ingress"
+	IngressExternalPort                    = 443
 )
 
 type KafkaResourceProvider struct {
@@ -86,28 +114,11 @@ func (krp KafkaResourceProvider) GetServiceAccountName() string {
 func (krp KafkaResourceProvider) NewKafkaClientServiceForCR() *corev1.Service {
 	kafkaLabels := krp.GetKafkaLabels()
 	selectorLabels := krp.GetSelectorLabels()
-	ports := []corev1.ServicePort{
-		{
-			Name:     "kafka-client",
-			Port:     9092,
-			Protocol: corev1.ProtocolTCP,
-		},
-		{
-			Name:     "external-kafka-client",
-			Port:     9094,
-			Protocol: corev1.ProtocolTCP,
-		},
-		{
-			Name:     "nonencrypted-kafka-client",
-			Port:     9095,
-			Protocol: corev1.ProtocolTCP,
-		},
-		{
-			Name:     "jolokia-http",
-			Port:     9087,
-			Protocol: corev1.ProtocolTCP,
-		},
-	}
+	ports := append(krp.getClientServicePorts(), corev1.ServicePort{
+		Name:     "jolokia-http",
+		Port:     9087,
+		Protocol: corev1.ProtocolTCP,
+	})
 	clientService := newServiceForCR(krp.cr.Name, krp.cr.Namespace, kafkaLabels, selectorLabels, ports)
 	return clientService
 }
@@ -145,24 +156,8 @@ func (krp KafkaResourceProvider) NewKafkaBrokerServiceForCR(brokerId int) *corev
 	serviceName := fmt.Sprintf("%s-%d", krp.cr.Name, brokerId)
 	kafkaLabels := krp.GetKafkaLabels()
 	kafkaLabels["name"] = serviceName
-	selectorLabels := krp.GetSelectorLabels()
-	selectorLabels["name"] = serviceName
-	ports := []corev1.ServicePort{
-		{
-			Name:     "kafka-client",
-			Port:     9092,
-			Protocol: corev1.ProtocolTCP,
-		},
-		{
-			Name:     "external-kafka-client",
-			Port:     9094,
-			Protocol: corev1.ProtocolTCP,
-		},
-		{
-			Name:     "nonencrypted-kafka-client",
-			Port:     9095,
-			Protocol: corev1.ProtocolTCP,
-		},
+	selectorLabels := krp.GetBrokerPodSelectorLabels(brokerId)
+	ports := append(krp.getClientServicePorts(), []corev1.ServicePort{
 		{
 			Name:     "jolokia-http",
 			Port:     9087,
@@ -173,11 +168,158 @@ func (krp KafkaResourceProvider) NewKafkaBrokerServiceForCR(brokerId int) *corev
 			Port:     8080,
 			Protocol: corev1.ProtocolTCP,
 		},
-	}
+	}...)
 	kafkaBrokerService := newServiceForBroker(serviceName, krp.cr.Namespace, kafkaLabels, selectorLabels, ports)
 	return kafkaBrokerService
 }
 
+// GetExternalAccessType returns type of external access to brokers managed by operator or empty string
+func (krp KafkaResourceProvider) GetExternalAccessType() string {
+	return strings.ToLower(krp.spec.ExternalAccess.Type)
+}
+
+func (krp KafkaResourceProvider) GetBrokerExternalServiceName(brokerId int) string {
+	return fmt.Sprintf(externalServicePattern, krp.cr.Name, brokerId)
+}
+
+func (krp KafkaResourceProvider) GetBootstrapExternalServiceName() string {
+	return fmt.Sprintf(externalBootstrapServicePattern, krp.cr.Name)
+}
+
+// GetBrokerIngressHost returns host name of broker behind ingress controller
+func (krp KafkaResourceProvider) GetBrokerIngressHost(brokerId int) string {
+	return fmt.Sprintf("broker-%d.%s", brokerId, krp.spec.ExternalAccess.Host)
+}
+
+// GetBootstrapIngressHost returns host name of all brokers behind ingress controller
+func (krp KafkaResourceProvider) GetBootstrapIngressHost() string {
+	return fmt.Sprintf("bootstrap.%s", krp.spec.ExternalAccess.Host)
+}
+
+// NewKafkaBrokerExternalServiceForCR returns service which exposes external listener of broker
+func (krp KafkaResourceProvider) NewKafkaBrokerExternalServiceForCR(brokerId int) *corev1.Service {
+	selectorLabels := krp.GetBrokerPodSelectorLabels(brokerId)
+	return krp.newExternalService(krp.GetBrokerExternalServiceName(brokerId), selectorLabels)
+}
+
+// NewKafkaBootstrapExternalServiceForCR returns service which exposes external listeners of all brokers
+// and is used by external clients for initial connection
+func (krp KafkaResourceProvider) NewKafkaBootstrapExternalServiceForCR() *corev1.Service {
+	return krp.newExternalService(krp.GetBootstrapExternalServiceName(), krp.GetSelectorLabels())
+}
+
+func (krp KafkaResourceProvider) newExternalService(serviceName string, selectorLabels map[string]string) *corev1.Service {
+	kafkaLabels := krp.GetKafkaLabels()
+	kafkaLabels["name"] = serviceName
+	kafkaLabels[ExternalAccessLabel] = "true"
+	ports := []corev1.ServicePort{
+		{
+			Name:       "external-kafka-client",
+			Port:       externalListenerPort,
+			TargetPort: intstr.FromInt(externalListenerPort),
+			Protocol:   corev1.ProtocolTCP,
+		},
+	}
+	service := newServiceForBroker(serviceName, krp.cr.Namespace, kafkaLabels, selectorLabels, ports)
+	for key, value := range krp.spec.ExternalAccess.Annotations {
+		service.Annotations[key] = value
+	}
+	switch krp.GetExternalAccessType() {
+	case ExternalAccessLoadBalancer:
+		service.Spec.Type = corev1.ServiceTypeLoadBalancer
+	case ExternalAccessNodePort:
+		service.Spec.Type = corev1.ServiceTypeNodePort
+	default:
+		service.Spec.Type = corev1.ServiceTypeClusterIP
+	}
+	if service.Spec.Type != corev1.ServiceTypeClusterIP && krp.spec.ExternalAccess.ExternalTrafficPolicy != "" {
+		service.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyType(krp.spec.ExternalAccess.ExternalTrafficPolicy)
+	}
+	return service
+}
+
+// NewKafkaBrokerIngressForCR returns ingress which passes TLS connections for broker host name to broker external service
+func (krp KafkaResourceProvider) NewKafkaBrokerIngressForCR(brokerId int) *networkingv1.Ingress {
+	return krp.newExternalIngress(krp.GetBrokerExternalServiceName(brokerId), krp.GetBrokerIngressHost(brokerId))
+}
+
+// NewKafkaBootstrapIngressForCR returns ingress which passes TLS connections for bootstrap host name to bootstrap service
+func (krp KafkaResourceProvider) NewKafkaBootstrapIngressForCR() *networkingv1.Ingress {
+	return krp.newExternalIngress(krp.GetBootstrapExternalServiceName(), krp.GetBootstrapIngressHost())
+}
+
+func (krp KafkaResourceProvider) newExternalIngress(serviceName string, host string) *networkingv1.Ingress {
+	kafkaLabels := krp.GetKafkaLabels()
+	kafkaLabels["name"] = serviceName
+	kafkaLabels[ExternalAccessLabel] = "true"
+	annotations := map[string]string{
+		"nginx.ingress.kubernetes.io/ssl-passthrough": "true",
+	}
+	for key, value := range krp.spec.ExternalAccess.Annotations {
+		annotations[key] = value
+	}
+	pathType := networkingv1.PathTypePrefix
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        serviceName,
+			Namespace:   krp.cr.Namespace,
+			Labels:      kafkaLabels,
+			Annotations: annotations,
+		},
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{{Hosts: []string{host}}},
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: host,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     "/",
+									PathType: &pathType,
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: serviceName,
+											Port: networkingv1.ServiceBackendPort{Number: externalListenerPort},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if krp.spec.ExternalAccess.IngressClassName != "" {
+		ingressClassName := krp.spec.ExternalAccess.IngressClassName
+		ingress.Spec.IngressClassName = &ingressClassName
+	}
+	return ingress
+}
+
+// getBrokerExternalAddress returns host and port advertised by broker for external clients.
+// Addresses of brokers exposed by operator are taken from status, where they are stored when assigned.
+func (krp KafkaResourceProvider) getBrokerExternalAddress(brokerId int) (string, string) {
+	if krp.GetExternalAccessType() != "" {
+		for _, address := range krp.cr.Status.ExternalAccessStatus.Brokers {
+			if address.BrokerId == brokerId && address.Host != "" && address.Port != 0 {
+				return address.Host, strconv.Itoa(int(address.Port))
+			}
+		}
+		return "", ""
+	}
+	if len(krp.cr.Spec.ExternalHostNames) > 0 {
+		externalPort := "9094"
+		index := krp.getBrokerIndex(brokerId)
+		if len(krp.cr.Spec.ExternalPorts) > 0 {
+			externalPort = strconv.Itoa(krp.cr.Spec.ExternalPorts[index])
+		}
+		return krp.cr.Spec.ExternalHostNames[index], externalPort
+	}
+	return "", ""
+}
+
 func (krp KafkaResourceProvider) NewKafkaControllerServiceForCR() *corev1.Service {
 	serviceName := fmt.Sprintf("%s-%s", krp.cr.Name, "kraft-controller")
 	kafkaLabels := krp.GetKafkaLabels()
@@ -201,22 +343,23 @@ func (krp KafkaResourceProvider) NewKafkaControllerServiceForCR() *corev1.Servic
 // NewKafkaPersistentVolumeClaimForCR returns a persistent volume claim for specified Kafka server
 func (krp KafkaResourceProvider) NewKafkaPersistentVolumeClaimForCR(brokerId int) *corev1.PersistentVolumeClaim {
 	var spec corev1.PersistentVolumeClaimSpec
-	var volumesCount = len(krp.spec.Storage.Volumes)
-	if err := krp.checkStorageClassDefinition(volumesCount); err != nil {
+	storage, index := krp.getBrokerStorage(brokerId)
+	var volumesCount = len(storage.Volumes)
+	if err := checkStorageClassDefinition(storage, volumesCount); err != nil {
 		return nil
 	}
 	if volumesCount > 0 {
 		krp.logger.Info("Persistent volume claims are created by volume names.")
 		spec = corev1.PersistentVolumeClaimSpec{
-			VolumeName:       krp.spec.Storage.Volumes[brokerId-1],
+			VolumeName:       storage.Volumes[index],
 			StorageClassName: new(string),
 		}
-		if len(krp.spec.Storage.ClassName) > 0 {
-			spec.StorageClassName = krp.getStorageClassForDynamicallyProvidedVolumes(brokerId)
+		if len(storage.ClassName) > 0 {
+			spec.StorageClassName = getStorageClassForDynamicallyProvidedVolumes(storage, index)
 		}
-	} else if len(krp.spec.Storage.Labels) > 0 {
+	} else if len(storage.Labels) > 0 {
 		krp.logger.Info("Persistent volume claims are created by labels.")
-		keyValue := strings.Split(krp.spec.Storage.Labels[brokerId-1], "=")
+		keyValue := strings.Split(storage.Labels[index], "=")
 		spec = corev1.PersistentVolumeClaimSpec{
 			Selector: &metav1.LabelSelector{
 				MatchLabels: map[string]string{
@@ -224,14 +367,14 @@ func (krp KafkaResourceProvider) NewKafkaPersistentVolumeClaimForCR(brokerId int
 				},
 			},
 		}
-		if len(krp.spec.Storage.ClassName) > 0 {
-			spec.StorageClassName = krp.getStorageClassForDynamicallyProvidedVolumes(brokerId)
+		if len(storage.ClassName) > 0 {
+			spec.StorageClassName = getStorageClassForDynamicallyProvidedVolumes(storage, index)
 		}
 
-	} else if len(krp.spec.Storage.ClassName) > 0 {
+	} else if len(storage.ClassName) > 0 {
 		krp.logger.Info("Persistent volume claims are created by class names.")
 		spec = corev1.PersistentVolumeClaimSpec{
-			StorageClassName: krp.getStorageClassForDynamicallyProvidedVolumes(brokerId),
+			StorageClassName: getStorageClassForDynamicallyProvidedVolumes(storage, index),
 		}
 	} else {
 		return nil
@@ -240,7 +383,7 @@ func (krp KafkaResourceProvider) NewKafkaPersistentVolumeClaimForCR(brokerId int
 	spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
 	spec.Resources = corev1.ResourceRequirements{
 		Requests: corev1.ResourceList{
-			corev1.ResourceStorage: resource.MustParse(krp.spec.Storage.Size),
+			corev1.ResourceStorage: resource.MustParse(storage.Size),
 		},
 	}
 	labels := krp.GetKafkaLabels()
@@ -258,6 +401,45 @@ func (krp KafkaResourceProvider) NewKafkaPersistentVolumeClaimForCR(brokerId int
 	return persistentVolumeClaim
 }
 
+// NewKafkaDataVolumePersistentVolumeClaimsForCR returns persistent volume claims for additional data volumes of Kafka broker
+func (krp KafkaResourceProvider) NewKafkaDataVolumePersistentVolumeClaimsForCR(brokerId int) []*corev1.PersistentVolumeClaim {
+	var persistentVolumeClaims []*corev1.PersistentVolumeClaim
+	storage, _ := krp.getBrokerStorage(brokerId)
+	for _, dataVolume := range storage.DataVolumes {
+		spec := corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse(dataVolume.Size),
+				},
+			},
+		}
+		if dataVolume.ClassName != "" {
+			className := dataVolume.ClassName
+			spec.StorageClassName = &className
+		}
+		persistentVolumeClaims = append(persistentVolumeClaims, &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf(dataVolumePersistentVolumeClaimPattern, krp.cr.Name, brokerId, dataVolume.Name),
+				Namespace: krp.cr.Namespace,
+				Labels:    krp.GetKafkaLabels(),
+			},
+			Spec: spec,
+		})
+	}
+	return persistentVolumeClaims
+}
+
+// GetLogDirs returns Kafka log directories of broker, the first one is located on the main data volume
+func (krp KafkaResourceProvider) GetLogDirs(brokerId int) []string {
+	logDirs := []string{fmt.Sprintf("/var/opt/kafka/data/%d", brokerId)}
+	storage, _ := krp.getBrokerStorage(brokerId)
+	for _, dataVolume := range storage.DataVolumes {
+		logDirs = append(logDirs, fmt.Sprintf(dataVolumeMountPathPattern+"/%d", dataVolume.Name, brokerId))
+	}
+	return logDirs
+}
+
 // NewKafkaControllerPersistentVolumeClaimForCR returns a persistent volume claim for migration Kafka controller
 func (krp KafkaResourceProvider) NewKafkaControllerPersistentVolumeClaimForCR() *corev1.PersistentVolumeClaim {
 	var spec corev1.PersistentVolumeClaimSpec
@@ -318,8 +500,8 @@ func (krp KafkaResourceProvider) NewKafkaControllerPersistentVolumeClaimForCR()
 }
 
 // checkStorageClassDefinition checks that number of storage classes is correct
-func (krp KafkaResourceProvider) checkStorageClassDefinition(volumesCount int) error {
-	var classNamesCount = len(krp.spec.Storage.ClassName)
+func checkStorageClassDefinition(storage kafkaservice.Storage, volumesCount int) error {
+	var classNamesCount = len(storage.ClassName)
 	if classNamesCount > 1 && classNamesCount != volumesCount {
 		return errors.New("number of storage class names should be matched to volumes number")
 	}
@@ -335,14 +517,15 @@ func (krp KafkaResourceProvider) checkControllerStorageClassDefinition() error {
 	return nil
 }
 
-// getStorageClassForDynamicallyProvidedVolumes returns storage class for specific Kafka broker with dynamic provisioning
-func (krp KafkaResourceProvider) getStorageClassForDynamicallyProvidedVolumes(brokerId int) *string {
-	var classNames = krp.spec.Storage.ClassName
+// getStorageClassForDynamicallyProvidedVolumes returns storage class for Kafka broker with specified index
+// in its pool with dynamic provisioning
+func getStorageClassForDynamicallyProvidedVolumes(storage kafkaservice.Storage, index int) *string {
+	var classNames = storage.ClassName
 	var classNamesCount = len(classNames)
 	if classNamesCount == 1 {
 		return &classNames[0]
 	}
-	return &classNames[brokerId-1]
+	return &classNames[index]
 }
 
 // NewEmptySecret creates empty secret for Kafka
@@ -358,18 +541,94 @@ func (krp KafkaResourceProvider) NewEmptySecret(secretName string) *corev1.Secre
 	return secret
 }
 
+// NewKafkaConfigMap returns config map of Kafka cluster with given data
+func (krp KafkaResourceProvider) NewKafkaConfigMap(configMapName string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configMapName,
+			Namespace: krp.cr.Namespace,
+			Labels:    krp.GetKafkaLabels(),
+		},
+		Data: data,
+	}
+}
+
+// NewKafkaBrokerPodDisruptionBudgetForCR returns PodDisruptionBudget for all broker deployments,
+// it allows only one unavailable broker unless other limits are specified
+func (krp KafkaResourceProvider) NewKafkaBrokerPodDisruptionBudgetForCR() *policyv1.PodDisruptionBudget {
+	pdb := krp.spec.PodDisruptionBudget
+	return newPodDisruptionBudget(fmt.Sprintf("%s-broker-pdb", krp.cr.Name), krp.cr.Namespace,
+		krp.GetKafkaLabels(), krp.GetSelectorLabels(), pdb.MaxUnavailable, pdb.MinAvailable)
+}
+
+// NewKafkaQuorumControllerPodDisruptionBudgetForCR returns PodDisruptionBudget which allows only one unavailable
+// dedicated Kraft controller, so that quorum is not lost
+func (krp KafkaResourceProvider) NewKafkaQuorumControllerPodDisruptionBudgetForCR() *policyv1.PodDisruptionBudget {
+	maxUnavailable := intstr.FromInt(1)
+	return newPodDisruptionBudget(fmt.Sprintf("%s-controller-pdb", krp.cr.Name), krp.cr.Namespace,
+		util.JoinMaps(krp.GetKafkaLabels(), krp.GetQuorumControllerSelectorLabels()), krp.GetQuorumControllerSelectorLabels(),
+		&maxUnavailable, nil)
+}
+
+// NewKafkaKraftControllerPodDisruptionBudgetForCR returns PodDisruptionBudget for Kraft controller used for migration
+func (krp KafkaResourceProvider) NewKafkaKraftControllerPodDisruptionBudgetForCR() *policyv1.PodDisruptionBudget {
+	deploymentName := fmt.Sprintf("%s-%s", krp.cr.Name, "kraft-controller")
+	kafkaLabels := krp.GetKafkaLabels()
+	kafkaLabels["name"] = deploymentName
+	kafkaLabels["component"] = "kafka-controller"
+	delete(kafkaLabels, "clusterName")
+	selectorLabels := map[string]string{"name": deploymentName, "component": "kafka-controller"}
+	pdb := krp.spec.MigrationController.PodDisruptionBudget
+	return newPodDisruptionBudget(fmt.Sprintf("%s-pdb", deploymentName), krp.cr.Namespace,
+		kafkaLabels, selectorLabels, pdb.MaxUnavailable, pdb.MinAvailable)
+}
+
 func (krp KafkaResourceProvider) NewKafkaBrokerDeploymentForCR(brokerId int, rack string, kraftEnabled bool, zkClusterID string) *appsv1.Deployment {
 	deploymentName := fmt.Sprintf("%s-%d", krp.cr.Name, brokerId)
-	domainName := fmt.Sprintf("%s-broker", krp.cr.Name)
 	kafkaLabels := krp.GetKafkaLabels()
 	kafkaLabels["name"] = deploymentName
 	kafkaLabels["app.kubernetes.io/instance"] = fmt.Sprintf("%s-%s", deploymentName, krp.cr.Namespace)
 	selectorLabels := krp.GetSelectorLabels()
 	selectorLabels["name"] = deploymentName
-	kafkaCustomLabels := krp.GetKafkaCustomLabels(kafkaLabels)
+	if poolName := krp.GetBrokerPoolName(brokerId); poolName != "" {
+		kafkaLabels[NodePoolLabel] = poolName
+	}
 	replicas := int32(1)
+	rollbackTimeout := getRollbackTimeout(krp.cr.Spec)
+
+	template := krp.newKafkaBrokerPodTemplate(brokerId, deploymentName, rack, kraftEnabled, zkClusterID)
+	template.Labels = krp.GetKafkaCustomLabels(kafkaLabels)
+	template.Spec.Hostname = deploymentName
+	template.Spec.Subdomain = krp.getBrokerDomainName()
+
+	brokerDeployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deploymentName,
+			Namespace: krp.cr.Namespace,
+			Labels:    kafkaLabels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Strategy:                appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			Replicas:                &replicas,
+			ProgressDeadlineSeconds: &rollbackTimeout,
+			Selector:                &metav1.LabelSelector{MatchLabels: selectorLabels},
+			Template:                template,
+		},
+	}
+	return brokerDeployment
+}
+
+func (krp KafkaResourceProvider) getBrokerDomainName() string {
+	return fmt.Sprintf("%s-broker", krp.cr.Name)
+}
+
+// newKafkaBrokerPodTemplate returns pod template of broker, the host name is used to build addresses of broker listeners
+func (krp KafkaResourceProvider) newKafkaBrokerPodTemplate(brokerId int, hostName string, rack string, kraftEnabled bool, zkClusterID string) corev1.PodTemplateSpec {
+	deploymentName := fmt.Sprintf("%s-%d", krp.cr.Name, brokerId)
+	domainName := krp.getBrokerDomainName()
+	pool, _ := krp.getBrokerPool(brokerId)
 	var dataVolumeSource corev1.VolumeSource
-	if len(krp.cr.Spec.Storage.Volumes) > 0 || (len(krp.cr.Spec.Storage.ClassName) > 0 && krp.cr.Spec.Storage.ClassName[0] != defaultVolumeName) {
+	if len(pool.Storage.Volumes) > 0 || (len(pool.Storage.ClassName) > 0 && pool.Storage.ClassName[0] != defaultVolumeName) {
 		dataVolumeSource = corev1.VolumeSource{
 			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
 				ClaimName: fmt.Sprintf(persistentVolumeClaimPattern, krp.cr.Name, brokerId),
@@ -380,17 +639,8 @@ func (krp KafkaResourceProvider) NewKafkaBrokerDeploymentForCR(brokerId int, rac
 	}
 	oauth := krp.cr.Spec.Oauth
 	terminationGracePeriod := getTerminationGracePeriod(krp.cr.Spec)
-	rollbackTimeout := getRollbackTimeout(krp.cr.Spec)
 
-	externalHostName := ""
-	externalPort := ""
-	if len(krp.cr.Spec.ExternalHostNames) > 0 {
-		externalHostName = krp.cr.Spec.ExternalHostNames[brokerId-1]
-		externalPort = "9094"
-		if len(krp.cr.Spec.ExternalPorts) > 0 {
-			externalPort = strconv.Itoa(krp.cr.Spec.ExternalPorts[brokerId-1])
-		}
-	}
+	externalHostName, externalPort := krp.getBrokerExternalAddress(brokerId)
 
 	volumes := []corev1.Volume{
 		{Name: "data", VolumeSource: dataVolumeSource},
@@ -423,17 +673,17 @@ func (krp KafkaResourceProvider) NewKafkaBrokerDeploymentForCR(brokerId int, rac
 		{Name: "CONF_KAFKA_BROKER_RACK", Value: rack},
 		{
 			Name:  "INTERNAL_HOST_NAME",
-			Value: fmt.Sprintf("%s.%s", deploymentName, krp.cr.Namespace),
+			Value: fmt.Sprintf("%s.%s", hostName, krp.cr.Namespace),
 		},
 		{
 			Name:  "INTER_BROKER_HOST_NAME",
-			Value: fmt.Sprintf("%s.%s.%s", deploymentName, domainName, krp.cr.Namespace),
+			Value: fmt.Sprintf("%s.%s.%s", hostName, domainName, krp.cr.Namespace),
 		},
 		{
 			Name:  "HEAP_OPTS",
-			Value: fmt.Sprintf("-Xms%dm -Xmx%dm", krp.cr.Spec.HeapSize, krp.cr.Spec.HeapSize),
+			Value: fmt.Sprintf("-Xms%dm -Xmx%dm", pool.HeapSize, pool.HeapSize),
 		},
-		{Name: "DISABLE_SECURITY", Value: strconv.FormatBool(krp.isSecurityDisabled())},
+		{Name: "DISABLE_SECURITY", Value: strconv.FormatBool(krp.IsSecurityDisabled())},
 		{Name: "CLOCK_SKEW", Value: strconv.Itoa(getClockSkew(oauth))},
 		{Name: "JWK_SOURCE_TYPE", Value: getJwkSourceType(oauth)},
 		{
@@ -451,15 +701,15 @@ func (krp KafkaResourceProvider) NewKafkaBrokerDeploymentForCR(brokerId int, rac
 		zkClusterID = zooKeeperClusterID
 	}
 	if kraftEnabled {
-		var voters []string
-		for i := 1; i <= krp.spec.Replicas; i++ {
-			voters = append(voters, fmt.Sprintf("%d@%s-%d.kafka-broker.%s:9096", i, krp.cr.Name, i, krp.cr.Namespace))
+		processRoles := "broker,controller"
+		if krp.IsQuorumControllersEnabled() {
+			processRoles = "broker"
 		}
 		envVars = append(envVars, []corev1.EnvVar{
 			{Name: "KRAFT_ENABLED", Value: "true"},
 			{Name: "KRAFT_CLUSTER_ID", Value: zkClusterID},
-			{Name: "VOTERS", Value: strings.Join(voters, ",")},
-			{Name: "PROCESS_ROLES", Value: "broker,controller"},
+			krp.getQuorumEnv(),
+			{Name: "PROCESS_ROLES", Value: processRoles},
 		}...)
 	} else {
 		envVars = append(envVars, []corev1.EnvVar{
@@ -469,7 +719,7 @@ func (krp KafkaResourceProvider) NewKafkaBrokerDeploymentForCR(brokerId int, rac
 	}
 	envVars = append(envVars, krp.getSecretEnvs(kraftEnabled)...)
 
-	if krp.cr.Spec.Ssl.Enabled && krp.cr.Spec.Ssl.SecretName != "" {
+	if krp.cr.Spec.Ssl.Enabled && krp.getSslSecretName(deploymentName) != "" {
 		envVars = append(envVars, []corev1.EnvVar{
 			{Name: "ENABLE_SSL", Value: "true"},
 			{Name: "SSL_CIPHER_SUITES", Value: strings.Join(krp.cr.Spec.Ssl.CipherSuites, ",")},
@@ -481,7 +731,7 @@ func (krp KafkaResourceProvider) NewKafkaBrokerDeploymentForCR(brokerId int, rac
 			Name: "ssl-certs",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: krp.cr.Spec.Ssl.SecretName,
+					SecretName: krp.getSslSecretName(deploymentName),
 				},
 			},
 		})
@@ -511,36 +761,48 @@ func (krp KafkaResourceProvider) NewKafkaBrokerDeploymentForCR(brokerId int, rac
 		}...)
 	}
 
-	brokerDeployment := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      deploymentName,
-			Namespace: krp.cr.Namespace,
-			Labels:    kafkaLabels,
-		},
-		Spec: appsv1.DeploymentSpec{
-			Strategy:                appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
-			Replicas:                &replicas,
-			ProgressDeadlineSeconds: &rollbackTimeout,
-			Selector:                &metav1.LabelSelector{MatchLabels: selectorLabels},
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{Labels: kafkaCustomLabels},
-				Spec: corev1.PodSpec{
-					Volumes:                       volumes,
-					InitContainers:                krp.getInitContainers(),
-					Containers:                    krp.createDeploymentContainers(buildEnvs(envVars, krp.spec.EnvironmentVariables, krp.logger), volumeMounts, kraftEnabled, false),
-					SecurityContext:               &krp.cr.Spec.SecurityContext,
-					ServiceAccountName:            krp.GetServiceAccountName(),
-					TerminationGracePeriodSeconds: &terminationGracePeriod,
-					Hostname:                      deploymentName,
-					Subdomain:                     domainName,
-					Affinity:                      krp.getBrokerAffinityForCR(brokerId),
-					Tolerations:                   krp.spec.Tolerations,
-					PriorityClassName:             krp.spec.PriorityClassName,
+	if len(pool.Storage.DataVolumes) > 0 {
+		for _, dataVolume := range pool.Storage.DataVolumes {
+			volumeName := fmt.Sprintf("data-%s", dataVolume.Name)
+			volumes = append(volumes, corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: fmt.Sprintf(dataVolumePersistentVolumeClaimPattern, krp.cr.Name, brokerId, dataVolume.Name),
+					},
 				},
-			},
+			})
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: volumeName, MountPath: fmt.Sprintf(dataVolumeMountPathPattern, dataVolume.Name)})
+		}
+		envVars = append(envVars, corev1.EnvVar{Name: "LOG_DIRS", Value: strings.Join(krp.GetLogDirs(brokerId), ",")})
+	}
+
+	if krp.spec.TieredStorage.Enabled {
+		envVars = append(envVars, krp.getTieredStorageEnvs()...)
+	}
+
+	if len(krp.spec.Listeners) > 0 {
+		envVars = append(envVars, krp.getListenersEnvs(fmt.Sprintf("%s.%s", hostName, krp.cr.Namespace))...)
+	}
+	envVars = append(envVars, krp.getStaticConfigEnvs()...)
+
+	containers := krp.createDeploymentContainers(buildEnvs(envVars, krp.spec.EnvironmentVariables, krp.logger), volumeMounts, kraftEnabled, false)
+	containers[0].Resources = pool.Resources
+
+	return corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Volumes:                       volumes,
+			InitContainers:                krp.getInitContainers(),
+			Containers:                    containers,
+			SecurityContext:               &krp.cr.Spec.SecurityContext,
+			ServiceAccountName:            krp.GetServiceAccountName(),
+			TerminationGracePeriodSeconds: &terminationGracePeriod,
+			Affinity:                      krp.getBrokerAffinityForCR(brokerId),
+			TopologySpreadConstraints:     krp.spec.TopologySpreadConstraints,
+			Tolerations:                   krp.spec.Tolerations,
+			PriorityClassName:             krp.spec.PriorityClassName,
 		},
 	}
-	return brokerDeployment
 }
 
 func (krp KafkaResourceProvider) NewKafkaKraftControllerDeploymentForCR(zkClusterID string, migrated bool, zookeeperEnabled bool) *appsv1.Deployment {
@@ -592,8 +854,8 @@ func (krp KafkaResourceProvider) NewKafkaKraftControllerDeploymentForCR(zkCluste
 	var voters []string
 	voters = append(voters, "3000@localhost:9092")
 	if migrated {
-		for i := 1; i <= krp.spec.Replicas; i++ {
-			voters = append(voters, fmt.Sprintf("%d@%s-%d.kafka-broker.%s:9096", i, krp.cr.Name, i, krp.cr.Namespace))
+		for _, brokerId := range krp.GetBrokerIds() {
+			voters = append(voters, fmt.Sprintf("%d@%s-%d.kafka-broker.%s:9096", brokerId, krp.cr.Name, brokerId, krp.cr.Namespace))
 		}
 	}
 
@@ -623,7 +885,7 @@ func (krp KafkaResourceProvider) NewKafkaKraftControllerDeploymentForCR(zkCluste
 			Name:  "HEAP_OPTS",
 			Value: fmt.Sprintf("-Xms%dm -Xmx%dm", krp.cr.Spec.HeapSize, krp.cr.Spec.HeapSize),
 		},
-		{Name: "DISABLE_SECURITY", Value: strconv.FormatBool(krp.isSecurityDisabled())},
+		{Name: "DISABLE_SECURITY", Value: strconv.FormatBool(krp.IsSecurityDisabled())},
 		{Name: "CLOCK_SKEW", Value: strconv.Itoa(getClockSkew(oauth))},
 		{Name: "JWK_SOURCE_TYPE", Value: getJwkSourceType(oauth)},
 		{
@@ -731,6 +993,310 @@ func (krp KafkaResourceProvider) NewKafkaKraftControllerDeploymentForCR(zkCluste
 	return controllerDeployment
 }
 
+// IsQuorumControllersEnabled returns true if Kraft metadata quorum is served by dedicated controller nodes
+func (krp KafkaResourceProvider) IsQuorumControllersEnabled() bool {
+	return krp.spec.Kraft.Enabled && !krp.spec.Kraft.Migration && krp.spec.Controllers.Replicas > 0
+}
+
+// IsDynamicQuorumEnabled returns true if dedicated controllers are configured with dynamic Kraft quorum,
+// so that voters can be added and removed without restart of the cluster
+func (krp KafkaResourceProvider) IsDynamicQuorumEnabled() bool {
+	return krp.IsQuorumControllersEnabled() && krp.spec.Controllers.DynamicQuorum
+}
+
+// GetQuorumControllerId returns Kraft node id of dedicated controller with specified ordinal number
+func (krp KafkaResourceProvider) GetQuorumControllerId(controllerIndex int) int {
+	return quorumControllerIdOffset + controllerIndex
+}
+
+// GetQuorumControllerName returns name of deployment and service for dedicated controller with specified ordinal number
+func (krp KafkaResourceProvider) GetQuorumControllerName(controllerIndex int) string {
+	return fmt.Sprintf("%s-controller-%d", krp.cr.Name, controllerIndex)
+}
+
+// GetQuorumVoters returns list of Kraft controller quorum voters in "id@host:port" format
+func (krp KafkaResourceProvider) GetQuorumVoters() []string {
+	var voters []string
+	if krp.IsQuorumControllersEnabled() {
+		for i := 1; i <= krp.spec.Controllers.Replicas; i++ {
+			voters = append(voters, fmt.Sprintf("%d@%s.%s:%d",
+				krp.GetQuorumControllerId(i), krp.GetQuorumControllerName(i), krp.cr.Namespace, quorumControllerPort))
+		}
+		return voters
+	}
+	for _, brokerId := range krp.GetBrokerIds() {
+		voters = append(voters, fmt.Sprintf("%d@%s-%d.kafka-broker.%s:9096", brokerId, krp.cr.Name, brokerId, krp.cr.Namespace))
+	}
+	return voters
+}
+
+// GetQuorumBootstrapServers returns address of headless service of dedicated controllers used to discover dynamic
+// Kraft quorum. It does not depend on the number of controllers, so that pods are not restarted when quorum is resized.
+func (krp KafkaResourceProvider) GetQuorumBootstrapServers() []string {
+	return []string{fmt.Sprintf("%s.%s:%d", krp.GetQuorumBootstrapServiceName(), krp.cr.Namespace, quorumControllerPort)}
+}
+
+// GetQuorumBootstrapServiceName returns name of headless service which resolves to addresses of all dedicated controllers
+func (krp KafkaResourceProvider) GetQuorumBootstrapServiceName() string {
+	return fmt.Sprintf("%s-controller-bootstrap", krp.cr.Name)
+}
+
+// GetQuorumControllerIndex returns ordinal number of dedicated controller by its Kraft node id or 0 if node is not a dedicated controller
+func (krp KafkaResourceProvider) GetQuorumControllerIndex(nodeId int) int {
+	if nodeId <= quorumControllerIdOffset || nodeId >= zooKeeperMigrationControllerId {
+		return 0
+	}
+	return nodeId - quorumControllerIdOffset
+}
+
+func (krp KafkaResourceProvider) getQuorumEnv() corev1.EnvVar {
+	if krp.IsDynamicQuorumEnabled() {
+		return corev1.EnvVar{Name: "QUORUM_BOOTSTRAP_SERVERS", Value: strings.Join(krp.GetQuorumBootstrapServers(), ",")}
+	}
+	return corev1.EnvVar{Name: "VOTERS", Value: strings.Join(krp.GetQuorumVoters(), ",")}
+}
+
+func (krp KafkaResourceProvider) GetQuorumControllerSelectorLabels() map[string]string {
+	return map[string]string{
+		"component":   "kafka-controller",
+		"clusterName": krp.cr.Name,
+	}
+}
+
+func (krp KafkaResourceProvider) NewKafkaQuorumControllerServiceForCR(controllerIndex int) *corev1.Service {
+	serviceName := krp.GetQuorumControllerName(controllerIndex)
+	kafkaLabels := util.JoinMaps(krp.GetKafkaLabels(), krp.GetQuorumControllerSelectorLabels())
+	kafkaLabels["name"] = serviceName
+	selectorLabels := krp.GetQuorumControllerSelectorLabels()
+	selectorLabels["name"] = serviceName
+	ports := []corev1.ServicePort{
+		{
+			Name:     "kafka-kraft-controller",
+			Port:     quorumControllerPort,
+			Protocol: corev1.ProtocolTCP,
+		},
+		{
+			Name:     "prometheus-http",
+			Port:     8080,
+			Protocol: corev1.ProtocolTCP,
+		},
+	}
+	return newServiceForBroker(serviceName, krp.cr.Namespace, kafkaLabels, selectorLabels, ports)
+}
+
+// NewKafkaQuorumBootstrapServiceForCR returns headless service which selects all dedicated controllers including not
+// ready ones, Kafka resolves all its addresses to discover dynamic Kraft quorum
+func (krp KafkaResourceProvider) NewKafkaQuorumBootstrapServiceForCR() *corev1.Service {
+	serviceName := krp.GetQuorumBootstrapServiceName()
+	kafkaLabels := util.JoinMaps(krp.GetKafkaLabels(), krp.GetQuorumControllerSelectorLabels())
+	kafkaLabels["name"] = serviceName
+	ports := []corev1.ServicePort{
+		{
+			Name:     "kafka-kraft-controller",
+			Port:     quorumControllerPort,
+			Protocol: corev1.ProtocolTCP,
+		},
+	}
+	service := newServiceForBroker(serviceName, krp.cr.Namespace, kafkaLabels, krp.GetQuorumControllerSelectorLabels(), ports)
+	service.Spec.ClusterIP = corev1.ClusterIPNone
+	return service
+}
+
+// NewKafkaQuorumControllerPersistentVolumeClaimForCR returns a persistent volume claim for dedicated Kafka controller
+func (krp KafkaResourceProvider) NewKafkaQuorumControllerPersistentVolumeClaimForCR(controllerIndex int) *corev1.PersistentVolumeClaim {
+	storage := krp.spec.Controllers.Storage
+	var spec corev1.PersistentVolumeClaimSpec
+	var storageClassName *string
+	if len(storage.ClassName) == 1 {
+		storageClassName = &storage.ClassName[0]
+	} else if len(storage.ClassName) >= controllerIndex {
+		storageClassName = &storage.ClassName[controllerIndex-1]
+	}
+	if len(storage.Volumes) >= controllerIndex {
+		spec = corev1.PersistentVolumeClaimSpec{
+			VolumeName:       storage.Volumes[controllerIndex-1],
+			StorageClassName: new(string),
+		}
+		if storageClassName != nil {
+			spec.StorageClassName = storageClassName
+		}
+	} else if len(storage.Labels) >= controllerIndex {
+		keyValue := strings.Split(storage.Labels[controllerIndex-1], "=")
+		spec = corev1.PersistentVolumeClaimSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					keyValue[0]: keyValue[1],
+				},
+			},
+			StorageClassName: storageClassName,
+		}
+	} else if storageClassName != nil && *storageClassName != defaultVolumeName {
+		spec = corev1.PersistentVolumeClaimSpec{
+			StorageClassName: storageClassName,
+		}
+	} else {
+		return nil
+	}
+
+	spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	spec.Resources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceStorage: resource.MustParse(storage.Size),
+		},
+	}
+	labels := util.JoinMaps(krp.GetKafkaLabels(), krp.GetQuorumControllerSelectorLabels())
+	labels["kraft"] = "enabled"
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("pvc-%s", krp.GetQuorumControllerName(controllerIndex)),
+			Namespace: krp.cr.Namespace,
+			Labels:    labels,
+		},
+		Spec: spec,
+	}
+}
+
+// NewKafkaQuorumControllerDeploymentForCR returns a deployment for dedicated Kafka controller
+func (krp KafkaResourceProvider) NewKafkaQuorumControllerDeploymentForCR(controllerIndex int, zkClusterID string) *appsv1.Deployment {
+	deploymentName := krp.GetQuorumControllerName(controllerIndex)
+	controllers := krp.spec.Controllers
+	kafkaLabels := util.JoinMaps(krp.GetKafkaLabels(), krp.GetQuorumControllerSelectorLabels())
+	kafkaLabels["name"] = deploymentName
+	kafkaLabels["app.kubernetes.io/instance"] = fmt.Sprintf("%s-%s", deploymentName, krp.cr.Namespace)
+	selectorLabels := krp.GetQuorumControllerSelectorLabels()
+	selectorLabels["name"] = deploymentName
+	kafkaCustomLabels := krp.GetKafkaCustomLabels(kafkaLabels)
+	replicas := int32(1)
+	var dataVolumeSource corev1.VolumeSource
+	if pvc := krp.NewKafkaQuorumControllerPersistentVolumeClaimForCR(controllerIndex); pvc != nil {
+		dataVolumeSource = corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: pvc.Name,
+			},
+		}
+	} else {
+		dataVolumeSource = corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}
+	}
+	if zkClusterID == "" {
+		zkClusterID = zooKeeperClusterID
+	}
+	heapSize := controllers.HeapSize
+	if heapSize == 0 {
+		heapSize = krp.spec.HeapSize
+	}
+	oauth := krp.cr.Spec.Oauth
+	terminationGracePeriod := getTerminationGracePeriod(krp.cr.Spec)
+	rollbackTimeout := getRollbackTimeout(krp.cr.Spec)
+
+	volumes := []corev1.Volume{
+		{Name: "data", VolumeSource: dataVolumeSource},
+		{Name: "log", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		{Name: "trusted-certs", VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: fmt.Sprintf("%s-trusted-certs", krp.cr.Name)}}},
+		{Name: "public-certs", VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: fmt.Sprintf("%s-public-certs", krp.cr.Name)}}},
+	}
+
+	volumeMounts := []corev1.VolumeMount{
+		{Name: "data", MountPath: "/var/opt/kafka/data"},
+		{Name: "log", MountPath: "/opt/kafka/logs"},
+		{Name: "trusted-certs", MountPath: "/opt/kafka/trustcerts"},
+		{Name: "public-certs", MountPath: "/opt/kafka/public-certs"},
+	}
+
+	hostName := fmt.Sprintf("%s.%s", deploymentName, krp.cr.Namespace)
+	envVars := []corev1.EnvVar{
+		{Name: "KRAFT_ENABLED", Value: "true"},
+		{Name: "BROKER_ID", Value: strconv.Itoa(krp.GetQuorumControllerId(controllerIndex))},
+		{Name: "CONTROLLER_LISTENER_NAMES", Value: "CONTROLLER"},
+		{Name: "LISTENERS", Value: fmt.Sprintf("CONTROLLER://0.0.0.0:%d", quorumControllerPort)},
+		{Name: "ADVERTISED_LISTENERS", Value: fmt.Sprintf("CONTROLLER://%s:%d", hostName, quorumControllerPort)},
+		{Name: "KRAFT_CLUSTER_ID", Value: zkClusterID},
+		{Name: "INTER_BROKER_LISTENER_NAME", Value: "INTERNAL"},
+		{Name: "PROCESS_ROLES", Value: "controller"},
+		krp.getQuorumEnv(),
+		{Name: "READINESS_PERIOD", Value: "30"},
+		{Name: "REPLICATION_FACTOR", Value: "3"},
+		{Name: "INTERNAL_HOST_NAME", Value: hostName},
+		{
+			Name:  "HEAP_OPTS",
+			Value: fmt.Sprintf("-Xms%dm -Xmx%dm", heapSize, heapSize),
+		},
+		{Name: "DISABLE_SECURITY", Value: strconv.FormatBool(krp.IsSecurityDisabled())},
+		{Name: "CLOCK_SKEW", Value: strconv.Itoa(getClockSkew(oauth))},
+		{Name: "JWK_SOURCE_TYPE", Value: getJwkSourceType(oauth)},
+		{
+			Name:  "JWKS_CONNECTION_TIMEOUT",
+			Value: strconv.Itoa(getJwksConnectionTimeout(oauth)),
+		},
+		{Name: "TOKEN_ROLES_PATH", Value: krp.cr.Spec.TokenRolesPath},
+		{Name: "JWKS_READ_TIMEOUT", Value: strconv.Itoa(getJwksReadTimeout(oauth))},
+		{Name: "JWKS_SIZE_LIMIT", Value: strconv.Itoa(getJwksSizeLimit(oauth))},
+		{Name: "ENABLE_AUDIT_LOGS", Value: strconv.FormatBool(krp.isAuditLogsEnabled())},
+		{Name: "ENABLE_AUTHORIZATION", Value: strconv.FormatBool(krp.isEnableAuthorization())},
+		{Name: "HEALTH_CHECK_TIMEOUT", Value: strconv.Itoa(int(getHealthCheckTimeout(krp.cr.Spec)))},
+	}
+	if krp.IsDynamicQuorumEnabled() && controllerIndex == 1 {
+		envVars = append(envVars, corev1.EnvVar{Name: "STANDALONE_CONTROLLER", Value: "true"})
+	}
+	envVars = append(envVars, krp.getSecretEnvs(true)...)
+
+	if krp.cr.Spec.Ssl.Enabled && krp.getSslSecretName(deploymentName) != "" {
+		envVars = append(envVars, []corev1.EnvVar{
+			{Name: "ENABLE_SSL", Value: "true"},
+			{Name: "SSL_CIPHER_SUITES", Value: strings.Join(krp.cr.Spec.Ssl.CipherSuites, ",")},
+			{Name: "ENABLE_2WAY_SSL", Value: strconv.FormatBool(krp.cr.Spec.Ssl.EnableTwoWaySsl)},
+		}...)
+
+		volumes = append(volumes, corev1.Volume{
+			Name: "ssl-certs",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: krp.getSslSecretName(deploymentName),
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: "ssl-certs", MountPath: "/opt/kafka/tls"})
+	}
+
+	containers := krp.createDeploymentContainers(buildEnvs(envVars, krp.spec.EnvironmentVariables, krp.logger), volumeMounts, true, true)
+	if controllers.Resources.Requests != nil || controllers.Resources.Limits != nil {
+		containers[0].Resources = controllers.Resources
+	}
+
+	controllerDeployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deploymentName,
+			Namespace: krp.cr.Namespace,
+			Labels:    kafkaLabels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Strategy:                appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			Replicas:                &replicas,
+			ProgressDeadlineSeconds: &rollbackTimeout,
+			Selector:                &metav1.LabelSelector{MatchLabels: selectorLabels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: kafkaCustomLabels},
+				Spec: corev1.PodSpec{
+					Volumes:                       volumes,
+					InitContainers:                krp.getInitContainers(),
+					Containers:                    containers,
+					SecurityContext:               &krp.cr.Spec.SecurityContext,
+					ServiceAccountName:            krp.GetServiceAccountName(),
+					TerminationGracePeriodSeconds: &terminationGracePeriod,
+					Hostname:                      deploymentName,
+					Affinity:                      krp.getQuorumControllerAffinityForCR(controllerIndex),
+					Tolerations:                   controllers.Tolerations,
+					PriorityClassName:             controllers.PriorityClassName,
+				},
+			},
+		},
+	}
+	return controllerDeployment
+}
+
 func (krp KafkaResourceProvider) GetZooKeeperFullName() string {
 	zooKeeperConnect := krp.spec.ZookeeperConnect
 	zooKeeperAddress := strings.Split(zooKeeperConnect, ":")[0]
@@ -741,7 +1307,7 @@ func (krp KafkaResourceProvider) GetZooKeeperFullName() string {
 	return zooKeeperAddress
 }
 
-func (krp KafkaResourceProvider) isSecurityDisabled() bool {
+func (krp KafkaResourceProvider) IsSecurityDisabled() bool {
 	if krp.cr.Spec.DisableSecurity != nil {
 		return *krp.cr.Spec.DisableSecurity
 	}
@@ -848,6 +1414,105 @@ func (krp KafkaResourceProvider) GetTopicReassignmentTimeoutSeconds() int {
 	return defaultTopicReassignmentTimeoutSeconds
 }
 
+// GetReplicationThrottleBytesPerSec returns the limit of replication rate during partitions reassignment,
+// 0 means that replication is not throttled
+func (krp KafkaResourceProvider) GetReplicationThrottleBytesPerSec() int64 {
+	if krp.cr.Spec.Scaling.ReplicationThrottleBytesPerSec != nil {
+		return *krp.cr.Spec.Scaling.ReplicationThrottleBytesPerSec
+	}
+	return 0
+}
+
+// GetMaxConcurrentPartitionMoves returns the maximum number of partitions moved at the same time during reassignment
+func (krp KafkaResourceProvider) GetMaxConcurrentPartitionMoves() int {
+	if krp.cr.Spec.Scaling.MaxConcurrentPartitionMoves != nil {
+		return *krp.cr.Spec.Scaling.MaxConcurrentPartitionMoves
+	}
+	return defaultMaxConcurrentPartitionMoves
+}
+
+// GetMaxBytesInFlight returns the maximum size of data moved at the same time during reassignment,
+// 0 means that the size is not limited
+func (krp KafkaResourceProvider) GetMaxBytesInFlight() int64 {
+	if krp.cr.Spec.Scaling.MaxBytesInFlight != nil {
+		return *krp.cr.Spec.Scaling.MaxBytesInFlight
+	}
+	return 0
+}
+
+// GetScalingStrategy returns strategy of partitions balancing between brokers
+func (krp KafkaResourceProvider) GetScalingStrategy() string {
+	if krp.cr.Spec.Scaling.Strategy != "" {
+		return krp.cr.Spec.Scaling.Strategy
+	}
+	return defaultScalingStrategy
+}
+
+// GetMaxBrokerDiskUsagePercent returns the share of broker storage which must not be exceeded by partitions balancing
+func (krp KafkaResourceProvider) GetMaxBrokerDiskUsagePercent() int {
+	if krp.cr.Spec.Scaling.MaxBrokerDiskUsagePercent != nil {
+		return *krp.cr.Spec.Scaling.MaxBrokerDiskUsagePercent
+	}
+	return defaultMaxBrokerDiskUsagePercent
+}
+
+// IsReassignPartitionsDryRun returns true if partitions reassignment is only proposed without moving replicas
+func (krp KafkaResourceProvider) IsReassignPartitionsDryRun() bool {
+	return krp.cr.Spec.Scaling.DryRun
+}
+
+// IsLeaderBalancingEnabled returns true if leaders of partitions are periodically rebalanced
+func (krp KafkaResourceProvider) IsLeaderBalancingEnabled() bool {
+	return krp.cr.Spec.Scaling.LeaderBalancing.Enabled
+}
+
+// GetLeaderBalancingIntervalSeconds returns the period of leader skew checks
+func (krp KafkaResourceProvider) GetLeaderBalancingIntervalSeconds() int {
+	if krp.cr.Spec.Scaling.LeaderBalancing.IntervalSeconds > 0 {
+		return krp.cr.Spec.Scaling.LeaderBalancing.IntervalSeconds
+	}
+	return defaultLeaderBalancingIntervalSeconds
+}
+
+// GetLeaderSkewThresholdPercent returns the deviation of leaders number of broker which triggers leader elections
+func (krp KafkaResourceProvider) GetLeaderSkewThresholdPercent() int {
+	if krp.cr.Spec.Scaling.LeaderBalancing.SkewThresholdPercent != nil {
+		return *krp.cr.Spec.Scaling.LeaderBalancing.SkewThresholdPercent
+	}
+	return defaultLeaderSkewThresholdPercent
+}
+
+// GetMaxLeaderElectionsPerCheck returns the maximum number of partitions changed by one leader skew check
+func (krp KafkaResourceProvider) GetMaxLeaderElectionsPerCheck() int {
+	if krp.cr.Spec.Scaling.LeaderBalancing.MaxElectionsPerCheck > 0 {
+		return krp.cr.Spec.Scaling.LeaderBalancing.MaxElectionsPerCheck
+	}
+	return defaultMaxLeaderElectionsPerCheck
+}
+
+// IsLeaderBalancingReorderReplicasEnabled returns true if replicas of partitions can be reordered to change preferred leaders
+func (krp KafkaResourceProvider) IsLeaderBalancingReorderReplicasEnabled() bool {
+	return krp.cr.Spec.Scaling.LeaderBalancing.ReorderReplicas
+}
+
+// IsRackAuditEnabled returns true if placement of partition replicas across racks is periodically checked
+func (krp KafkaResourceProvider) IsRackAuditEnabled() bool {
+	return krp.cr.Spec.Scaling.RackAudit.Enabled
+}
+
+// GetRackAuditIntervalSeconds returns the period of rack checks
+func (krp KafkaResourceProvider) GetRackAuditIntervalSeconds() int {
+	if krp.cr.Spec.Scaling.RackAudit.IntervalSeconds > 0 {
+		return krp.cr.Spec.Scaling.RackAudit.IntervalSeconds
+	}
+	return defaultRackAuditIntervalSeconds
+}
+
+// IsRackAuditRepairEnabled returns true if replicas sharing a rack are moved to other racks
+func (krp KafkaResourceProvider) IsRackAuditRepairEnabled() bool {
+	return krp.cr.Spec.Scaling.RackAudit.Repair
+}
+
 func getHealthCheckTimeout(kafka kafkaservice.KafkaSpec) int32 {
 	if kafka.HealthCheckTimeout != nil {
 		return *kafka.HealthCheckTimeout
@@ -856,8 +1521,9 @@ func getHealthCheckTimeout(kafka kafkaservice.KafkaSpec) int32 {
 }
 
 func (krp KafkaResourceProvider) getBrokerAffinityForCR(brokerId int) *corev1.Affinity {
-	affinity := krp.cr.Spec.Affinity.DeepCopy()
-	if len(krp.cr.Spec.Storage.Nodes) > 0 {
+	pool, _ := krp.getBrokerPool(brokerId)
+	affinity := pool.Affinity.DeepCopy()
+	if node := krp.GetBrokerStorageNode(brokerId); node != "" {
 		affinity.NodeAffinity = &corev1.NodeAffinity{
 			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
 				NodeSelectorTerms: []corev1.NodeSelectorTerm{
@@ -866,7 +1532,7 @@ func (krp KafkaResourceProvider) getBrokerAffinityForCR(brokerId int) *corev1.Af
 							{
 								Key:      "kubernetes.io/hostname",
 								Operator: corev1.NodeSelectorOpIn,
-								Values:   []string{krp.cr.Spec.Storage.Nodes[brokerId-1]},
+								Values:   []string{node},
 							},
 						},
 					},
@@ -899,6 +1565,28 @@ func (krp KafkaResourceProvider) getControllerAffinityForCR() *corev1.Affinity {
 	return affinity
 }
 
+func (krp KafkaResourceProvider) getQuorumControllerAffinityForCR(controllerIndex int) *corev1.Affinity {
+	affinity := krp.cr.Spec.Controllers.Affinity.DeepCopy()
+	if len(krp.cr.Spec.Controllers.Storage.Nodes) >= controllerIndex {
+		affinity.NodeAffinity = &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchExpressions: []corev1.NodeSelectorRequirement{
+							{
+								Key:      "kubernetes.io/hostname",
+								Operator: corev1.NodeSelectorOpIn,
+								Values:   []string{krp.cr.Spec.Controllers.Storage.Nodes[controllerIndex-1]},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	return affinity
+}
+
 func (krp KafkaResourceProvider) getCommand() []string {
 	return nil
 }
@@ -915,6 +1603,60 @@ func (krp KafkaResourceProvider) getExecCommand(originalCommand []string) *corev
 	return &corev1.ExecAction{Command: originalCommand}
 }
 
+// getTieredStorageEnvs returns broker properties which enable offloading of log segments to S3 compatible object storage
+func (krp KafkaResourceProvider) getTieredStorageEnvs() []corev1.EnvVar {
+	tieredStorage := krp.spec.TieredStorage
+	classPath := tieredStorage.RemoteStorageManagerClassPath
+	if classPath == "" {
+		classPath = defaultRemoteStorageManagerClassPath
+	}
+	className := tieredStorage.RemoteStorageManagerClassName
+	if className == "" {
+		className = defaultRemoteStorageManagerClassName
+	}
+	region := tieredStorage.Region
+	if region == "" {
+		region = defaultRemoteStorageRegion
+	}
+	envVars := []corev1.EnvVar{
+		{Name: "CONF_KAFKA_REMOTE_LOG_STORAGE_SYSTEM_ENABLE", Value: "true"},
+		{Name: "CONF_KAFKA_REMOTE_LOG_STORAGE_MANAGER_CLASS_NAME", Value: className},
+		{Name: "CONF_KAFKA_REMOTE_LOG_STORAGE_MANAGER_CLASS_PATH", Value: classPath},
+		{Name: "CONF_KAFKA_REMOTE_LOG_METADATA_MANAGER_LISTENER_NAME", Value: "INTER_BROKER"},
+		{Name: "CONF_KAFKA_RSM_CONFIG_STORAGE_BACKEND_CLASS", Value: defaultRemoteStorageBackendClassName},
+		{Name: "CONF_KAFKA_RSM_CONFIG_STORAGE_S3_BUCKET_NAME", Value: tieredStorage.Bucket},
+		{Name: "CONF_KAFKA_RSM_CONFIG_STORAGE_S3_REGION", Value: region},
+	}
+	if tieredStorage.Endpoint != "" {
+		// S3 compatible storages like MinIO are usually accessed by path-style URLs
+		envVars = append(envVars, []corev1.EnvVar{
+			{Name: "CONF_KAFKA_RSM_CONFIG_STORAGE_S3_ENDPOINT_URL", Value: tieredStorage.Endpoint},
+			{Name: "CONF_KAFKA_RSM_CONFIG_STORAGE_S3_PATH_STYLE_ACCESS_ENABLED", Value: "true"},
+		}...)
+	}
+	if tieredStorage.SecretName != "" {
+		envVars = append(envVars, []corev1.EnvVar{
+			{Name: "CONF_KAFKA_RSM_CONFIG_STORAGE_AWS_ACCESS_KEY_ID", ValueFrom: getSecretEnvVarSource(tieredStorage.SecretName, "access-key-id")},
+			{Name: "CONF_KAFKA_RSM_CONFIG_STORAGE_AWS_SECRET_ACCESS_KEY", ValueFrom: getSecretEnvVarSource(tieredStorage.SecretName, "secret-access-key")},
+		}...)
+	}
+	if tieredStorage.LocalRetentionMs != nil {
+		envVars = append(envVars, corev1.EnvVar{Name: "CONF_KAFKA_LOG_LOCAL_RETENTION_MS", Value: strconv.FormatInt(*tieredStorage.LocalRetentionMs, 10)})
+	}
+	if tieredStorage.LocalRetentionBytes != nil {
+		envVars = append(envVars, corev1.EnvVar{Name: "CONF_KAFKA_LOG_LOCAL_RETENTION_BYTES", Value: strconv.FormatInt(*tieredStorage.LocalRetentionBytes, 10)})
+	}
+	keys := make([]string, 0, len(tieredStorage.Config))
+	for key := range tieredStorage.Config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		envVars = append(envVars, corev1.EnvVar{Name: GetConfigEnvName(key), Value: tieredStorage.Config[key]})
+	}
+	return envVars
+}
+
 func (krp KafkaResourceProvider) getSecretEnvs(kraftEnabled bool) []corev1.EnvVar {
 	envVars := []corev1.EnvVar{
 		{Name: "ADMIN_USERNAME", ValueFrom: getSecretEnvVarSource(krp.cr.Spec.SecretName, "admin-username")},
@@ -947,6 +1689,9 @@ func (krp KafkaResourceProvider) createDeploymentContainers(envs []corev1.EnvVar
 			{ContainerPort: 9096, Protocol: corev1.ProtocolTCP},
 		}...)
 	}
+	if !kraftController {
+		ports = krp.appendListenersContainerPorts(ports)
+	}
 	var livenessCommand []string
 	var readinessCommand []string
 	if kraftController {
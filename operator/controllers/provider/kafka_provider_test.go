@@ -0,0 +1,531 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	kafkaservice "github.com/Netcracker/qubership-kafka/operator/api/v1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func newTestKafkaCR(spec kafkaservice.KafkaSpec) *kafkaservice.Kafka {
+	spec.Replicas = 3
+	spec.HeapSize = 512
+	spec.SecretName = "kafka-secret"
+	return &kafkaservice.Kafka{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka-service"},
+		Spec:       spec,
+	}
+}
+
+func getEnvValue(envs []corev1.EnvVar, name string) string {
+	for _, env := range envs {
+		if env.Name == name {
+			return env.Value
+		}
+	}
+	return ""
+}
+
+func TestKafkaResourceProvider_GetQuorumVotersForCombinedMode(t *testing.T) {
+	krp := NewKafkaResourceProvider(newTestKafkaCR(kafkaservice.KafkaSpec{Kraft: kafkaservice.Kraft{Enabled: true}}), logr.Discard())
+	assert.False(t, krp.IsQuorumControllersEnabled())
+	assert.Equal(t, []string{
+		"1@kafka-1.kafka-broker.kafka-service:9096",
+		"2@kafka-2.kafka-broker.kafka-service:9096",
+		"3@kafka-3.kafka-broker.kafka-service:9096",
+	}, krp.GetQuorumVoters())
+
+	deployment := krp.NewKafkaBrokerDeploymentForCR(1, "", true, "")
+	assert.Equal(t, "broker,controller", getEnvValue(deployment.Spec.Template.Spec.Containers[0].Env, "PROCESS_ROLES"))
+}
+
+func TestKafkaResourceProvider_GetQuorumVotersForDedicatedControllers(t *testing.T) {
+	krp := NewKafkaResourceProvider(newTestKafkaCR(kafkaservice.KafkaSpec{
+		Kraft:       kafkaservice.Kraft{Enabled: true},
+		Controllers: kafkaservice.Controllers{Replicas: 3},
+	}), logr.Discard())
+	assert.True(t, krp.IsQuorumControllersEnabled())
+	voters := []string{
+		"2001@kafka-controller-1.kafka-service:9092",
+		"2002@kafka-controller-2.kafka-service:9092",
+		"2003@kafka-controller-3.kafka-service:9092",
+	}
+	assert.Equal(t, voters, krp.GetQuorumVoters())
+
+	brokerEnvs := krp.NewKafkaBrokerDeploymentForCR(1, "", true, "").Spec.Template.Spec.Containers[0].Env
+	assert.Equal(t, "broker", getEnvValue(brokerEnvs, "PROCESS_ROLES"))
+	assert.Equal(t, "2001@kafka-controller-1.kafka-service:9092,2002@kafka-controller-2.kafka-service:9092,2003@kafka-controller-3.kafka-service:9092",
+		getEnvValue(brokerEnvs, "VOTERS"))
+
+	controller := krp.NewKafkaQuorumControllerDeploymentForCR(2, "")
+	assert.Equal(t, "kafka-controller-2", controller.Name)
+	assert.Equal(t, "kafka-controller", controller.Spec.Selector.MatchLabels["component"])
+	controllerEnvs := controller.Spec.Template.Spec.Containers[0].Env
+	assert.Equal(t, "controller", getEnvValue(controllerEnvs, "PROCESS_ROLES"))
+	assert.Equal(t, "2002", getEnvValue(controllerEnvs, "BROKER_ID"))
+	assert.Equal(t, "-Xms512m -Xmx512m", getEnvValue(controllerEnvs, "HEAP_OPTS"))
+	assert.Equal(t, "CONTROLLER://kafka-controller-2.kafka-service:9092", getEnvValue(controllerEnvs, "ADVERTISED_LISTENERS"))
+	assert.NotNil(t, controller.Spec.Template.Spec.Volumes[0].EmptyDir)
+}
+
+func TestKafkaResourceProvider_QuorumControllersAreIgnoredDuringMigration(t *testing.T) {
+	krp := NewKafkaResourceProvider(newTestKafkaCR(kafkaservice.KafkaSpec{
+		Kraft:       kafkaservice.Kraft{Enabled: true, Migration: true},
+		Controllers: kafkaservice.Controllers{Replicas: 3},
+	}), logr.Discard())
+	assert.False(t, krp.IsQuorumControllersEnabled())
+	assert.Len(t, krp.GetQuorumVoters(), 3)
+}
+
+func TestKafkaResourceProvider_NewKafkaQuorumControllerPersistentVolumeClaimForCR(t *testing.T) {
+	krp := NewKafkaResourceProvider(newTestKafkaCR(kafkaservice.KafkaSpec{
+		Kraft: kafkaservice.Kraft{Enabled: true},
+		Controllers: kafkaservice.Controllers{
+			Replicas: 3,
+			Storage:  kafkaservice.Storage{ClassName: []string{"standard"}, Size: "5Gi"},
+		},
+	}), logr.Discard())
+	pvc := krp.NewKafkaQuorumControllerPersistentVolumeClaimForCR(3)
+	assert.NotNil(t, pvc)
+	assert.Equal(t, "pvc-kafka-controller-3", pvc.Name)
+	assert.Equal(t, "standard", *pvc.Spec.StorageClassName)
+	assert.Equal(t, "5Gi", pvc.Spec.Resources.Requests.Storage().String())
+
+	controller := krp.NewKafkaQuorumControllerDeploymentForCR(3, "")
+	assert.Equal(t, "pvc-kafka-controller-3", controller.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+}
+
+func TestKafkaResourceProvider_DynamicQuorumEnvs(t *testing.T) {
+	krp := NewKafkaResourceProvider(newTestKafkaCR(kafkaservice.KafkaSpec{
+		Kraft:       kafkaservice.Kraft{Enabled: true},
+		Controllers: kafkaservice.Controllers{Replicas: 3, DynamicQuorum: true},
+	}), logr.Discard())
+	assert.True(t, krp.IsDynamicQuorumEnabled())
+	bootstrapServers := "kafka-controller-bootstrap.kafka-service:9092"
+
+	brokerEnvs := krp.NewKafkaBrokerDeploymentForCR(1, "", true, "").Spec.Template.Spec.Containers[0].Env
+	assert.Equal(t, bootstrapServers, getEnvValue(brokerEnvs, "QUORUM_BOOTSTRAP_SERVERS"))
+	assert.Equal(t, "", getEnvValue(brokerEnvs, "VOTERS"))
+
+	firstControllerEnvs := krp.NewKafkaQuorumControllerDeploymentForCR(1, "").Spec.Template.Spec.Containers[0].Env
+	assert.Equal(t, bootstrapServers, getEnvValue(firstControllerEnvs, "QUORUM_BOOTSTRAP_SERVERS"))
+	assert.Equal(t, "true", getEnvValue(firstControllerEnvs, "STANDALONE_CONTROLLER"))
+	secondControllerEnvs := krp.NewKafkaQuorumControllerDeploymentForCR(2, "").Spec.Template.Spec.Containers[0].Env
+	assert.Equal(t, "", getEnvValue(secondControllerEnvs, "STANDALONE_CONTROLLER"))
+
+	// bootstrap servers do not depend on the number of controllers, so that resize of quorum does not restart pods
+	resized := NewKafkaResourceProvider(newTestKafkaCR(kafkaservice.KafkaSpec{
+		Kraft:       kafkaservice.Kraft{Enabled: true},
+		Controllers: kafkaservice.Controllers{Replicas: 5, DynamicQuorum: true},
+	}), logr.Discard())
+	assert.Equal(t, krp.NewKafkaBrokerDeploymentForCR(1, "", true, "").Spec.Template,
+		resized.NewKafkaBrokerDeploymentForCR(1, "", true, "").Spec.Template)
+	assert.Equal(t, krp.NewKafkaQuorumControllerDeploymentForCR(2, "").Spec.Template,
+		resized.NewKafkaQuorumControllerDeploymentForCR(2, "").Spec.Template)
+
+	bootstrapService := krp.NewKafkaQuorumBootstrapServiceForCR()
+	assert.Equal(t, "kafka-controller-bootstrap", bootstrapService.Name)
+	assert.Equal(t, corev1.ClusterIPNone, bootstrapService.Spec.ClusterIP)
+	assert.True(t, bootstrapService.Spec.PublishNotReadyAddresses)
+	assert.Equal(t, krp.GetQuorumControllerSelectorLabels(), bootstrapService.Spec.Selector)
+
+	assert.Equal(t, 5, krp.GetQuorumControllerIndex(2005))
+	assert.Equal(t, 0, krp.GetQuorumControllerIndex(3))
+	assert.Equal(t, 0, krp.GetQuorumControllerIndex(3000))
+}
+
+func TestKafkaResourceProvider_DataVolumes(t *testing.T) {
+	krp := NewKafkaResourceProvider(newTestKafkaCR(kafkaservice.KafkaSpec{
+		Storage: kafkaservice.Storage{
+			ClassName: []string{"standard"},
+			Size:      "10Gi",
+			DataVolumes: []kafkaservice.DataVolume{
+				{Name: "ssd", Size: "50Gi", ClassName: "fast"},
+				{Name: "hdd", Size: "100Gi"},
+			},
+		},
+	}), logr.Discard())
+	assert.Equal(t, []string{"/var/opt/kafka/data/2", "/var/opt/kafka/data-ssd/2", "/var/opt/kafka/data-hdd/2"}, krp.GetLogDirs(2))
+
+	claims := krp.NewKafkaDataVolumePersistentVolumeClaimsForCR(2)
+	assert.Len(t, claims, 2)
+	assert.Equal(t, "pvc-kafka-2-ssd", claims[0].Name)
+	assert.Equal(t, "fast", *claims[0].Spec.StorageClassName)
+	assert.Equal(t, "50Gi", claims[0].Spec.Resources.Requests.Storage().String())
+	assert.Nil(t, claims[1].Spec.StorageClassName)
+
+	podSpec := krp.NewKafkaBrokerDeploymentForCR(2, "", true, "").Spec.Template.Spec
+	assert.Equal(t, "/var/opt/kafka/data/2,/var/opt/kafka/data-ssd/2,/var/opt/kafka/data-hdd/2", getEnvValue(podSpec.Containers[0].Env, "LOG_DIRS"))
+	mountPaths := map[string]string{}
+	for _, volumeMount := range podSpec.Containers[0].VolumeMounts {
+		mountPaths[volumeMount.Name] = volumeMount.MountPath
+	}
+	assert.Equal(t, "/var/opt/kafka/data-ssd", mountPaths["data-ssd"])
+	assert.Equal(t, "/var/opt/kafka/data-hdd", mountPaths["data-hdd"])
+	assert.Equal(t, int64(160*1024*1024*1024), krp.GetBrokersStorageCapacity()[2])
+}
+
+func TestKafkaResourceProvider_TieredStorage(t *testing.T) {
+	localRetentionMs := int64(3600000)
+	krp := NewKafkaResourceProvider(newTestKafkaCR(kafkaservice.KafkaSpec{
+		Storage: kafkaservice.Storage{Size: "10Gi"},
+		TieredStorage: kafkaservice.TieredStorage{
+			Enabled:          true,
+			Bucket:           "kafka-segments",
+			Endpoint:         "http://minio:9000",
+			SecretName:       "kafka-tiered-storage",
+			LocalRetentionMs: &localRetentionMs,
+			Config:           map[string]string{"rsm.config.chunk.size": "4194304"},
+		},
+	}), logr.Discard())
+	envs := krp.NewKafkaBrokerDeploymentForCR(1, "", true, "").Spec.Template.Spec.Containers[0].Env
+	assert.Equal(t, "true", getEnvValue(envs, "CONF_KAFKA_REMOTE_LOG_STORAGE_SYSTEM_ENABLE"))
+	assert.Equal(t, defaultRemoteStorageManagerClassName, getEnvValue(envs, "CONF_KAFKA_REMOTE_LOG_STORAGE_MANAGER_CLASS_NAME"))
+	assert.Equal(t, "kafka-segments", getEnvValue(envs, "CONF_KAFKA_RSM_CONFIG_STORAGE_S3_BUCKET_NAME"))
+	assert.Equal(t, defaultRemoteStorageRegion, getEnvValue(envs, "CONF_KAFKA_RSM_CONFIG_STORAGE_S3_REGION"))
+	assert.Equal(t, "http://minio:9000", getEnvValue(envs, "CONF_KAFKA_RSM_CONFIG_STORAGE_S3_ENDPOINT_URL"))
+	assert.Equal(t, "true", getEnvValue(envs, "CONF_KAFKA_RSM_CONFIG_STORAGE_S3_PATH_STYLE_ACCESS_ENABLED"))
+	assert.Equal(t, "3600000", getEnvValue(envs, "CONF_KAFKA_LOG_LOCAL_RETENTION_MS"))
+	assert.Equal(t, "", getEnvValue(envs, "CONF_KAFKA_LOG_LOCAL_RETENTION_BYTES"))
+	assert.Equal(t, "4194304", getEnvValue(envs, "CONF_KAFKA_RSM_CONFIG_CHUNK_SIZE"))
+	for _, env := range envs {
+		if env.Name == "CONF_KAFKA_RSM_CONFIG_STORAGE_AWS_SECRET_ACCESS_KEY" {
+			assert.Equal(t, "kafka-tiered-storage", env.ValueFrom.SecretKeyRef.Name)
+			assert.Equal(t, "secret-access-key", env.ValueFrom.SecretKeyRef.Key)
+		}
+	}
+
+	krp = NewKafkaResourceProvider(newTestKafkaCR(kafkaservice.KafkaSpec{Storage: kafkaservice.Storage{Size: "10Gi"}}), logr.Discard())
+	envs = krp.NewKafkaBrokerDeploymentForCR(1, "", true, "").Spec.Template.Spec.Containers[0].Env
+	assert.Equal(t, "", getEnvValue(envs, "CONF_KAFKA_REMOTE_LOG_STORAGE_SYSTEM_ENABLE"))
+}
+
+func TestKafkaResourceProvider_ExternalAccess(t *testing.T) {
+	cr := newTestKafkaCR(kafkaservice.KafkaSpec{
+		Storage: kafkaservice.Storage{Size: "10Gi"},
+		ExternalAccess: kafkaservice.ExternalAccess{
+			Type:                  "loadbalancer",
+			ExternalTrafficPolicy: "Local",
+			Annotations:           map[string]string{"service.beta.kubernetes.io/aws-load-balancer-type": "nlb"},
+		},
+	})
+	cr.Status.ExternalAccessStatus.Brokers = []kafkaservice.BrokerExternalAddress{{BrokerId: 2, Host: "10.0.0.2", Port: 9094}}
+	krp := NewKafkaResourceProvider(cr, logr.Discard())
+	assert.Equal(t, ExternalAccessLoadBalancer, krp.GetExternalAccessType())
+
+	service := krp.NewKafkaBrokerExternalServiceForCR(2)
+	assert.Equal(t, "external-kafka-2", service.Name)
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, service.Spec.Type)
+	assert.Equal(t, corev1.ServiceExternalTrafficPolicyTypeLocal, service.Spec.ExternalTrafficPolicy)
+	assert.Equal(t, "kafka-2", service.Spec.Selector["name"])
+	assert.Equal(t, "nlb", service.Annotations["service.beta.kubernetes.io/aws-load-balancer-type"])
+	assert.Equal(t, "true", service.Labels[ExternalAccessLabel])
+	assert.Equal(t, "external-kafka-bootstrap", krp.NewKafkaBootstrapExternalServiceForCR().Name)
+
+	envs := krp.NewKafkaBrokerDeploymentForCR(2, "", true, "").Spec.Template.Spec.Containers[0].Env
+	assert.Equal(t, "10.0.0.2", getEnvValue(envs, "EXTERNAL_HOST_NAME"))
+	assert.Equal(t, "9094", getEnvValue(envs, "EXTERNAL_PORT"))
+	envs = krp.NewKafkaBrokerDeploymentForCR(1, "", true, "").Spec.Template.Spec.Containers[0].Env
+	assert.Equal(t, "", getEnvValue(envs, "EXTERNAL_HOST_NAME"))
+}
+
+func TestKafkaResourceProvider_ExternalAccessIngress(t *testing.T) {
+	krp := NewKafkaResourceProvider(newTestKafkaCR(kafkaservice.KafkaSpec{
+		ExternalAccess: kafkaservice.ExternalAccess{Type: "ingress", Host: "kafka.example.com", IngressClassName: "nginx"},
+	}), logr.Discard())
+	assert.Equal(t, corev1.ServiceTypeClusterIP, krp.NewKafkaBrokerExternalServiceForCR(1).Spec.Type)
+
+	ingress := krp.NewKafkaBrokerIngressForCR(1)
+	assert.Equal(t, "nginx", *ingress.Spec.IngressClassName)
+	assert.Equal(t, "true", ingress.Annotations["nginx.ingress.kubernetes.io/ssl-passthrough"])
+	assert.Equal(t, "broker-1.kafka.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "external-kafka-1", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+	assert.Equal(t, "bootstrap.kafka.example.com", krp.NewKafkaBootstrapIngressForCR().Spec.Rules[0].Host)
+}
+
+func TestKafkaResourceProvider_Listeners(t *testing.T) {
+	krp := NewKafkaResourceProvider(newTestKafkaCR(kafkaservice.KafkaSpec{
+		Storage: kafkaservice.Storage{Size: "10Gi"},
+		Ssl:     kafkaservice.Ssl{Enabled: true},
+		Listeners: []kafkaservice.Listener{
+			{Name: "internal", Port: 9092, TLS: true},
+			{Name: "mtls", Port: 9098, TLS: true, Authentication: "mtls"},
+			{Name: "oauth", Port: 9099, Authentication: "oauth"},
+		},
+	}), logr.Discard())
+	envs := krp.NewKafkaBrokerDeploymentForCR(1, "", true, "").Spec.Template.Spec.Containers[0].Env
+	assert.Equal(t, "INTERNAL://0.0.0.0:9092,MTLS://0.0.0.0:9098,OAUTH://0.0.0.0:9099", getEnvValue(envs, "CLIENT_LISTENERS"))
+	assert.Equal(t, "INTERNAL://kafka-1.kafka-service:9092,MTLS://kafka-1.kafka-service:9098,OAUTH://kafka-1.kafka-service:9099",
+		getEnvValue(envs, "CLIENT_ADVERTISED_LISTENERS"))
+	assert.Equal(t, "INTERNAL:SASL_SSL,MTLS:SSL,OAUTH:SASL_PLAINTEXT", getEnvValue(envs, "CLIENT_LISTENER_SECURITY_PROTOCOL_MAP"))
+	assert.Equal(t, "SCRAM-SHA-512", getEnvValue(envs, "CONF_KAFKA_LISTENER_NAME_internal_SASL_ENABLED_MECHANISMS"))
+	assert.Equal(t, "none", getEnvValue(envs, "CONF_KAFKA_LISTENER_NAME_internal_SSL_CLIENT_AUTH"))
+	assert.Equal(t, "required", getEnvValue(envs, "CONF_KAFKA_LISTENER_NAME_mtls_SSL_CLIENT_AUTH"))
+	assert.Equal(t, "", getEnvValue(envs, "CONF_KAFKA_LISTENER_NAME_mtls_SASL_ENABLED_MECHANISMS"))
+	assert.Equal(t, "OAUTHBEARER", getEnvValue(envs, "CONF_KAFKA_LISTENER_NAME_oauth_SASL_ENABLED_MECHANISMS"))
+
+	var containerPorts []int32
+	for _, port := range krp.NewKafkaBrokerDeploymentForCR(1, "", true, "").Spec.Template.Spec.Containers[0].Ports {
+		containerPorts = append(containerPorts, port.ContainerPort)
+	}
+	assert.Contains(t, containerPorts, int32(9098))
+	assert.Contains(t, containerPorts, int32(9099))
+
+	var servicePorts []string
+	for _, port := range krp.NewKafkaClientServiceForCR().Spec.Ports {
+		servicePorts = append(servicePorts, fmt.Sprintf("%s:%d", port.Name, port.Port))
+	}
+	assert.Contains(t, servicePorts, "kafka-client:9092")
+	assert.Contains(t, servicePorts, "mtls:9098")
+	assert.Contains(t, servicePorts, "oauth:9099")
+	assert.NotContains(t, servicePorts, "nonencrypted-kafka-client:9095")
+
+	krp = NewKafkaResourceProvider(newTestKafkaCR(kafkaservice.KafkaSpec{Storage: kafkaservice.Storage{Size: "10Gi"}}), logr.Discard())
+	envs = krp.NewKafkaBrokerDeploymentForCR(1, "", true, "").Spec.Template.Spec.Containers[0].Env
+	assert.Equal(t, "", getEnvValue(envs, "CLIENT_LISTENERS"))
+	assert.Equal(t, 3, len(krp.getClientServicePorts()))
+}
+
+func TestKafkaResourceProvider_CertManagerCertificates(t *testing.T) {
+	cr := newTestKafkaCR(kafkaservice.KafkaSpec{
+		Storage: kafkaservice.Storage{Size: "10Gi"},
+		Ssl: kafkaservice.Ssl{
+			Enabled: true,
+			CertManager: kafkaservice.CertManager{
+				Enabled:     true,
+				IssuerRef:   kafkaservice.CertManagerIssuerRef{Name: "kafka-issuer"},
+				Duration:    "8760h",
+				RenewBefore: "720h",
+			},
+			SubjectAlternativeName: kafkaservice.SubjectAlternativeName{AdditionalDnsNames: []string{"kafka.example.com"}},
+		},
+		ExternalHostNames: []string{"10.0.0.1", "kafka-2.example.com", "10.0.0.3"},
+	})
+	krp := NewKafkaResourceProvider(cr, logr.Discard())
+
+	certificate := krp.NewKafkaBrokerCertificateForCR(2)
+	assert.Equal(t, "kafka-2-tls-certificate", certificate.Name)
+	assert.Equal(t, "kafka-2-tls-cert", certificate.Spec.SecretName)
+	assert.Equal(t, "kafka-issuer", certificate.Spec.IssuerRef.Name)
+	assert.Equal(t, "Issuer", certificate.Spec.IssuerRef.Kind)
+	assert.Equal(t, "cert-manager.io", certificate.Spec.IssuerRef.Group)
+	assert.Equal(t, "8760h0m0s", certificate.Spec.Duration.Duration.String())
+	assert.Equal(t, "720h0m0s", certificate.Spec.RenewBefore.Duration.String())
+	assert.Contains(t, certificate.Spec.DNSNames, "kafka-2.kafka-service.svc.cluster.local")
+	assert.Contains(t, certificate.Spec.DNSNames, "kafka-2.kafka-broker.kafka-service")
+	assert.Contains(t, certificate.Spec.DNSNames, "kafka.kafka-service")
+	assert.Contains(t, certificate.Spec.DNSNames, "kafka-2.example.com")
+	assert.Contains(t, certificate.Spec.DNSNames, "kafka.example.com")
+	assert.Equal(t, []string{"127.0.0.1"}, certificate.Spec.IPAddresses)
+	assert.Equal(t, "true", certificate.Spec.SecretTemplate.Labels[CertificateLabel])
+	assert.Equal(t, []string{"127.0.0.1", "10.0.0.3"}, krp.NewKafkaBrokerCertificateForCR(3).Spec.IPAddresses)
+	assert.Equal(t, "kafka-controller-1-tls-cert", krp.NewKafkaQuorumControllerCertificateForCR(1).Spec.SecretName)
+
+	for _, volume := range krp.NewKafkaBrokerDeploymentForCR(2, "", true, "").Spec.Template.Spec.Volumes {
+		if volume.Name == "ssl-certs" {
+			assert.Equal(t, "kafka-2-tls-cert", volume.Secret.SecretName)
+		}
+	}
+
+	cr.Spec.Ssl.CertManager.Enabled = false
+	cr.Spec.Ssl.SecretName = "kafka-tls-secret"
+	for _, volume := range krp.NewKafkaBrokerDeploymentForCR(2, "", true, "").Spec.Template.Spec.Volumes {
+		if volume.Name == "ssl-certs" {
+			assert.Equal(t, "kafka-tls-secret", volume.Secret.SecretName)
+		}
+	}
+}
+
+func TestKafkaResourceProvider_Config(t *testing.T) {
+	krp := NewKafkaResourceProvider(newTestKafkaCR(kafkaservice.KafkaSpec{
+		Storage: kafkaservice.Storage{Size: "10Gi"},
+		Config: map[string]string{
+			"log.retention.ms":                  "86400000",
+			"leader.replication.throttled.rate": "1048576",
+			"auto.create.topics.enable":         "false",
+			"log.retention.hours":               "24",
+		},
+	}), logr.Discard())
+	assert.Equal(t, []string{"leader.replication.throttled.rate", "log.retention.ms"}, krp.GetDynamicConfigs())
+	assert.Equal(t, []string{"auto.create.topics.enable", "log.retention.hours"}, krp.GetStaticConfigs())
+
+	envs := krp.NewKafkaBrokerDeploymentForCR(1, "", true, "").Spec.Template.Spec.Containers[0].Env
+	assert.Equal(t, "false", getEnvValue(envs, "CONF_KAFKA_AUTO_CREATE_TOPICS_ENABLE"))
+	assert.Equal(t, "24", getEnvValue(envs, "CONF_KAFKA_LOG_RETENTION_HOURS"))
+	// dynamic properties are not passed to deployment, so their changes do not restart brokers
+	assert.Equal(t, "", getEnvValue(envs, "CONF_KAFKA_LOG_RETENTION_MS"))
+	assert.Equal(t, "", getEnvValue(envs, "CONF_KAFKA_LEADER_REPLICATION_THROTTLED_RATE"))
+
+	assert.Equal(t, PerBrokerConfigScope, GetConfigScope("leader.replication.throttled.rate"))
+	assert.Equal(t, ClusterWideConfigScope, GetConfigScope("log.retention.ms"))
+	assert.Equal(t, "", GetConfigScope("log.retention.hours"))
+	assert.True(t, IsManagedConfig("listeners"))
+	assert.True(t, IsManagedConfig("ssl.keystore.location"))
+	assert.False(t, IsManagedConfig("auto.create.topics.enable"))
+}
+
+func TestKafkaResourceProvider_PodDisruptionBudgets(t *testing.T) {
+	minAvailable := intstr.FromString("50%")
+	cr := newTestKafkaCR(kafkaservice.KafkaSpec{
+		MigrationController: kafkaservice.MigrationController{
+			PodDisruptionBudget: kafkaservice.PodDisruptionBudget{MinAvailable: &minAvailable},
+		},
+	})
+	krp := NewKafkaResourceProvider(cr, logr.Discard())
+
+	brokerPdb := krp.NewKafkaBrokerPodDisruptionBudgetForCR()
+	assert.Equal(t, "kafka-broker-pdb", brokerPdb.Name)
+	assert.Equal(t, intstr.FromInt(1), *brokerPdb.Spec.MaxUnavailable)
+	assert.Nil(t, brokerPdb.Spec.MinAvailable)
+	// selector must match pods of all broker deployments
+	assert.Equal(t, krp.GetSelectorLabels(), brokerPdb.Spec.Selector.MatchLabels)
+	assert.NotContains(t, brokerPdb.Spec.Selector.MatchLabels, "name")
+
+	maxUnavailable := intstr.FromInt(2)
+	cr.Spec.PodDisruptionBudget.MaxUnavailable = &maxUnavailable
+	brokerPdb = NewKafkaResourceProvider(cr, logr.Discard()).NewKafkaBrokerPodDisruptionBudgetForCR()
+	assert.Equal(t, maxUnavailable, *brokerPdb.Spec.MaxUnavailable)
+	assert.Nil(t, brokerPdb.Spec.MinAvailable)
+
+	controllerPdb := krp.NewKafkaQuorumControllerPodDisruptionBudgetForCR()
+	assert.Equal(t, krp.GetQuorumControllerSelectorLabels(), controllerPdb.Spec.Selector.MatchLabels)
+
+	kraftControllerPdb := krp.NewKafkaKraftControllerPodDisruptionBudgetForCR()
+	assert.Nil(t, kraftControllerPdb.Spec.MaxUnavailable)
+	assert.Equal(t, minAvailable, *kraftControllerPdb.Spec.MinAvailable)
+
+	assert.True(t, IsPodDisruptionBudgetEnabled(nil))
+	disabled := false
+	assert.False(t, IsPodDisruptionBudgetEnabled(&disabled))
+}
+
+func TestKafkaResourceProvider_TopologySpreadConstraints(t *testing.T) {
+	constraints := []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       "topology.kubernetes.io/zone",
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"component": "kafka"}},
+	}}
+	krp := NewKafkaResourceProvider(newTestKafkaCR(kafkaservice.KafkaSpec{TopologySpreadConstraints: constraints}), logr.Discard())
+
+	deployment := krp.NewKafkaBrokerDeploymentForCR(1, "", false, "")
+	assert.Equal(t, constraints, deployment.Spec.Template.Spec.TopologySpreadConstraints)
+}
+
+func TestKafkaResourceProvider_NodePools(t *testing.T) {
+	cr := newTestKafkaCR(kafkaservice.KafkaSpec{
+		Storage: kafkaservice.Storage{ClassName: []string{"standard"}, Size: "10Gi"},
+		NodePools: []kafkaservice.NodePool{
+			{Name: "general", Replicas: 2, FirstBrokerId: 1, Racks: []string{"zone-a", "zone-b"}},
+			{
+				Name:          "large",
+				Replicas:      1,
+				FirstBrokerId: 100,
+				HeapSize:      2048,
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+				},
+				Storage: &kafkaservice.Storage{ClassName: []string{"fast"}, Size: "100Gi"},
+			},
+		},
+		ExternalHostNames: []string{"host-1", "host-2", "host-3"},
+	})
+	krp := NewKafkaResourceProvider(cr, logr.Discard())
+	assert.Equal(t, []int{1, 2, 100}, krp.GetBrokerIds())
+	assert.Equal(t, map[int32]string{1: "general", 2: "general", 100: "large"}, krp.GetBrokerPools())
+	assert.Equal(t, []kafkaservice.NodePoolStatus{
+		{Name: "general", BrokerIds: []int{1, 2}},
+		{Name: "large", BrokerIds: []int{100}},
+	}, krp.GetNodePoolsStatus())
+	assert.Equal(t, "zone-b", krp.GetBrokerConfiguredRack(2))
+	assert.Equal(t, "", krp.GetBrokerConfiguredRack(100))
+	assert.Equal(t, map[int32]int64{1: 10 * 1024 * 1024 * 1024, 2: 10 * 1024 * 1024 * 1024, 100: 100 * 1024 * 1024 * 1024},
+		krp.GetBrokersStorageCapacity())
+
+	claim := krp.NewKafkaPersistentVolumeClaimForCR(100)
+	assert.Equal(t, "pvc-kafka-100", claim.Name)
+	assert.Equal(t, "fast", *claim.Spec.StorageClassName)
+	assert.Equal(t, "100Gi", claim.Spec.Resources.Requests.Storage().String())
+	assert.Equal(t, "standard", *krp.NewKafkaPersistentVolumeClaimForCR(2).Spec.StorageClassName)
+
+	deployment := krp.NewKafkaBrokerDeploymentForCR(100, "", true, "")
+	assert.Equal(t, "large", deployment.Spec.Template.Labels[NodePoolLabel])
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "-Xms2048m -Xmx2048m", getEnvValue(container.Env, "HEAP_OPTS"))
+	assert.Equal(t, "4Gi", container.Resources.Limits.Memory().String())
+	assert.Equal(t, "-Xms512m -Xmx512m", getEnvValue(krp.NewKafkaBrokerDeploymentForCR(1, "", true, "").Spec.Template.Spec.Containers[0].Env, "HEAP_OPTS"))
+
+	host, port := krp.getBrokerExternalAddress(100)
+	assert.Equal(t, "host-3", host)
+	assert.Equal(t, "9094", port)
+}
+
+func TestKafkaResourceProvider_BrokerIdsWithoutNodePools(t *testing.T) {
+	krp := NewKafkaResourceProvider(newTestKafkaCR(kafkaservice.KafkaSpec{}), logr.Discard())
+	assert.False(t, krp.IsNodePoolsEnabled())
+	assert.Equal(t, []int{1, 2, 3}, krp.GetBrokerIds())
+	assert.Nil(t, krp.GetBrokerPools())
+	assert.Equal(t, "", krp.GetBrokerPoolName(2))
+}
+
+func TestKafkaResourceProvider_StatefulSet(t *testing.T) {
+	krp := NewKafkaResourceProvider(newTestKafkaCR(kafkaservice.KafkaSpec{
+		PodManagement: StatefulSetPodManagement,
+		Storage:       kafkaservice.Storage{ClassName: []string{"standard"}, Size: "10Gi"},
+	}), logr.Discard())
+	assert.True(t, krp.IsStatefulSetEnabled())
+	assert.Equal(t, "kafka-2", krp.GetBrokerPodSelectorLabels(2)["statefulset.kubernetes.io/pod-name"])
+
+	statefulSet := krp.NewKafkaBrokerStatefulSetForCR(3, false, "")
+	assert.Equal(t, "kafka", statefulSet.Name)
+	assert.Equal(t, int32(3), *statefulSet.Spec.Replicas)
+	assert.Equal(t, "kafka-broker", statefulSet.Spec.ServiceName)
+	assert.Equal(t, "OnDelete", string(statefulSet.Spec.UpdateStrategy.Type))
+
+	envs := statefulSet.Spec.Template.Spec.Containers[0].Env
+	assert.Equal(t, "POD_NAME", envs[0].Name)
+	assert.Equal(t, "$(POD_NAME).kafka-service", getEnvValue(envs, "INTERNAL_HOST_NAME"))
+	for _, env := range envs {
+		if env.Name == "BROKER_ID" {
+			assert.Equal(t, "metadata.labels['apps.kubernetes.io/pod-index']", env.ValueFrom.FieldRef.FieldPath)
+		}
+	}
+
+	assert.Len(t, statefulSet.Spec.VolumeClaimTemplates, 1)
+	claim := statefulSet.Spec.VolumeClaimTemplates[0]
+	assert.Equal(t, "pvc", claim.Name)
+	assert.Equal(t, "standard", *claim.Spec.StorageClassName)
+	assert.Equal(t, "10Gi", claim.Spec.Resources.Requests.Storage().String())
+	for _, volume := range statefulSet.Spec.Template.Spec.Volumes {
+		assert.NotEqual(t, "data", volume.Name)
+	}
+	mountNames := map[string]bool{}
+	for _, volumeMount := range statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts {
+		mountNames[volumeMount.Name] = true
+	}
+	assert.True(t, mountNames["pvc"])
+
+	deploymentKrp := NewKafkaResourceProvider(newTestKafkaCR(kafkaservice.KafkaSpec{}), logr.Discard())
+	assert.False(t, deploymentKrp.IsStatefulSetEnabled())
+	assert.Equal(t, "kafka-2", deploymentKrp.GetBrokerPodSelectorLabels(2)["name"])
+}
@@ -0,0 +1,145 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	StatefulSetPodManagement = "statefulset"
+	// StatefulSetStartOrdinal makes ordinals of broker pods equal to broker IDs
+	StatefulSetStartOrdinal = 1
+	// statefulSetPodIndexLabel is set by Kubernetes to ordinal of stateful set pod
+	statefulSetPodIndexLabel = "apps.kubernetes.io/pod-index"
+	// brokerVolumeClaimTemplateName produces the same claim names as persistentVolumeClaimPattern
+	brokerVolumeClaimTemplateName = "pvc"
+	podNameReference              = "$(POD_NAME)"
+)
+
+// IsStatefulSetEnabled returns true if brokers are managed with one stateful set instead of deployment per broker
+func (krp KafkaResourceProvider) IsStatefulSetEnabled() bool {
+	return krp.spec.PodManagement == StatefulSetPodManagement
+}
+
+// GetBrokerStatefulSetName returns name of stateful set with broker pods
+func (krp KafkaResourceProvider) GetBrokerStatefulSetName() string {
+	return krp.cr.Name
+}
+
+// GetBrokerPodSelectorLabels returns labels which select pod of the broker in both deployment and stateful set modes
+func (krp KafkaResourceProvider) GetBrokerPodSelectorLabels(brokerId int) map[string]string {
+	labels := krp.GetSelectorLabels()
+	podName := fmt.Sprintf("%s-%d", krp.cr.Name, brokerId)
+	if krp.IsStatefulSetEnabled() {
+		labels[appsv1.StatefulSetPodNameLabel] = podName
+	} else {
+		labels["name"] = podName
+	}
+	return labels
+}
+
+// NewKafkaBrokerStatefulSetForCR returns stateful set with given number of brokers. Ordinals of pods start from 1,
+// so pod names and persistent volume claims created from template are the same as for broker deployments.
+// Pods are restarted by operator one by one, that is why the update strategy is OnDelete.
+func (krp KafkaResourceProvider) NewKafkaBrokerStatefulSetForCR(replicas int, kraftEnabled bool, zkClusterID string) *appsv1.StatefulSet {
+	name := krp.GetBrokerStatefulSetName()
+	kafkaLabels := krp.GetKafkaLabels()
+	kafkaLabels["app.kubernetes.io/instance"] = fmt.Sprintf("%s-%s", name, krp.cr.Namespace)
+	selectorLabels := krp.GetSelectorLabels()
+	selectorLabels["name"] = name
+	statefulSetReplicas := int32(replicas)
+
+	template := krp.newKafkaBrokerPodTemplate(StatefulSetStartOrdinal, podNameReference, "", kraftEnabled, zkClusterID)
+	template.Labels = krp.GetKafkaCustomLabels(kafkaLabels)
+	container := &template.Spec.Containers[0]
+	for i := range container.Env {
+		if container.Env[i].Name == "BROKER_ID" {
+			container.Env[i] = corev1.EnvVar{Name: "BROKER_ID", ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: fmt.Sprintf("metadata.labels['%s']", statefulSetPodIndexLabel)},
+			}}
+		}
+	}
+	// POD_NAME must precede variables which refer to it
+	container.Env = append([]corev1.EnvVar{{Name: "POD_NAME", ValueFrom: &corev1.EnvVarSource{
+		FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+	}}}, container.Env...)
+
+	var volumeClaimTemplates []corev1.PersistentVolumeClaim
+	if claim := krp.newBrokerVolumeClaimTemplate(); claim != nil {
+		volumeClaimTemplates = append(volumeClaimTemplates, *claim)
+		var volumes []corev1.Volume
+		for _, volume := range template.Spec.Volumes {
+			if volume.Name != "data" {
+				volumes = append(volumes, volume)
+			}
+		}
+		template.Spec.Volumes = volumes
+		for i := range container.VolumeMounts {
+			if container.VolumeMounts[i].Name == "data" {
+				container.VolumeMounts[i].Name = brokerVolumeClaimTemplateName
+			}
+		}
+	}
+
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: krp.cr.Namespace,
+			Labels:    kafkaLabels,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:             &statefulSetReplicas,
+			Selector:             &metav1.LabelSelector{MatchLabels: selectorLabels},
+			Template:             template,
+			VolumeClaimTemplates: volumeClaimTemplates,
+			ServiceName:          krp.getBrokerDomainName(),
+			PodManagementPolicy:  appsv1.ParallelPodManagement,
+			UpdateStrategy:       appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType},
+		},
+	}
+}
+
+// newBrokerVolumeClaimTemplate returns template of broker data volume claim or nil if brokers use ephemeral storage
+func (krp KafkaResourceProvider) newBrokerVolumeClaimTemplate() *corev1.PersistentVolumeClaim {
+	storage := krp.spec.Storage
+	if len(storage.ClassName) == 0 || storage.ClassName[0] == defaultVolumeName {
+		return nil
+	}
+	labels := krp.GetKafkaLabels()
+	if krp.cr.Spec.Kraft.Enabled {
+		labels["kraft"] = "enabled"
+	}
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   brokerVolumeClaimTemplateName,
+			Labels: labels,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: &storage.ClassName[0],
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse(storage.Size),
+				},
+			},
+		},
+	}
+}
@@ -18,7 +18,9 @@ import (
 	"fmt"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"strings"
 )
 
@@ -28,6 +30,33 @@ func NewServiceAccount(serviceAccountName string, namespace string, labels map[s
 	}
 }
 
+// IsPodDisruptionBudgetEnabled checks if PodDisruptionBudget must be created, it is enabled by default
+func IsPodDisruptionBudgetEnabled(enabled *bool) bool {
+	return enabled == nil || *enabled
+}
+
+// newPodDisruptionBudget returns PodDisruptionBudget for pods with specified selector labels,
+// one unavailable pod is allowed if neither maxUnavailable nor minAvailable is specified
+func newPodDisruptionBudget(name string, namespace string, labels map[string]string, selectorLabels map[string]string,
+	maxUnavailable *intstr.IntOrString, minAvailable *intstr.IntOrString) *policyv1.PodDisruptionBudget {
+	if maxUnavailable == nil && minAvailable == nil {
+		defaultMaxUnavailable := intstr.FromInt(1)
+		maxUnavailable = &defaultMaxUnavailable
+	}
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: maxUnavailable,
+			MinAvailable:   minAvailable,
+			Selector:       &metav1.LabelSelector{MatchLabels: selectorLabels},
+		},
+	}
+}
+
 // newServiceForCR returns service with specified parameters
 func newServiceForCR(serviceName string, namespace string, labels map[string]string, selectorLabels map[string]string, ports []corev1.ServicePort) *corev1.Service {
 	return &corev1.Service{
@@ -22,6 +22,7 @@ import (
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"strconv"
 	"strings"
@@ -339,12 +340,13 @@ func (mmrp MirrorMakerResourceProvider) NewMirrorMakerDeploymentForCR(cluster ka
 							SecurityContext: getDefaultContainerSecurityContext(),
 						},
 					},
-					Hostname:           deploymentName,
-					Affinity:           mmrp.getAffinityRules(cluster),
-					Tolerations:        mmrp.spec.Tolerations,
-					PriorityClassName:  mmrp.spec.PriorityClassName,
-					SecurityContext:    &mmrp.spec.SecurityContext,
-					ServiceAccountName: mmrp.GetServiceAccountName(),
+					Hostname:                  deploymentName,
+					Affinity:                  mmrp.getAffinityRules(cluster),
+					TopologySpreadConstraints: mmrp.spec.TopologySpreadConstraints,
+					Tolerations:               mmrp.spec.Tolerations,
+					PriorityClassName:         mmrp.spec.PriorityClassName,
+					SecurityContext:           &mmrp.spec.SecurityContext,
+					ServiceAccountName:        mmrp.GetServiceAccountName(),
 				},
 			},
 		},
@@ -511,3 +513,10 @@ func (mmrp MirrorMakerResourceProvider) getKafkaClusterCredentialsEnvs(upperClus
 		},
 	}
 }
+
+// NewMirrorMakerPodDisruptionBudget returns PodDisruptionBudget for pods of all Kafka Mirror Maker deployments
+func (mmrp MirrorMakerResourceProvider) NewMirrorMakerPodDisruptionBudget() *policyv1.PodDisruptionBudget {
+	pdb := mmrp.spec.PodDisruptionBudget
+	return newPodDisruptionBudget(fmt.Sprintf("%s-pdb", mmrp.serviceName), mmrp.cr.Namespace,
+		mmrp.GetMirrorMakerLabels(), mmrp.GetMirrorMakerSelectorLabels(), pdb.MaxUnavailable, pdb.MinAvailable)
+}
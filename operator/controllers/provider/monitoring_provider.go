@@ -21,6 +21,7 @@ import (
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"strconv"
 )
@@ -134,15 +135,16 @@ func (mrp MonitoringResourceProvider) NewMonitoringDeployment(cmVersion string)
 					Labels: monitoringCustomLabels,
 				},
 				Spec: corev1.PodSpec{
-					Volumes:            mrp.getMonitoringVolumes(),
-					InitContainers:     mrp.getInitContainers(),
-					Containers:         mrp.getMonitoringContainers(cmVersion),
-					SecurityContext:    &mrp.spec.SecurityContext,
-					ServiceAccountName: mrp.GetServiceAccountName(),
-					Hostname:           mrp.serviceName,
-					Affinity:           &mrp.spec.Affinity,
-					Tolerations:        mrp.spec.Tolerations,
-					PriorityClassName:  mrp.spec.PriorityClassName,
+					Volumes:                   mrp.getMonitoringVolumes(),
+					InitContainers:            mrp.getInitContainers(),
+					Containers:                mrp.getMonitoringContainers(cmVersion),
+					SecurityContext:           &mrp.spec.SecurityContext,
+					ServiceAccountName:        mrp.GetServiceAccountName(),
+					Hostname:                  mrp.serviceName,
+					Affinity:                  &mrp.spec.Affinity,
+					TopologySpreadConstraints: mrp.spec.TopologySpreadConstraints,
+					Tolerations:               mrp.spec.Tolerations,
+					PriorityClassName:         mrp.spec.PriorityClassName,
 				},
 			},
 			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
@@ -371,3 +373,10 @@ func (mrp MonitoringResourceProvider) getMonitoringCredentialsEnvs() []corev1.En
 		},
 	}
 }
+
+// NewMonitoringPodDisruptionBudget returns PodDisruptionBudget for Kafka monitoring pods
+func (mrp MonitoringResourceProvider) NewMonitoringPodDisruptionBudget() *policyv1.PodDisruptionBudget {
+	pdb := mrp.spec.PodDisruptionBudget
+	return newPodDisruptionBudget(fmt.Sprintf("%s-pdb", mrp.serviceName), mrp.cr.Namespace,
+		mrp.GetMonitoringLabels(), mrp.GetMonitoringSelectorLabels(), pdb.MaxUnavailable, pdb.MinAvailable)
+}
@@ -0,0 +1,102 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"fmt"
+)
+
+// RackAuditResult describes partitions whose replicas are not spread across racks
+type RackAuditResult struct {
+	// ViolatingPartitions are partitions in format "topic-partition" whose replicas share a rack
+	// while other racks of the node pool have no replicas of the partition
+	ViolatingPartitions []string
+	// RepairPlan moves replicas of violating partitions to other racks, it is computed only if repair is requested
+	RepairPlan *ReassignmentPlan
+}
+
+// AuditRackAwareness checks placement of replicas of all topics across racks of brokers. If repair is true,
+// the result contains reassignment plan which moves the minimal number of replicas to racks without replicas
+// of the partition. Brokers must be checked with CheckAllBrokersAreUp before audit, so their racks are known.
+func (kc *KafkaClient) AuditRackAwareness(repair bool) (*RackAuditResult, error) {
+	topics, err := kc.adminClient.ListTopics()
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := kc.describeCluster(topicNames(topics))
+	if err != nil {
+		return nil, err
+	}
+	result := &RackAuditResult{}
+	for _, partition := range rackViolations(snapshot.brokers, snapshot.partitions) {
+		result.ViolatingPartitions = append(result.ViolatingPartitions, fmt.Sprintf("%s-%d", partition.topic, partition.partition))
+	}
+	log.Info(fmt.Sprintf("Replicas of %d partitions are not spread across racks", len(result.ViolatingPartitions)))
+	if !repair || len(result.ViolatingPartitions) == 0 {
+		return result, nil
+	}
+	partitions := snapshot.copyPartitions()
+	spreadReplicasAcrossRacks(snapshot.brokers, partitions)
+	assignments := changedAssignments(snapshot.partitions, partitions)
+	result.RepairPlan = &ReassignmentPlan{Topics: newTopicReassignments(topics, snapshot, assignments)}
+	log.Info(fmt.Sprintf("Replicas of %d partitions are planned to be moved to other racks", len(assignments)))
+	return result, nil
+}
+
+// rackViolations returns partitions which have several replicas in the same rack while the node pool of these
+// replicas has a rack without replicas of the partition. Brokers without rack and unknown brokers are ignored.
+func rackViolations(brokers []balancingBroker, partitions []*partitionReplicas) []*partitionReplicas {
+	brokersById := make(map[int32]balancingBroker, len(brokers))
+	poolRacks := map[string]map[string]bool{}
+	for _, broker := range brokers {
+		brokersById[broker.id] = broker
+		if broker.rack == "" {
+			continue
+		}
+		if poolRacks[broker.pool] == nil {
+			poolRacks[broker.pool] = map[string]bool{}
+		}
+		poolRacks[broker.pool][broker.rack] = true
+	}
+	var violations []*partitionReplicas
+	for _, partition := range partitions {
+		replicasByRack := map[string]int{}
+		for _, replica := range partition.replicas {
+			if broker, found := brokersById[replica]; found && broker.rack != "" {
+				replicasByRack[broker.rack]++
+			}
+		}
+		if hasFreeRackForSharedReplica(partition, brokersById, poolRacks, replicasByRack) {
+			violations = append(violations, partition)
+		}
+	}
+	return violations
+}
+
+func hasFreeRackForSharedReplica(partition *partitionReplicas, brokersById map[int32]balancingBroker,
+	poolRacks map[string]map[string]bool, replicasByRack map[string]int) bool {
+	for _, replica := range partition.replicas {
+		broker, found := brokersById[replica]
+		if !found || broker.rack == "" || replicasByRack[broker.rack] < 2 {
+			continue
+		}
+		for rack := range poolRacks[broker.pool] {
+			if replicasByRack[rack] == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRackAuditAdmin keeps assignment of topics with empty log directories and dynamic configs
+type fakeRackAuditAdmin struct {
+	*fakeConfigsAdmin
+	assignments map[string][][]int32
+}
+
+func (a *fakeRackAuditAdmin) ListTopics() (map[string]sarama.TopicDetail, error) {
+	topics := map[string]sarama.TopicDetail{}
+	for topic, assignment := range a.assignments {
+		replicaAssignment := map[int32][]int32{}
+		for partition, replicas := range assignment {
+			replicaAssignment[int32(partition)] = replicas
+		}
+		topics[topic] = sarama.TopicDetail{
+			NumPartitions:     int32(len(assignment)),
+			ReplicationFactor: int16(len(assignment[0])),
+			ReplicaAssignment: replicaAssignment,
+		}
+	}
+	return topics, nil
+}
+
+func (a *fakeRackAuditAdmin) DescribeTopics(topics []string) ([]*sarama.TopicMetadata, error) {
+	var metadata []*sarama.TopicMetadata
+	for _, topic := range topics {
+		topicMetadata := &sarama.TopicMetadata{Name: topic}
+		for partition, replicas := range a.assignments[topic] {
+			topicMetadata.Partitions = append(topicMetadata.Partitions,
+				&sarama.PartitionMetadata{ID: int32(partition), Leader: replicas[0], Replicas: replicas})
+		}
+		metadata = append(metadata, topicMetadata)
+	}
+	return metadata, nil
+}
+
+func (a *fakeRackAuditAdmin) DescribeLogDirs(brokerIds []int32) (map[int32][]sarama.DescribeLogDirsResponseDirMetadata, error) {
+	return map[int32][]sarama.DescribeLogDirsResponseDirMetadata{}, nil
+}
+
+func TestRackViolations(t *testing.T) {
+	brokers := []balancingBroker{
+		{id: 1, rack: "a"}, {id: 2, rack: "a"}, {id: 3, rack: "b"},
+		{id: 4, rack: "c", pool: "large"}, {id: 5, rack: "c", pool: "large"},
+		{id: 6},
+	}
+	partitions := []*partitionReplicas{
+		// rack b has no replicas of the partition
+		{topic: "test", partition: 0, replicas: []int32{1, 2}},
+		{topic: "test", partition: 1, replicas: []int32{1, 3}},
+		// the pool has a single rack
+		{topic: "test", partition: 2, replicas: []int32{4, 5}},
+		// all racks of the pool are used
+		{topic: "test", partition: 3, replicas: []int32{1, 2, 3}},
+		// brokers without rack and unknown brokers are ignored
+		{topic: "test", partition: 4, replicas: []int32{6, 7}},
+		{topic: "test", partition: 5, replicas: []int32{2, 1}},
+	}
+	var violations []int32
+	for _, partition := range rackViolations(brokers, partitions) {
+		violations = append(violations, partition.partition)
+	}
+	assert.Equal(t, []int32{0, 5}, violations)
+}
+
+func TestAuditRackAwareness(t *testing.T) {
+	admin := &fakeRackAuditAdmin{fakeConfigsAdmin: newFakeConfigsAdmin(), assignments: map[string][][]int32{
+		"orders":   {{1, 2}, {1, 3}, {2, 1}},
+		"payments": {{3, 4}},
+	}}
+	kc := &KafkaClient{adminClient: admin, brokerIds: []int32{1, 2, 3, 4}, brokerPools: map[int32]string{}}
+	kc.setBrokerRacks(map[int32]string{1: "a", 2: "a", 3: "b", 4: "b"})
+
+	result, err := kc.AuditRackAwareness(false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"orders-0", "orders-2", "payments-0"}, result.ViolatingPartitions)
+	assert.Nil(t, result.RepairPlan)
+
+	result, err = kc.AuditRackAwareness(true)
+	assert.NoError(t, err)
+	assert.Len(t, result.RepairPlan.Topics, 2)
+	brokersById := map[int32]balancingBroker{}
+	for _, broker := range kc.balancingBrokers() {
+		brokersById[broker.id] = broker
+	}
+	for _, topic := range result.RepairPlan.Topics {
+		for _, partition := range topic.Partitions {
+			current := topic.CurrentReplicas[partition.Partition]
+			target := topic.Replicas[partition.Partition]
+			assert.Equal(t, 2, countRacks(target, brokersById), "%s-%d is moved to %v", topic.Topic, partition.Partition, target)
+			// a single replica is moved to another rack
+			assert.Len(t, missingReplicas(target, current), 1)
+		}
+	}
+	// partition which is already spread across racks is not moved
+	assert.Equal(t, []int32{0, 2}, result.RepairPlan.Topics[0].partitionsWithStatus(TopicReassignmentPending))
+}
@@ -0,0 +1,412 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+const (
+	TopicReassignmentPending    = "Pending"
+	TopicReassignmentInProgress = "In Progress"
+	TopicReassignmentFinished   = "Finished"
+	TopicReassignmentFailed     = "Failed"
+	TopicReassignmentSkipped    = "Skipped"
+	TopicReassignmentCancelled  = "Cancelled"
+	// PartitionReassignmentTimedOut is status of partition which is not moved in time and is still being moved,
+	// it is reported and kept within limits of concurrent moves until Kafka finishes or the reassignment is cancelled
+	PartitionReassignmentTimedOut = "Timed Out"
+)
+
+// ReassignmentPlan is a new assignment of partitions computed for all topics at once. Partitions of different topics
+// are moved in parallel within limits of concurrent moves, so the plan keeps progress of each partition to resume reassignment.
+type ReassignmentPlan struct {
+	Topics []TopicReassignment `json:"topics"`
+}
+
+// TopicReassignment is a new assignment of topic partitions with status of its reassignment
+type TopicReassignment struct {
+	Topic           string    `json:"topic"`
+	CurrentReplicas [][]int32 `json:"currentReplicas"`
+	Replicas        [][]int32 `json:"replicas"`
+	Status          string    `json:"status"`
+	// StartTime is the time when reassignment of the topic was started
+	StartTime *time.Time `json:"startTime,omitempty"`
+	// ThrottledBrokerIds are brokers with throttled replication rate which is removed when reassignment is finished
+	ThrottledBrokerIds []int32 `json:"throttledBrokerIds,omitempty"`
+	// Partitions are moved partitions of the topic
+	Partitions []PartitionReassignment `json:"partitions,omitempty"`
+}
+
+// PartitionReassignment is status of reassignment of moved partition
+type PartitionReassignment struct {
+	Partition int32 `json:"partition"`
+	// BytesToMove is the size of data copied to replicas added to the partition
+	BytesToMove int64      `json:"bytesToMove"`
+	Status      string     `json:"status"`
+	StartTime   *time.Time `json:"startTime,omitempty"`
+}
+
+func newTopicReassignment(topic TopicInfo, newReplicaAssignment [][]int32) (TopicReassignment, bool) {
+	currentReplicaAssignment := CopyCurrentReplicaAssignment(topic)
+	var partitions []PartitionReassignment
+	for partition := range newReplicaAssignment {
+		if !equalReplicas(currentReplicaAssignment[partition], newReplicaAssignment[partition]) {
+			partitions = append(partitions, PartitionReassignment{Partition: int32(partition), Status: TopicReassignmentPending})
+		}
+	}
+	return TopicReassignment{
+		Topic:           topic.topicName,
+		CurrentReplicas: currentReplicaAssignment,
+		Replicas:        newReplicaAssignment,
+		Status:          TopicReassignmentPending,
+		Partitions:      partitions,
+	}, len(partitions) > 0
+}
+
+// PlanPartitionsReassignment computes new assignment of partitions of all topics with configured balancing strategy.
+// Brokers must be checked with CheckAllBrokersAreUp before planning.
+func (kc *KafkaClient) PlanPartitionsReassignment() (*ReassignmentPlan, error) {
+	topics, err := kc.adminClient.ListTopics()
+	if err != nil {
+		return nil, err
+	}
+	reassignments, err := kc.planTopicReassignments(topics)
+	if err != nil {
+		return nil, err
+	}
+	log.Info(fmt.Sprintf("Partitions of %d topics are planned to be reassigned", len(reassignments)))
+	return &ReassignmentPlan{Topics: reassignments}, nil
+}
+
+// AdvanceReassignmentPlan checks partitions which are being moved and starts moving the next partitions of the plan
+// while the number of moved partitions and the size of moved data are within limits. It does not wait for reassignment
+// and returns true when all topics of the plan are processed. The plan is persisted with saveProgress before replication
+// of topic is throttled, so that throttles can be removed after operator restart.
+func (kc *KafkaClient) AdvanceReassignmentPlan(plan *ReassignmentPlan, now time.Time, saveProgress func() error) (bool, error) {
+	partitionsInFlight, bytesInFlight, err := kc.checkReassignmentPlan(plan, now, false)
+	if err != nil {
+		return false, err
+	}
+	for i := range plan.Topics {
+		topic := &plan.Topics[i]
+		if topic.Status != TopicReassignmentPending && topic.Status != TopicReassignmentInProgress {
+			continue
+		}
+		var started []int
+		for j, partition := range topic.Partitions {
+			if partition.Status != TopicReassignmentPending {
+				continue
+			}
+			// at least one partition is moved even if it exceeds the limit of data size
+			if (kc.maxConcurrentPartitionMoves > 0 && partitionsInFlight >= kc.maxConcurrentPartitionMoves) ||
+				(kc.maxBytesInFlight > 0 && partitionsInFlight > 0 && bytesInFlight+partition.BytesToMove > kc.maxBytesInFlight) {
+				break
+			}
+			started = append(started, j)
+			partitionsInFlight++
+			bytesInFlight += partition.BytesToMove
+		}
+		if len(started) > 0 {
+			if topic.Status == TopicReassignmentPending {
+				log.Info(fmt.Sprintf("%d of %d: Trying to reassign partitions for topic %s...", i+1, len(plan.Topics), topic.Topic))
+			}
+			if err = kc.startPartitionsReassignment(plan, topic, started, now, saveProgress); err != nil {
+				log.Error(err, fmt.Sprintf("Cannot reassign partitions for topic [%s]", topic.Topic))
+				topic.Status = TopicReassignmentFailed
+			}
+			if topic.Status != TopicReassignmentInProgress {
+				partitionsInFlight -= len(started)
+				for _, j := range started {
+					bytesInFlight -= topic.Partitions[j].BytesToMove
+				}
+				continue
+			}
+		}
+		if hasPartitionsWithStatus(topic, TopicReassignmentPending) {
+			// partitions are moved in order of the plan
+			break
+		}
+	}
+	for _, topic := range plan.Topics {
+		if topic.Status == TopicReassignmentPending || topic.Status == TopicReassignmentInProgress {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// PauseReassignmentPlan checks partitions which are being moved without starting the next ones.
+// It returns true when no partition of the plan is being moved, so reassignment can be resumed later.
+func (kc *KafkaClient) PauseReassignmentPlan(plan *ReassignmentPlan, now time.Time) (bool, error) {
+	partitionsInFlight, _, err := kc.checkReassignmentPlan(plan, now, true)
+	return partitionsInFlight == 0, err
+}
+
+// CancelReassignmentPlan cancels reassignment of partitions which are being moved, they are returned
+// to the current replicas by Kafka. Pending topics are left untouched.
+func (kc *KafkaClient) CancelReassignmentPlan(plan *ReassignmentPlan) error {
+	for i := range plan.Topics {
+		topic := &plan.Topics[i]
+		if topic.Status != TopicReassignmentInProgress {
+			continue
+		}
+		log.Info(fmt.Sprintf("Cancel reassignment of topic %s", topic.Topic))
+		assignment := topic.actualAssignment()
+		for j := range topic.Partitions {
+			partition := &topic.Partitions[j]
+			if partition.Status == TopicReassignmentInProgress || partition.Status == PartitionReassignmentTimedOut {
+				// reassignment of partition is cancelled by empty target replicas
+				assignment[partition.Partition] = nil
+				partition.Status = TopicReassignmentCancelled
+			}
+		}
+		err := kc.adminClient.AlterPartitionReassignments(topic.Topic, assignment)
+		// partitions could be moved after the last check
+		if err != nil && !errors.Is(err, sarama.ErrNoReassignmentInProgress) {
+			return fmt.Errorf("cannot cancel reassignment of topic [%s]: %w", topic.Topic, err)
+		}
+		if err = kc.removeTopicReplicationThrottle(plan, topic); err != nil {
+			return err
+		}
+		topic.Status = TopicReassignmentCancelled
+	}
+	return nil
+}
+
+// ReassignmentProgress counts topics of the plan by reassignment result
+type ReassignmentProgress struct {
+	Completed int
+	Failed    int
+	Remaining int
+	Cancelled []string
+	// TimedOut contains partitions in format "topic-partition" which are not moved in time and are still being moved
+	TimedOut []string
+}
+
+// Progress returns the numbers of finished, failed or skipped, and not yet finished topics, names of cancelled topics
+// and partitions which are not moved in time
+func (plan *ReassignmentPlan) Progress() ReassignmentProgress {
+	var progress ReassignmentProgress
+	for _, topic := range plan.Topics {
+		switch topic.Status {
+		case TopicReassignmentFinished:
+			progress.Completed++
+		case TopicReassignmentFailed, TopicReassignmentSkipped:
+			progress.Failed++
+		case TopicReassignmentCancelled:
+			progress.Cancelled = append(progress.Cancelled, topic.Topic)
+		default:
+			progress.Remaining++
+		}
+		for _, partition := range topic.Partitions {
+			if partition.Status == PartitionReassignmentTimedOut {
+				progress.TimedOut = append(progress.TimedOut, fmt.Sprintf("%s-%d", topic.Topic, partition.Partition))
+			}
+		}
+	}
+	return progress
+}
+
+// checkReassignmentPlan polls partitions being moved with one request per topic. Partitions which are not moved
+// in time are reported as timed out, but they are still counted as being moved and their throttles are kept until
+// Kafka finishes them. Topics are finished when all their partitions are processed. If pausing, throttles of topics
+// without moved partitions are removed. It returns the number of partitions being moved and the size of their data.
+func (kc *KafkaClient) checkReassignmentPlan(plan *ReassignmentPlan, now time.Time, pausing bool) (int, int64, error) {
+	timeout := time.Duration(kc.topicReassignmentTimeoutSeconds) * time.Second
+	partitionsInFlight := 0
+	var bytesInFlight int64
+	for i := range plan.Topics {
+		topic := &plan.Topics[i]
+		if topic.Status != TopicReassignmentInProgress {
+			continue
+		}
+		var moving map[int32]*sarama.PartitionReplicaReassignmentsStatus
+		inFlight := topic.movingPartitions()
+		if len(inFlight) > 0 {
+			reassignmentStatus, err := kc.adminClient.ListPartitionReassignments(topic.Topic, inFlight)
+			if err != nil {
+				// partitions are considered being moved until Kafka reports them finished
+				log.Error(err, fmt.Sprintf("Cannot check reassignment of topic [%s]", topic.Topic))
+				moving = nil
+			} else {
+				moving = reassignmentStatus[topic.Topic]
+				if moving == nil {
+					moving = map[int32]*sarama.PartitionReplicaReassignmentsStatus{}
+				}
+			}
+		}
+		for j := range topic.Partitions {
+			partition := &topic.Partitions[j]
+			if partition.Status != TopicReassignmentInProgress && partition.Status != PartitionReassignmentTimedOut {
+				continue
+			}
+			if _, found := moving[partition.Partition]; moving != nil && !found {
+				partition.Status = TopicReassignmentFinished
+				continue
+			}
+			if partition.Status == TopicReassignmentInProgress && partition.StartTime != nil && now.Sub(*partition.StartTime) >= timeout {
+				log.Info(fmt.Sprintf("Reassignment of partition %d of topic %s is not finished in %d seconds, it is still awaited",
+					partition.Partition, topic.Topic, kc.topicReassignmentTimeoutSeconds))
+				partition.Status = PartitionReassignmentTimedOut
+			}
+			partitionsInFlight++
+			bytesInFlight += partition.BytesToMove
+		}
+		movingPartitions := len(topic.movingPartitions()) > 0
+		pendingPartitions := hasPartitionsWithStatus(topic, TopicReassignmentPending)
+		if !movingPartitions && (!pendingPartitions || pausing) {
+			if err := kc.removeTopicReplicationThrottle(plan, topic); err != nil {
+				return partitionsInFlight, bytesInFlight, err
+			}
+		}
+		if !movingPartitions && !pendingPartitions {
+			topic.Status = TopicReassignmentFinished
+		}
+	}
+	return partitionsInFlight, bytesInFlight, nil
+}
+
+// startPartitionsReassignment throttles replication and moves given partitions of topic to new brokers.
+// Reassignment of the topic is skipped if the current assignment of the topic differs from the planned one.
+func (kc *KafkaClient) startPartitionsReassignment(plan *ReassignmentPlan, topic *TopicReassignment, started []int, now time.Time,
+	saveProgress func() error) error {
+	if topic.Status == TopicReassignmentPending {
+		metadata, err := kc.adminClient.DescribeTopics([]string{topic.Topic})
+		if err != nil {
+			return err
+		}
+		if len(metadata) != 1 || !assignmentMatches(metadata[0].Partitions, topic.CurrentReplicas) {
+			log.Info(fmt.Sprintf("Assignment of topic %s is changed after planning, skip reassignment", topic.Topic))
+			topic.Status = TopicReassignmentSkipped
+			for j := range topic.Partitions {
+				topic.Partitions[j].Status = TopicReassignmentSkipped
+			}
+			return nil
+		}
+		topic.StartTime = &now
+	}
+	var err error
+	if topic.ThrottledBrokerIds == nil && kc.replicationThrottleBytesPerSec > 0 {
+		// throttled brokers are recorded before throttles are set, so that they are removed if operator restarts in between
+		_, _, topic.ThrottledBrokerIds = getThrottledReplicas(topic.CurrentReplicas, topic.Replicas)
+		if len(topic.ThrottledBrokerIds) > 0 {
+			if err = saveProgress(); err == nil {
+				_, err = kc.setReplicationThrottle(topic.Topic, topic.CurrentReplicas, topic.Replicas)
+			}
+		}
+	}
+	if err == nil {
+		// partitions which are not started keep their replicas, moved partitions keep their target replicas
+		assignment := topic.actualAssignment()
+		for _, j := range started {
+			assignment[topic.Partitions[j].Partition] = topic.Replicas[topic.Partitions[j].Partition]
+		}
+		err = kc.adminClient.AlterPartitionReassignments(topic.Topic, assignment)
+	}
+	if err != nil {
+		if removeErr := kc.removeTopicReplicationThrottle(plan, topic); removeErr != nil {
+			log.Error(removeErr, fmt.Sprintf("Cannot remove replication throttle for topic [%s]", topic.Topic))
+		}
+		for j := range topic.Partitions {
+			if topic.Partitions[j].Status == TopicReassignmentPending {
+				topic.Partitions[j].Status = TopicReassignmentFailed
+			}
+		}
+		return err
+	}
+	for _, j := range started {
+		topic.Partitions[j].Status = TopicReassignmentInProgress
+		topic.Partitions[j].StartTime = &now
+	}
+	topic.Status = TopicReassignmentInProgress
+	return nil
+}
+
+// removeTopicReplicationThrottle removes throttled replicas of the topic and throttled rates of brokers
+// which do not participate in reassignment of other topics
+func (kc *KafkaClient) removeTopicReplicationThrottle(plan *ReassignmentPlan, topic *TopicReassignment) error {
+	if topic.ThrottledBrokerIds == nil {
+		return nil
+	}
+	inUse := map[int32]bool{}
+	for _, other := range plan.Topics {
+		if other.Topic == topic.Topic {
+			continue
+		}
+		for _, brokerId := range other.ThrottledBrokerIds {
+			inUse[brokerId] = true
+		}
+	}
+	var brokerIds []int32
+	for _, brokerId := range topic.ThrottledBrokerIds {
+		if !inUse[brokerId] {
+			brokerIds = append(brokerIds, brokerId)
+		}
+	}
+	if err := kc.removeReplicationThrottle(topic.Topic, brokerIds); err != nil {
+		return fmt.Errorf("cannot remove replication throttle for topic [%s]: %w", topic.Topic, err)
+	}
+	topic.ThrottledBrokerIds = nil
+	return nil
+}
+
+// actualAssignment returns replicas which partitions of the topic have now or will have when moved partitions are finished
+func (topic *TopicReassignment) actualAssignment() [][]int32 {
+	assignment := make([][]int32, len(topic.CurrentReplicas))
+	copy(assignment, topic.CurrentReplicas)
+	for _, partition := range topic.Partitions {
+		if partition.Status == TopicReassignmentInProgress || partition.Status == TopicReassignmentFinished ||
+			partition.Status == PartitionReassignmentTimedOut {
+			assignment[partition.Partition] = topic.Replicas[partition.Partition]
+		}
+	}
+	return assignment
+}
+
+// movingPartitions returns partitions of the topic which are being moved including timed out ones
+func (topic *TopicReassignment) movingPartitions() []int32 {
+	return append(topic.partitionsWithStatus(TopicReassignmentInProgress), topic.partitionsWithStatus(PartitionReassignmentTimedOut)...)
+}
+
+func (topic *TopicReassignment) partitionsWithStatus(status string) []int32 {
+	var partitions []int32
+	for _, partition := range topic.Partitions {
+		if partition.Status == status {
+			partitions = append(partitions, partition.Partition)
+		}
+	}
+	return partitions
+}
+
+func hasPartitionsWithStatus(topic *TopicReassignment, status string) bool {
+	return len(topic.partitionsWithStatus(status)) > 0
+}
+
+func assignmentMatches(partitions []*sarama.PartitionMetadata, assignment [][]int32) bool {
+	if len(partitions) != len(assignment) {
+		return false
+	}
+	for _, partition := range partitions {
+		if int(partition.ID) >= len(assignment) || !equalReplicas(partition.Replicas, assignment[partition.ID]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,357 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeReassignmentAdmin keeps assignment of topics and reassignments of partitions which are finished on demand
+type fakeReassignmentAdmin struct {
+	*fakeConfigsAdmin
+	assignments map[string][][]int32
+	ongoing     map[string]map[int32][]int32
+}
+
+// noSaveProgress is used when persistence of the plan is not checked
+func noSaveProgress() error {
+	return nil
+}
+
+func newFakeReassignmentAdmin(assignments map[string][][]int32) *fakeReassignmentAdmin {
+	return &fakeReassignmentAdmin{fakeConfigsAdmin: newFakeConfigsAdmin(), assignments: assignments, ongoing: map[string]map[int32][]int32{}}
+}
+
+func (a *fakeReassignmentAdmin) DescribeTopics(topics []string) ([]*sarama.TopicMetadata, error) {
+	var metadata []*sarama.TopicMetadata
+	for _, topic := range topics {
+		assignment, found := a.assignments[topic]
+		if !found {
+			continue
+		}
+		topicMetadata := &sarama.TopicMetadata{Name: topic}
+		for partition, replicas := range assignment {
+			topicMetadata.Partitions = append(topicMetadata.Partitions,
+				&sarama.PartitionMetadata{ID: int32(partition), Leader: replicas[0], Replicas: replicas})
+		}
+		metadata = append(metadata, topicMetadata)
+	}
+	return metadata, nil
+}
+
+func (a *fakeReassignmentAdmin) AlterPartitionReassignments(topic string, assignment [][]int32) error {
+	if a.ongoing[topic] == nil {
+		a.ongoing[topic] = map[int32][]int32{}
+	}
+	var err error
+	for partition, replicas := range assignment {
+		_, moving := a.ongoing[topic][int32(partition)]
+		switch {
+		case replicas == nil && !moving:
+			err = sarama.ErrNoReassignmentInProgress
+		case replicas == nil:
+			// empty target replicas cancel reassignment
+			delete(a.ongoing[topic], int32(partition))
+		case !equalReplicas(replicas, a.assignments[topic][partition]):
+			a.ongoing[topic][int32(partition)] = replicas
+		}
+	}
+	if len(a.ongoing[topic]) == 0 {
+		delete(a.ongoing, topic)
+	}
+	if err != nil {
+		return sarama.Wrap(sarama.ErrReassignPartitions, err)
+	}
+	return nil
+}
+
+func (a *fakeReassignmentAdmin) ListPartitionReassignments(topic string, partitions []int32) (map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus, error) {
+	status := map[string]map[int32]*sarama.PartitionReplicaReassignmentsStatus{}
+	for _, partition := range partitions {
+		if replicas, found := a.ongoing[topic][partition]; found {
+			if status[topic] == nil {
+				status[topic] = map[int32]*sarama.PartitionReplicaReassignmentsStatus{}
+			}
+			status[topic][partition] = &sarama.PartitionReplicaReassignmentsStatus{Replicas: replicas}
+		}
+	}
+	return status, nil
+}
+
+// finish completes reassignment of all partitions of topic
+func (a *fakeReassignmentAdmin) finish(topic string) {
+	for partition, replicas := range a.ongoing[topic] {
+		a.assignments[topic][partition] = replicas
+	}
+	delete(a.ongoing, topic)
+}
+
+// moving returns the number of partitions being moved
+func (a *fakeReassignmentAdmin) moving() int {
+	count := 0
+	for _, partitions := range a.ongoing {
+		count += len(partitions)
+	}
+	return count
+}
+
+func TestAdvanceReassignmentPlan(t *testing.T) {
+	admin := newFakeReassignmentAdmin(map[string][][]int32{
+		"first":   {{1, 2}, {2, 1}},
+		"second":  {{1, 2}},
+		"changed": {{2, 3}},
+	})
+	kc := &KafkaClient{adminClient: admin, topicReassignmentTimeoutSeconds: 300, replicationThrottleBytesPerSec: 1024,
+		maxConcurrentPartitionMoves: 1}
+	plan := &ReassignmentPlan{Topics: []TopicReassignment{
+		{Topic: "first", CurrentReplicas: [][]int32{{1, 2}, {2, 1}}, Replicas: [][]int32{{3, 2}, {2, 1}}, Status: TopicReassignmentPending,
+			Partitions: []PartitionReassignment{{Partition: 0, Status: TopicReassignmentPending}}},
+		{Topic: "changed", CurrentReplicas: [][]int32{{1, 2}}, Replicas: [][]int32{{1, 3}}, Status: TopicReassignmentPending,
+			Partitions: []PartitionReassignment{{Partition: 0, Status: TopicReassignmentPending}}},
+		{Topic: "second", CurrentReplicas: [][]int32{{1, 2}}, Replicas: [][]int32{{3, 2}}, Status: TopicReassignmentPending,
+			Partitions: []PartitionReassignment{{Partition: 0, Status: TopicReassignmentPending}}},
+	}}
+	now := time.Now()
+
+	done, err := kc.AdvanceReassignmentPlan(plan, now, noSaveProgress)
+	assert.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, TopicReassignmentInProgress, plan.Topics[0].Status)
+	assert.Equal(t, []int32{1, 2, 3}, plan.Topics[0].ThrottledBrokerIds)
+	assert.Equal(t, map[int32][]int32{0: {3, 2}}, admin.ongoing["first"])
+	assert.Equal(t, TopicReassignmentPending, plan.Topics[1].Status)
+
+	// the topic is still being reassigned
+	done, err = kc.AdvanceReassignmentPlan(plan, now.Add(time.Minute), noSaveProgress)
+	assert.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, TopicReassignmentInProgress, plan.Topics[0].Status)
+
+	// partition which is not moved in time is reported, but it is still counted in limits and throttled
+	done, err = kc.AdvanceReassignmentPlan(plan, now.Add(10*time.Minute), noSaveProgress)
+	assert.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, PartitionReassignmentTimedOut, plan.Topics[0].Partitions[0].Status)
+	assert.Equal(t, TopicReassignmentInProgress, plan.Topics[0].Status)
+	assert.Equal(t, TopicReassignmentPending, plan.Topics[1].Status)
+	assert.Equal(t, 1, admin.moving())
+	assert.Equal(t, []int32{1, 2, 3}, plan.Topics[0].ThrottledBrokerIds)
+	assert.Contains(t, admin.configs[sarama.TopicResource], "first")
+	assert.Equal(t, ReassignmentProgress{Remaining: 3, TimedOut: []string{"first-0"}}, plan.Progress())
+
+	// the next topic is started when the previous one is finished, topics changed after planning are skipped
+	admin.finish("first")
+	done, err = kc.AdvanceReassignmentPlan(plan, now.Add(11*time.Minute), noSaveProgress)
+	assert.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, TopicReassignmentFinished, plan.Topics[0].Status)
+	assert.Nil(t, plan.Topics[0].ThrottledBrokerIds)
+	assert.Equal(t, TopicReassignmentSkipped, plan.Topics[1].Status)
+	assert.Equal(t, TopicReassignmentInProgress, plan.Topics[2].Status)
+	assert.NotContains(t, admin.configs[sarama.TopicResource], "first")
+
+	admin.finish("second")
+	done, err = kc.AdvanceReassignmentPlan(plan, now.Add(12*time.Minute), noSaveProgress)
+	assert.NoError(t, err)
+	assert.True(t, done)
+	assert.Equal(t, TopicReassignmentFinished, plan.Topics[2].Status)
+	assert.Equal(t, ReassignmentProgress{Completed: 2, Failed: 1}, plan.Progress())
+	assert.Empty(t, admin.configs[sarama.BrokerResource])
+	assert.Empty(t, admin.configs[sarama.TopicResource])
+}
+
+func TestAdvanceReassignmentPlanInParallel(t *testing.T) {
+	admin := newFakeReassignmentAdmin(map[string][][]int32{
+		"first":  {{1, 2}, {1, 2}, {1, 2}},
+		"second": {{1, 2}},
+	})
+	kc := &KafkaClient{adminClient: admin, topicReassignmentTimeoutSeconds: 300, replicationThrottleBytesPerSec: 1024,
+		maxConcurrentPartitionMoves: 3, maxBytesInFlight: 250}
+	plan := &ReassignmentPlan{Topics: []TopicReassignment{
+		{Topic: "first", CurrentReplicas: [][]int32{{1, 2}, {1, 2}, {1, 2}}, Replicas: [][]int32{{3, 2}, {3, 2}, {3, 2}},
+			Status: TopicReassignmentPending, Partitions: []PartitionReassignment{
+				{Partition: 0, BytesToMove: 100, Status: TopicReassignmentPending},
+				{Partition: 1, BytesToMove: 100, Status: TopicReassignmentPending},
+				{Partition: 2, BytesToMove: 100, Status: TopicReassignmentPending},
+			}},
+		{Topic: "second", CurrentReplicas: [][]int32{{1, 2}}, Replicas: [][]int32{{3, 1}},
+			Status: TopicReassignmentPending, Partitions: []PartitionReassignment{
+				{Partition: 0, BytesToMove: 50, Status: TopicReassignmentPending},
+			}},
+	}}
+	now := time.Now()
+
+	// the third partition exceeds the limit of bytes in flight
+	done, err := kc.AdvanceReassignmentPlan(plan, now, noSaveProgress)
+	assert.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, map[int32][]int32{0: {3, 2}, 1: {3, 2}}, admin.ongoing["first"])
+	assert.Equal(t, TopicReassignmentPending, plan.Topics[1].Status)
+
+	// finished partitions free the limits for the rest of the first topic and the next topic
+	admin.finish("first")
+	done, err = kc.AdvanceReassignmentPlan(plan, now.Add(time.Minute), noSaveProgress)
+	assert.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, 2, admin.moving())
+	assert.Equal(t, map[int32][]int32{2: {3, 2}}, admin.ongoing["first"])
+	assert.Equal(t, TopicReassignmentInProgress, plan.Topics[1].Status)
+
+	// throttled rates of brokers are kept while they are used by reassignment of the second topic
+	admin.finish("first")
+	done, err = kc.AdvanceReassignmentPlan(plan, now.Add(2*time.Minute), noSaveProgress)
+	assert.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, TopicReassignmentFinished, plan.Topics[0].Status)
+	assert.NotContains(t, admin.configs[sarama.TopicResource], "first")
+	assert.NotEmpty(t, admin.configs[sarama.BrokerResource])
+
+	// partition which is not moved in time is reported and awaited until Kafka finishes it
+	done, err = kc.AdvanceReassignmentPlan(plan, now.Add(10*time.Minute), noSaveProgress)
+	assert.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, PartitionReassignmentTimedOut, plan.Topics[1].Partitions[0].Status)
+	assert.Equal(t, ReassignmentProgress{Completed: 1, Remaining: 1, TimedOut: []string{"second-0"}}, plan.Progress())
+	assert.Contains(t, admin.configs[sarama.TopicResource], "second")
+
+	admin.finish("second")
+	done, err = kc.AdvanceReassignmentPlan(plan, now.Add(11*time.Minute), noSaveProgress)
+	assert.NoError(t, err)
+	assert.True(t, done)
+	assert.Equal(t, ReassignmentProgress{Completed: 2}, plan.Progress())
+	assert.Empty(t, admin.configs[sarama.BrokerResource])
+	assert.Empty(t, admin.configs[sarama.TopicResource])
+}
+
+func TestAdvanceReassignmentPlanStartsPartitionExceedingBytesLimit(t *testing.T) {
+	admin := newFakeReassignmentAdmin(map[string][][]int32{"test": {{1, 2}, {1, 2}}})
+	kc := &KafkaClient{adminClient: admin, topicReassignmentTimeoutSeconds: 300, maxBytesInFlight: 10}
+	plan := &ReassignmentPlan{Topics: []TopicReassignment{
+		{Topic: "test", CurrentReplicas: [][]int32{{1, 2}, {1, 2}}, Replicas: [][]int32{{3, 2}, {1, 3}},
+			Status: TopicReassignmentPending, Partitions: []PartitionReassignment{
+				{Partition: 0, BytesToMove: 100, Status: TopicReassignmentPending},
+				{Partition: 1, BytesToMove: 1, Status: TopicReassignmentPending},
+			}},
+	}}
+
+	_, err := kc.AdvanceReassignmentPlan(plan, time.Now(), noSaveProgress)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int32][]int32{0: {3, 2}}, admin.ongoing["test"])
+}
+
+func TestNewTopicReassignment(t *testing.T) {
+	topic := TopicInfo{topicName: "test", configs: sarama.TopicDetail{
+		NumPartitions:     2,
+		ReplicationFactor: 2,
+		ReplicaAssignment: map[int32][]int32{0: {1, 2}, 1: {2, 1}},
+	}}
+	reassignment, changed := newTopicReassignment(topic, [][]int32{{1, 2}, {2, 1}})
+	assert.False(t, changed)
+	reassignment, changed = newTopicReassignment(topic, [][]int32{{1, 2}, {2, 3}})
+	assert.True(t, changed)
+	assert.Equal(t, TopicReassignment{
+		Topic:           "test",
+		CurrentReplicas: [][]int32{{1, 2}, {2, 1}},
+		Replicas:        [][]int32{{1, 2}, {2, 3}},
+		Status:          TopicReassignmentPending,
+		Partitions:      []PartitionReassignment{{Partition: 1, Status: TopicReassignmentPending}},
+	}, reassignment)
+}
+
+func TestPauseAndCancelReassignmentPlan(t *testing.T) {
+	admin := newFakeReassignmentAdmin(map[string][][]int32{
+		"first":  {{1, 2}},
+		"second": {{1, 2}},
+		"third":  {{1, 2}},
+	})
+	kc := &KafkaClient{adminClient: admin, topicReassignmentTimeoutSeconds: 300, replicationThrottleBytesPerSec: 1024,
+		maxConcurrentPartitionMoves: 1}
+	plan := &ReassignmentPlan{Topics: []TopicReassignment{
+		{Topic: "first", CurrentReplicas: [][]int32{{1, 2}}, Replicas: [][]int32{{3, 2}}, Status: TopicReassignmentPending,
+			Partitions: []PartitionReassignment{{Partition: 0, Status: TopicReassignmentPending}}},
+		{Topic: "second", CurrentReplicas: [][]int32{{1, 2}}, Replicas: [][]int32{{3, 2}}, Status: TopicReassignmentPending,
+			Partitions: []PartitionReassignment{{Partition: 0, Status: TopicReassignmentPending}}},
+		{Topic: "third", CurrentReplicas: [][]int32{{1, 2}}, Replicas: [][]int32{{3, 2}}, Status: TopicReassignmentPending,
+			Partitions: []PartitionReassignment{{Partition: 0, Status: TopicReassignmentPending}}},
+	}}
+	now := time.Now()
+
+	_, err := kc.AdvanceReassignmentPlan(plan, now, noSaveProgress)
+	assert.NoError(t, err)
+	assert.Equal(t, ReassignmentProgress{Remaining: 3}, plan.Progress())
+
+	// the topic being reassigned is awaited, the next topic is not started
+	paused, err := kc.PauseReassignmentPlan(plan, now.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.False(t, paused)
+	admin.finish("first")
+	paused, err = kc.PauseReassignmentPlan(plan, now.Add(2*time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, paused)
+	assert.Equal(t, TopicReassignmentPending, plan.Topics[1].Status)
+	assert.NotContains(t, admin.ongoing, "second")
+	assert.Equal(t, ReassignmentProgress{Completed: 1, Remaining: 2}, plan.Progress())
+
+	// reassignment is resumed and then cancelled
+	_, err = kc.AdvanceReassignmentPlan(plan, now.Add(3*time.Minute), noSaveProgress)
+	assert.NoError(t, err)
+	assert.Contains(t, admin.ongoing, "second")
+	assert.NoError(t, kc.CancelReassignmentPlan(plan))
+	assert.NotContains(t, admin.ongoing, "second")
+	assert.Equal(t, [][]int32{{1, 2}}, admin.assignments["second"])
+	assert.Empty(t, admin.configs[sarama.BrokerResource])
+	assert.Empty(t, admin.configs[sarama.TopicResource])
+	assert.Equal(t, ReassignmentProgress{Completed: 1, Remaining: 1, Cancelled: []string{"second"}}, plan.Progress())
+}
+
+func TestAdvanceReassignmentPlanSavesThrottledBrokersBeforeThrottling(t *testing.T) {
+	admin := newFakeReassignmentAdmin(map[string][][]int32{"orders": {{1, 2}}})
+	kc := &KafkaClient{adminClient: admin, topicReassignmentTimeoutSeconds: 300, replicationThrottleBytesPerSec: 1024,
+		maxConcurrentPartitionMoves: 1}
+	plan := &ReassignmentPlan{Topics: []TopicReassignment{
+		{Topic: "orders", CurrentReplicas: [][]int32{{1, 2}}, Replicas: [][]int32{{1, 3}}, Status: TopicReassignmentPending,
+			Partitions: []PartitionReassignment{{Partition: 0, Status: TopicReassignmentPending}}},
+	}}
+	saves := 0
+	saveProgress := func() error {
+		saves++
+		assert.Equal(t, []int32{1, 2, 3}, plan.Topics[0].ThrottledBrokerIds)
+		assert.Empty(t, admin.configs[sarama.BrokerResource])
+		return nil
+	}
+
+	done, err := kc.AdvanceReassignmentPlan(plan, time.Now(), saveProgress)
+	assert.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, 1, saves)
+	assert.Len(t, admin.configs[sarama.BrokerResource], 3)
+
+	// throttles are not set when the plan cannot be saved
+	admin = newFakeReassignmentAdmin(map[string][][]int32{"orders": {{1, 2}}})
+	kc.adminClient = admin
+	plan.Topics[0] = TopicReassignment{Topic: "orders", CurrentReplicas: [][]int32{{1, 2}}, Replicas: [][]int32{{1, 3}},
+		Status: TopicReassignmentPending, Partitions: []PartitionReassignment{{Partition: 0, Status: TopicReassignmentPending}}}
+	_, err = kc.AdvanceReassignmentPlan(plan, time.Now(), func() error { return errors.New("conflict") })
+	assert.NoError(t, err)
+	assert.Equal(t, TopicReassignmentFailed, plan.Topics[0].Status)
+	assert.Empty(t, admin.configs[sarama.BrokerResource])
+	assert.Empty(t, admin.configs[sarama.TopicResource])
+	assert.Equal(t, TopicReassignmentFailed, plan.Topics[0].Partitions[0].Status)
+}
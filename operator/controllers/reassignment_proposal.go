@@ -0,0 +1,247 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"math"
+	"sort"
+)
+
+// reassignmentJsonVersion is the version of reassignment JSON format of kafka-reassign-partitions tool
+const reassignmentJsonVersion = 1
+
+// PartitionsAssignment is assignment of partitions in JSON format of kafka-reassign-partitions tool,
+// so the proposal can be reviewed, executed or rolled back with the tool
+type PartitionsAssignment struct {
+	Version    int                   `json:"version"`
+	Partitions []PartitionAssignment `json:"partitions"`
+}
+
+type PartitionAssignment struct {
+	Topic     string  `json:"topic"`
+	Partition int32   `json:"partition"`
+	Replicas  []int32 `json:"replicas"`
+}
+
+// ReassignmentProposal is partitions reassignment computed without moving replicas. Reassignment contains
+// new replicas of moved partitions and CurrentAssignment contains their current replicas to roll back the reassignment.
+type ReassignmentProposal struct {
+	Reassignment      PartitionsAssignment
+	CurrentAssignment PartitionsAssignment
+	Summary           ReassignmentProposalSummary
+}
+
+// ReassignmentProposalSummary describes moves of partitions and load of brokers and racks before and after reassignment
+type ReassignmentProposalSummary struct {
+	Strategy    string       `json:"strategy"`
+	Partitions  int          `json:"partitions"`
+	BytesToMove int64        `json:"bytesToMove"`
+	Topics      []TopicMoves `json:"topics"`
+	Brokers     []BrokerSkew `json:"brokers"`
+	Racks       []RackSkew   `json:"racks,omitempty"`
+}
+
+// TopicMoves describes moved partitions of topic, BytesToMove is the size of replicas copied to new brokers
+type TopicMoves struct {
+	Topic       string `json:"topic"`
+	Partitions  int    `json:"partitions"`
+	BytesToMove int64  `json:"bytesToMove"`
+}
+
+type BrokerSkew struct {
+	BrokerId int32       `json:"brokerId"`
+	Pool     string      `json:"pool,omitempty"`
+	Rack     string      `json:"rack,omitempty"`
+	Before   LoadSummary `json:"before"`
+	After    LoadSummary `json:"after"`
+}
+
+type RackSkew struct {
+	Rack   string      `json:"rack"`
+	Before LoadSummary `json:"before"`
+	After  LoadSummary `json:"after"`
+}
+
+// LoadSummary describes partitions and disk usage of broker or rack. Skew is deviation of the load from the average
+// load in percent, load is measured by balancing strategy.
+type LoadSummary struct {
+	Replicas       int   `json:"replicas"`
+	Leaders        int   `json:"leaders"`
+	DiskUsageBytes int64 `json:"diskUsageBytes"`
+	Skew           int32 `json:"skew"`
+}
+
+// ProposePartitionsReassignment describes the effect of reassignment plan on brokers without moving replicas
+func (kc *KafkaClient) ProposePartitionsReassignment(plan *ReassignmentPlan) (*ReassignmentProposal, error) {
+	topics, err := kc.adminClient.ListTopics()
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := kc.describeCluster(topicNames(topics))
+	if err != nil {
+		return nil, err
+	}
+	brokerLoad := newBalancingStrategy(kc.balancingSettings).brokerLoad(snapshot)
+	proposal := buildReassignmentProposal(snapshot.brokers, snapshot.partitions, snapshot.diskUsage, plan, brokerLoad)
+	proposal.Summary.Strategy = kc.balancingSettings.Strategy
+	return proposal, nil
+}
+
+// buildReassignmentProposal applies plan to partitions and compares load of brokers and racks before and after it.
+// Disk usage after reassignment is estimated by sizes of moved partitions and leaders are preferred replicas.
+func buildReassignmentProposal(brokers []balancingBroker, partitions []*partitionReplicas, diskUsage map[int32]int64,
+	plan *ReassignmentPlan, brokerLoad func(balance BrokerBalance) int64) *ReassignmentProposal {
+	planned := make(map[string][][]int32, len(plan.Topics))
+	for _, topic := range plan.Topics {
+		planned[topic.Topic] = topic.Replicas
+	}
+	proposal := &ReassignmentProposal{
+		Reassignment:      PartitionsAssignment{Version: reassignmentJsonVersion, Partitions: []PartitionAssignment{}},
+		CurrentAssignment: PartitionsAssignment{Version: reassignmentJsonVersion, Partitions: []PartitionAssignment{}},
+		Summary:           ReassignmentProposalSummary{Topics: []TopicMoves{}},
+	}
+	diskUsageAfter := make(map[int32]int64, len(diskUsage))
+	for brokerId, usage := range diskUsage {
+		diskUsageAfter[brokerId] = usage
+	}
+	partitionsAfter := make([]*partitionReplicas, 0, len(partitions))
+	for _, partition := range partitions {
+		assignment := planned[partition.topic]
+		if int(partition.partition) >= len(assignment) || equalReplicas(partition.replicas, assignment[partition.partition]) {
+			partitionsAfter = append(partitionsAfter, partition)
+			continue
+		}
+		replicas := assignment[partition.partition]
+		partitionsAfter = append(partitionsAfter, &partitionReplicas{
+			topic:     partition.topic,
+			partition: partition.partition,
+			leader:    replicas[0],
+			replicas:  replicas,
+			sizeBytes: partition.sizeBytes,
+		})
+		proposal.Reassignment.Partitions = append(proposal.Reassignment.Partitions,
+			PartitionAssignment{Topic: partition.topic, Partition: partition.partition, Replicas: replicas})
+		proposal.CurrentAssignment.Partitions = append(proposal.CurrentAssignment.Partitions,
+			PartitionAssignment{Topic: partition.topic, Partition: partition.partition, Replicas: partition.replicas})
+
+		addedReplicas := missingReplicas(replicas, partition.replicas)
+		for _, brokerId := range addedReplicas {
+			diskUsageAfter[brokerId] += partition.sizeBytes
+		}
+		for _, brokerId := range missingReplicas(partition.replicas, replicas) {
+			diskUsageAfter[brokerId] -= partition.sizeBytes
+			if diskUsageAfter[brokerId] < 0 {
+				diskUsageAfter[brokerId] = 0
+			}
+		}
+		bytesToMove := int64(len(addedReplicas)) * partition.sizeBytes
+		topics := proposal.Summary.Topics
+		if len(topics) == 0 || topics[len(topics)-1].Topic != partition.topic {
+			proposal.Summary.Topics = append(proposal.Summary.Topics, TopicMoves{Topic: partition.topic})
+		}
+		topicMoves := &proposal.Summary.Topics[len(proposal.Summary.Topics)-1]
+		topicMoves.Partitions++
+		topicMoves.BytesToMove += bytesToMove
+		proposal.Summary.Partitions++
+		proposal.Summary.BytesToMove += bytesToMove
+	}
+
+	balancesBefore := calculateBrokersBalance(brokers, partitions, diskUsage, brokerLoad)
+	balancesAfter := calculateBrokersBalance(brokers, partitionsAfter, diskUsageAfter, brokerLoad)
+	racks := make(map[int32]string, len(brokers))
+	for _, broker := range brokers {
+		racks[broker.id] = broker.rack
+	}
+	for i := range balancesBefore {
+		proposal.Summary.Brokers = append(proposal.Summary.Brokers, BrokerSkew{
+			BrokerId: balancesBefore[i].BrokerId,
+			Pool:     balancesBefore[i].Pool,
+			Rack:     racks[balancesBefore[i].BrokerId],
+			Before:   toLoadSummary(balancesBefore[i]),
+			After:    toLoadSummary(balancesAfter[i]),
+		})
+	}
+	racksBefore := calculateRacksBalance(racks, balancesBefore, brokerLoad)
+	racksAfter := calculateRacksBalance(racks, balancesAfter, brokerLoad)
+	for i := range racksBefore {
+		proposal.Summary.Racks = append(proposal.Summary.Racks, RackSkew{
+			Rack:   racksBefore[i].rack,
+			Before: racksBefore[i].LoadSummary,
+			After:  racksAfter[i].LoadSummary,
+		})
+	}
+	return proposal
+}
+
+type rackBalance struct {
+	rack string
+	LoadSummary
+}
+
+// calculateRacksBalance sums load of brokers in each rack, it returns nothing if racks of brokers are not known
+func calculateRacksBalance(racks map[int32]string, balances []BrokerBalance,
+	brokerLoad func(balance BrokerBalance) int64) []rackBalance {
+	summaries := map[string]*LoadSummary{}
+	load := map[string]int64{}
+	var totalLoad int64
+	for _, balance := range balances {
+		rack := racks[balance.BrokerId]
+		if rack == "" {
+			return nil
+		}
+		summary, found := summaries[rack]
+		if !found {
+			summary = &LoadSummary{}
+			summaries[rack] = summary
+		}
+		summary.Replicas += balance.Replicas
+		summary.Leaders += balance.Leaders
+		summary.DiskUsageBytes += balance.DiskUsageBytes
+		load[rack] += brokerLoad(balance)
+		totalLoad += brokerLoad(balance)
+	}
+	result := make([]rackBalance, 0, len(summaries))
+	average := float64(totalLoad) / float64(len(summaries))
+	for rack, summary := range summaries {
+		if average > 0 {
+			summary.Skew = int32(math.Round((float64(load[rack]) - average) / average * 100))
+		}
+		result = append(result, rackBalance{rack: rack, LoadSummary: *summary})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].rack < result[j].rack
+	})
+	return result
+}
+
+func toLoadSummary(balance BrokerBalance) LoadSummary {
+	return LoadSummary{
+		Replicas:       balance.Replicas,
+		Leaders:        balance.Leaders,
+		DiskUsageBytes: balance.DiskUsageBytes,
+		Skew:           balance.Skew,
+	}
+}
+
+// missingReplicas returns replicas which are not contained in other replicas
+func missingReplicas(replicas []int32, other []int32) []int32 {
+	var result []int32
+	for _, replica := range replicas {
+		if indexOfInt32(other, replica) < 0 {
+			result = append(result, replica)
+		}
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildReassignmentProposal(t *testing.T) {
+	brokers := []balancingBroker{{id: 1, rack: "a"}, {id: 2, rack: "b"}, {id: 3, rack: "a"}}
+	partitions := []*partitionReplicas{
+		{topic: "first", partition: 0, leader: 1, replicas: []int32{1, 2}, sizeBytes: 100},
+		{topic: "first", partition: 1, leader: 1, replicas: []int32{1, 2}, sizeBytes: 200},
+		{topic: "second", partition: 0, leader: 2, replicas: []int32{2, 1}, sizeBytes: 50},
+	}
+	diskUsage := map[int32]int64{1: 350, 2: 350}
+	plan := &ReassignmentPlan{Topics: []TopicReassignment{
+		{Topic: "first", CurrentReplicas: [][]int32{{1, 2}, {1, 2}}, Replicas: [][]int32{{1, 2}, {3, 2}}},
+	}}
+	leaders := func(balance BrokerBalance) int64 {
+		return int64(balance.Leaders)
+	}
+	proposal := buildReassignmentProposal(brokers, partitions, diskUsage, plan, leaders)
+
+	assert.Equal(t, PartitionsAssignment{Version: 1, Partitions: []PartitionAssignment{
+		{Topic: "first", Partition: 1, Replicas: []int32{3, 2}},
+	}}, proposal.Reassignment)
+	assert.Equal(t, PartitionsAssignment{Version: 1, Partitions: []PartitionAssignment{
+		{Topic: "first", Partition: 1, Replicas: []int32{1, 2}},
+	}}, proposal.CurrentAssignment)
+	assert.Equal(t, 1, proposal.Summary.Partitions)
+	assert.Equal(t, int64(200), proposal.Summary.BytesToMove)
+	assert.Equal(t, []TopicMoves{{Topic: "first", Partitions: 1, BytesToMove: 200}}, proposal.Summary.Topics)
+	assert.Equal(t, []BrokerSkew{
+		{BrokerId: 1, Rack: "a",
+			Before: LoadSummary{Replicas: 3, Leaders: 2, DiskUsageBytes: 350, Skew: 100},
+			After:  LoadSummary{Replicas: 2, Leaders: 1, DiskUsageBytes: 150, Skew: 0}},
+		{BrokerId: 2, Rack: "b",
+			Before: LoadSummary{Replicas: 3, Leaders: 1, DiskUsageBytes: 350, Skew: 0},
+			After:  LoadSummary{Replicas: 3, Leaders: 1, DiskUsageBytes: 350, Skew: 0}},
+		{BrokerId: 3, Rack: "a",
+			Before: LoadSummary{Skew: -100},
+			After:  LoadSummary{Replicas: 1, Leaders: 1, DiskUsageBytes: 200, Skew: 0}},
+	}, proposal.Summary.Brokers)
+	assert.Equal(t, []RackSkew{
+		{Rack: "a",
+			Before: LoadSummary{Replicas: 3, Leaders: 2, DiskUsageBytes: 350, Skew: 33},
+			After:  LoadSummary{Replicas: 3, Leaders: 2, DiskUsageBytes: 350, Skew: 33}},
+		{Rack: "b",
+			Before: LoadSummary{Replicas: 3, Leaders: 1, DiskUsageBytes: 350, Skew: -33},
+			After:  LoadSummary{Replicas: 3, Leaders: 1, DiskUsageBytes: 350, Skew: -33}},
+	}, proposal.Summary.Racks)
+
+	reassignment, err := json.Marshal(proposal.Reassignment)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"version":1,"partitions":[{"topic":"first","partition":1,"replicas":[3,2]}]}`, string(reassignment))
+}
+
+func TestBuildReassignmentProposalWithoutRacks(t *testing.T) {
+	brokers := []balancingBroker{{id: 1}, {id: 2}}
+	partitions := []*partitionReplicas{{topic: "test", partition: 0, leader: 1, replicas: []int32{1}}}
+	proposal := buildReassignmentProposal(brokers, partitions, nil, &ReassignmentPlan{}, func(balance BrokerBalance) int64 {
+		return int64(balance.Replicas)
+	})
+	assert.Empty(t, proposal.Reassignment.Partitions)
+	assert.Empty(t, proposal.Summary.Racks)
+	assert.Len(t, proposal.Summary.Brokers, 2)
+}
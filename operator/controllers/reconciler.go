@@ -19,18 +19,24 @@ import (
 	"fmt"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"strings"
 
+	certmanagerv1 "github.com/Netcracker/qubership-kafka/operator/api/certmanager/v1"
 	"github.com/Netcracker/qubership-kafka/operator/util"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -87,6 +93,15 @@ func GetActualPodNames(pods []corev1.Pod) []string {
 	return names
 }
 
+func (r *Reconciler) DeletePod(pod *corev1.Pod, logger logr.Logger) error {
+	logger.Info("Deleting pod", "Pod.Namespace", pod.Namespace, "Pod.Name", pod.Name)
+	err := r.Client.Delete(context.TODO(), pod)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
 func ApiGroupMatches(apiVersion string, targetApiGroup string) bool {
 	apiGroup := strings.Split(apiVersion, "/")[0]
 	return apiGroup == targetApiGroup
@@ -154,11 +169,35 @@ func (r *Reconciler) CreateOrUpdateService(service *corev1.Service, logger logr.
 		service.ResourceVersion = foundService.ResourceVersion
 		if foundService.Spec.Type == corev1.ServiceTypeClusterIP {
 			service.Spec.ClusterIP = foundService.Spec.ClusterIP
+		} else if foundService.Spec.Type == service.Spec.Type {
+			// keep allocated cluster IP and node ports, otherwise they are changed on each update
+			service.Spec.ClusterIP = foundService.Spec.ClusterIP
+			keepNodePorts(service, foundService)
 		}
 		return r.Client.Update(context.TODO(), service)
 	}
 }
 
+func keepNodePorts(service *corev1.Service, foundService *corev1.Service) {
+	for i, port := range service.Spec.Ports {
+		if port.NodePort != 0 {
+			continue
+		}
+		for _, foundPort := range foundService.Spec.Ports {
+			if foundPort.Name == port.Name {
+				service.Spec.Ports[i].NodePort = foundPort.NodePort
+			}
+		}
+	}
+}
+
+func (r *Reconciler) FindService(name string, namespace string, logger logr.Logger) (*corev1.Service, error) {
+	logger.Info(fmt.Sprintf("Checking Existence of [%s] service", name))
+	foundService := &corev1.Service{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, foundService)
+	return foundService, err
+}
+
 func (r *Reconciler) DeleteService(service *corev1.Service, logger logr.Logger) error {
 	logger.Info(fmt.Sprintf("Checking Existence of [%s] service", service.Name))
 	foundService := &corev1.Service{}
@@ -183,6 +222,109 @@ func (r *Reconciler) GetServiceIp(namespace, serviceName string) (string, error)
 	return foundService.Spec.ClusterIP, err
 }
 
+// CreateOrUpdateIngress creates the ingress if it doesn't exist and updates otherwise
+func (r *Reconciler) CreateOrUpdateIngress(ingress *networkingv1.Ingress, logger logr.Logger) error {
+	logger.Info(fmt.Sprintf("Checking Existence of [%s] ingress", ingress.Name))
+	foundIngress := &networkingv1.Ingress{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: ingress.Name, Namespace: ingress.Namespace}, foundIngress)
+	if err != nil && errors.IsNotFound(err) {
+		logger.Info("Creating a new ingress",
+			"Ingress.Namespace", ingress.Namespace, "Ingress.Name", ingress.Name)
+		return r.Client.Create(context.TODO(), ingress)
+	} else if err != nil {
+		return err
+	} else {
+		logger.Info("Updating the found ingress",
+			"Ingress.Namespace", ingress.Namespace, "Ingress.Name", ingress.Name)
+		ingress.ResourceVersion = foundIngress.ResourceVersion
+		return r.Client.Update(context.TODO(), ingress)
+	}
+}
+
+func (r *Reconciler) DeleteIngress(ingress *networkingv1.Ingress, logger logr.Logger) error {
+	logger.Info(fmt.Sprintf("Checking Existence of [%s] ingress", ingress.Name))
+	foundIngress := &networkingv1.Ingress{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: ingress.Name, Namespace: ingress.Namespace}, foundIngress)
+	if err != nil && errors.IsNotFound(err) {
+		logger.Info("Ingress not exist, nothing to delete",
+			"Ingress.Namespace", ingress.Namespace, "Ingress.Name", ingress.Name)
+		return nil
+	} else if err != nil {
+		return err
+	} else {
+		logger.Info("Deleting the found ingress",
+			"Ingress.Namespace", ingress.Namespace, "Ingress.Name", ingress.Name)
+		return r.Client.Delete(context.TODO(), foundIngress)
+	}
+}
+
+func (r *Reconciler) CreateOrUpdatePodDisruptionBudget(pdb *policyv1.PodDisruptionBudget, logger logr.Logger) error {
+	logger.Info(fmt.Sprintf("Checking Existence of [%s] pod disruption budget", pdb.Name))
+	foundPdb := &policyv1.PodDisruptionBudget{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: pdb.Name, Namespace: pdb.Namespace}, foundPdb)
+	if err != nil && errors.IsNotFound(err) {
+		logger.Info("Creating a new pod disruption budget",
+			"PodDisruptionBudget.Namespace", pdb.Namespace, "PodDisruptionBudget.Name", pdb.Name)
+		return r.Client.Create(context.TODO(), pdb)
+	} else if err != nil {
+		return err
+	} else {
+		logger.Info("Updating the found pod disruption budget",
+			"PodDisruptionBudget.Namespace", pdb.Namespace, "PodDisruptionBudget.Name", pdb.Name)
+		pdb.ResourceVersion = foundPdb.ResourceVersion
+		return r.Client.Update(context.TODO(), pdb)
+	}
+}
+
+func (r *Reconciler) DeletePodDisruptionBudget(pdb *policyv1.PodDisruptionBudget, logger logr.Logger) error {
+	logger.Info(fmt.Sprintf("Checking Existence of [%s] pod disruption budget", pdb.Name))
+	foundPdb := &policyv1.PodDisruptionBudget{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: pdb.Name, Namespace: pdb.Namespace}, foundPdb)
+	if err != nil && errors.IsNotFound(err) {
+		logger.Info("Pod disruption budget not exist, nothing to delete",
+			"PodDisruptionBudget.Namespace", pdb.Namespace, "PodDisruptionBudget.Name", pdb.Name)
+		return nil
+	} else if err != nil {
+		return err
+	} else {
+		logger.Info("Deleting the found pod disruption budget",
+			"PodDisruptionBudget.Namespace", pdb.Namespace, "PodDisruptionBudget.Name", pdb.Name)
+		return r.Client.Delete(context.TODO(), foundPdb)
+	}
+}
+
+// ReconcilePodDisruptionBudget creates or updates PodDisruptionBudget owned by custom resource if it is enabled
+// and deletes it otherwise
+func (r *Reconciler) ReconcilePodDisruptionBudget(owner runtime.Object, pdb *policyv1.PodDisruptionBudget, enabled bool, logger logr.Logger) error {
+	if !enabled {
+		return r.DeletePodDisruptionBudget(pdb, logger)
+	}
+	if err := r.SetControllerReference(owner, pdb, r.Scheme); err != nil {
+		return err
+	}
+	return r.CreateOrUpdatePodDisruptionBudget(pdb, logger)
+}
+
+func (r *Reconciler) CreateOrUpdateCertificate(certificate *certmanagerv1.Certificate, logger logr.Logger) error {
+	logger.Info(fmt.Sprintf("Checking Existence of [%s] certificate", certificate.Name))
+	foundCertificate := &certmanagerv1.Certificate{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: certificate.Name, Namespace: certificate.Namespace}, foundCertificate)
+	if err != nil && errors.IsNotFound(err) {
+		logger.Info("Creating a new certificate",
+			"Certificate.Namespace", certificate.Namespace, "Certificate.Name", certificate.Name)
+		return r.Client.Create(context.TODO(), certificate)
+	} else if err != nil {
+		return err
+	} else if reflect.DeepEqual(foundCertificate.Spec, certificate.Spec) {
+		return nil
+	} else {
+		logger.Info("Updating the found certificate",
+			"Certificate.Namespace", certificate.Namespace, "Certificate.Name", certificate.Name)
+		certificate.ResourceVersion = foundCertificate.ResourceVersion
+		return r.Client.Update(context.TODO(), certificate)
+	}
+}
+
 func (r *Reconciler) CreatePersistentVolumeClaim(persistentVolumeClaim *corev1.PersistentVolumeClaim, logger logr.Logger) error {
 	logger.Info(fmt.Sprintf("Checking Existence of [%s] persistent volume claim", persistentVolumeClaim.Name))
 	foundPersistentVolumeClaim := &corev1.PersistentVolumeClaim{}
@@ -228,6 +370,30 @@ func (r *Reconciler) DeletePersistentVolumeClaim(persistentVolumeClaim *corev1.P
 	return err
 }
 
+func (r *Reconciler) FindPersistentVolumeClaim(name string, namespace string, logger logr.Logger) (*corev1.PersistentVolumeClaim, error) {
+	logger.Info(fmt.Sprintf("Checking Existence of [%s] persistent volume claim", name))
+	foundPersistentVolumeClaim := &corev1.PersistentVolumeClaim{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, foundPersistentVolumeClaim)
+	return foundPersistentVolumeClaim, err
+}
+
+func (r *Reconciler) UpdatePersistentVolumeClaim(persistentVolumeClaim *corev1.PersistentVolumeClaim, logger logr.Logger) error {
+	logger.Info("Updating the found persistent volume claim",
+		"PersistentVolumeClaim.Namespace", persistentVolumeClaim.Namespace, "PersistentVolumeClaim.Name", persistentVolumeClaim.Name)
+	return r.Client.Update(context.TODO(), persistentVolumeClaim)
+}
+
+// IsVolumeExpansionAllowed checks whether storage class with specified name allows volume expansion
+func (r *Reconciler) IsVolumeExpansionAllowed(storageClassName string, logger logr.Logger) (bool, error) {
+	storageClass := &storagev1.StorageClass{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: storageClassName}, storageClass)
+	if err != nil {
+		logger.Error(err, fmt.Sprintf("Cannot get storage class [%s] info", storageClassName))
+		return false, err
+	}
+	return storageClass.AllowVolumeExpansion != nil && *storageClass.AllowVolumeExpansion, nil
+}
+
 func (r *Reconciler) CreateOrUpdateDeployment(deployment *appsv1.Deployment, logger logr.Logger) error {
 	_, err := r.FindDeployment(deployment.Name, deployment.Namespace, logger)
 	if err != nil && errors.IsNotFound(err) {
@@ -265,6 +431,76 @@ func (r *Reconciler) FindDeployment(name string, namespace string, logger logr.L
 	return foundDeployment, err
 }
 
+// CreateOrUpdateStatefulSet creates or updates stateful set with given start ordinal of pods. The stateful set is sent
+// as unstructured object, because ordinals are not present in Kubernetes API version used by operator and typed
+// update would reset them. Volume claim templates cannot be changed, so the found ones are kept.
+func (r *Reconciler) CreateOrUpdateStatefulSet(statefulSet *appsv1.StatefulSet, startOrdinal int32, logger logr.Logger) error {
+	foundStatefulSet, err := r.FindStatefulSet(statefulSet.Name, statefulSet.Namespace, logger)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	found := err == nil
+	if found {
+		statefulSet.Spec.VolumeClaimTemplates = foundStatefulSet.Spec.VolumeClaimTemplates
+	}
+	object, err := toUnstructured(statefulSet)
+	if err != nil {
+		return err
+	}
+	object.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("StatefulSet"))
+	if err = unstructured.SetNestedField(object.Object, int64(startOrdinal), "spec", "ordinals", "start"); err != nil {
+		return err
+	}
+	if !found {
+		logger.Info("Creating a new StatefulSet",
+			"StatefulSet.Namespace", statefulSet.Namespace, "StatefulSet.Name", statefulSet.Name)
+		return r.Client.Create(context.TODO(), object)
+	}
+	logger.Info("Updating the found StatefulSet",
+		"StatefulSet.Namespace", statefulSet.Namespace, "StatefulSet.Name", statefulSet.Name)
+	object.SetResourceVersion(foundStatefulSet.ResourceVersion)
+	return r.Client.Update(context.TODO(), object)
+}
+
+func (r *Reconciler) FindStatefulSet(name string, namespace string, logger logr.Logger) (*appsv1.StatefulSet, error) {
+	logger.Info(fmt.Sprintf("Checking Existence of [%s] stateful set", name))
+	foundStatefulSet := &appsv1.StatefulSet{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, foundStatefulSet)
+	return foundStatefulSet, err
+}
+
+// GetStatefulSetStartOrdinal reads start ordinal of stateful set as unstructured object, because the field is unknown
+// to API of the operator. It returns false if Kubernetes does not keep the field.
+func (r *Reconciler) GetStatefulSetStartOrdinal(name string, namespace string) (int64, bool, error) {
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("StatefulSet"))
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, object); err != nil {
+		return 0, false, err
+	}
+	return unstructured.NestedInt64(object.Object, "spec", "ordinals", "start")
+}
+
+// GetServerVersion returns version of Kubernetes API server
+func (r *Reconciler) GetServerVersion() (*version.Version, error) {
+	clientSet, err := kubernetes.NewForConfig(r.GetOperatorClusterConfig())
+	if err != nil {
+		return nil, err
+	}
+	info, err := clientSet.Discovery().ServerVersion()
+	if err != nil {
+		return nil, err
+	}
+	return version.ParseGeneric(info.GitVersion)
+}
+
+// ScaleStatefulSet changes only replicas of stateful set with merge patch to keep fields unknown to operator
+func (r *Reconciler) ScaleStatefulSet(name string, replicas int32, namespace string, logger logr.Logger) error {
+	logger.Info(fmt.Sprintf("Scaling [%s] stateful set to [%d] replicas", name, replicas))
+	statefulSet := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	patch := []byte(fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas))
+	return r.Client.Patch(context.TODO(), statefulSet, client.RawPatch(types.MergePatchType, patch))
+}
+
 func (r *Reconciler) FindDeploymentList(namespace string, deploymentLabels map[string]string) (*appsv1.DeploymentList, error) {
 	foundDeploymentList := &appsv1.DeploymentList{}
 	err := r.Client.List(context.TODO(), foundDeploymentList, &client.ListOptions{
@@ -0,0 +1,95 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	certmanagerv1 "github.com/Netcracker/qubership-kafka/operator/api/certmanager/v1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCreateOrUpdateCertificate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, certmanagerv1.AddToScheme(scheme))
+	r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build(), Scheme: scheme}
+	newCertificate := func(dnsNames ...string) *certmanagerv1.Certificate {
+		return &certmanagerv1.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Name: "kafka-1-tls-certificate", Namespace: "kafka-service"},
+			Spec: certmanagerv1.CertificateSpec{
+				SecretName: "kafka-1-tls-cert",
+				DNSNames:   dnsNames,
+				IssuerRef:  certmanagerv1.ObjectReference{Name: "kafka-issuer", Kind: certmanagerv1.IssuerKind},
+			},
+		}
+	}
+	key := types.NamespacedName{Name: "kafka-1-tls-certificate", Namespace: "kafka-service"}
+
+	assert.NoError(t, r.CreateOrUpdateCertificate(newCertificate("kafka-1"), logr.Discard()))
+	found := &certmanagerv1.Certificate{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, found))
+	assert.Equal(t, []string{"kafka-1"}, found.Spec.DNSNames)
+	resourceVersion := found.ResourceVersion
+
+	// certificate with the same specification is not updated to avoid unnecessary reissuing
+	assert.NoError(t, r.CreateOrUpdateCertificate(newCertificate("kafka-1"), logr.Discard()))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, found))
+	assert.Equal(t, resourceVersion, found.ResourceVersion)
+
+	assert.NoError(t, r.CreateOrUpdateCertificate(newCertificate("kafka-1", "kafka-1.example.com"), logr.Discard()))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, found))
+	assert.Equal(t, []string{"kafka-1", "kafka-1.example.com"}, found.Spec.DNSNames)
+}
+
+func TestCreateOrUpdateStatefulSet(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, appsv1.AddToScheme(scheme))
+	r := &Reconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build(), Scheme: scheme}
+	newStatefulSet := func(replicas int32, claimName string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "kafka", Namespace: "kafka-service"},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas:             &replicas,
+				ServiceName:          "kafka-broker",
+				VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: claimName}}},
+			},
+		}
+	}
+	key := types.NamespacedName{Name: "kafka", Namespace: "kafka-service"}
+
+	assert.NoError(t, r.CreateOrUpdateStatefulSet(newStatefulSet(3, "pvc"), 1, logr.Discard()))
+	found := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, found))
+	assert.Equal(t, int32(3), *found.Spec.Replicas)
+
+	// volume claim templates of existing stateful set are kept
+	assert.NoError(t, r.CreateOrUpdateStatefulSet(newStatefulSet(4, "data"), 1, logr.Discard()))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, found))
+	assert.Equal(t, int32(4), *found.Spec.Replicas)
+	assert.Equal(t, "pvc", found.Spec.VolumeClaimTemplates[0].Name)
+
+	assert.NoError(t, r.ScaleStatefulSet("kafka", 2, "kafka-service", logr.Discard()))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, found))
+	assert.Equal(t, int32(2), *found.Spec.Replicas)
+	assert.Equal(t, "kafka-broker", found.Spec.ServiceName)
+}
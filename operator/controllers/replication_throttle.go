@@ -0,0 +1,157 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/sarama"
+)
+
+const (
+	leaderThrottledRateConfig       = "leader.replication.throttled.rate"
+	followerThrottledRateConfig     = "follower.replication.throttled.rate"
+	leaderThrottledReplicasConfig   = "leader.replication.throttled.replicas"
+	followerThrottledReplicasConfig = "follower.replication.throttled.replicas"
+)
+
+// getThrottledReplicas returns throttled replicas of partitions which are moved by new assignment in format
+// "partition:broker,...". Leader throttle is applied to current replicas which send data, follower throttle is applied
+// to added replicas which receive data. The function also returns IDs of brokers which participate in reassignment.
+func getThrottledReplicas(currentAssignment [][]int32, newAssignment [][]int32) (string, string, []int32) {
+	var leaderReplicas, followerReplicas []string
+	brokers := map[int32]bool{}
+	for partition := range newAssignment {
+		if partition >= len(currentAssignment) || equalReplicas(currentAssignment[partition], newAssignment[partition]) {
+			continue
+		}
+		for _, broker := range currentAssignment[partition] {
+			leaderReplicas = append(leaderReplicas, fmt.Sprintf("%d:%d", partition, broker))
+			brokers[broker] = true
+		}
+		for _, broker := range newAssignment[partition] {
+			if !containsInt32(currentAssignment[partition], broker) {
+				followerReplicas = append(followerReplicas, fmt.Sprintf("%d:%d", partition, broker))
+				brokers[broker] = true
+			}
+		}
+	}
+	brokerIds := make([]int32, 0, len(brokers))
+	for broker := range brokers {
+		brokerIds = append(brokerIds, broker)
+	}
+	sort.Slice(brokerIds, func(i, j int) bool {
+		return brokerIds[i] < brokerIds[j]
+	})
+	return strings.Join(leaderReplicas, ","), strings.Join(followerReplicas, ","), brokerIds
+}
+
+func equalReplicas(a []int32, b []int32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// setReplicationThrottle limits the rate of replication for partitions of the topic moved by new assignment.
+// It returns IDs of brokers with throttled rates.
+func (kc *KafkaClient) setReplicationThrottle(topic string, currentAssignment [][]int32, newAssignment [][]int32) ([]int32, error) {
+	if kc.replicationThrottleBytesPerSec <= 0 {
+		return nil, nil
+	}
+	leaderReplicas, followerReplicas, brokerIds := getThrottledReplicas(currentAssignment, newAssignment)
+	if len(brokerIds) == 0 {
+		return nil, nil
+	}
+	rate := strconv.FormatInt(kc.replicationThrottleBytesPerSec, 10)
+	log.Info(fmt.Sprintf("Throttling replication of topic %s on brokers %v to %s bytes per second", topic, brokerIds, rate))
+	for _, brokerId := range brokerIds {
+		entries := map[string]sarama.IncrementalAlterConfigsEntry{
+			leaderThrottledRateConfig:   {Operation: sarama.IncrementalAlterConfigsOperationSet, Value: &rate},
+			followerThrottledRateConfig: {Operation: sarama.IncrementalAlterConfigsOperationSet, Value: &rate},
+		}
+		if err := kc.adminClient.IncrementalAlterConfig(sarama.BrokerResource, strconv.Itoa(int(brokerId)), entries, false); err != nil {
+			return brokerIds, err
+		}
+	}
+	entries := map[string]sarama.IncrementalAlterConfigsEntry{
+		leaderThrottledReplicasConfig:   {Operation: sarama.IncrementalAlterConfigsOperationSet, Value: &leaderReplicas},
+		followerThrottledReplicasConfig: {Operation: sarama.IncrementalAlterConfigsOperationSet, Value: &followerReplicas},
+	}
+	return brokerIds, kc.adminClient.IncrementalAlterConfig(sarama.TopicResource, topic, entries, false)
+}
+
+// removeReplicationThrottle removes throttled replicas of the topic and then throttled rates of given brokers
+func (kc *KafkaClient) removeReplicationThrottle(topic string, brokerIds []int32) error {
+	if topic != "" {
+		entries := map[string]sarama.IncrementalAlterConfigsEntry{
+			leaderThrottledReplicasConfig:   {Operation: sarama.IncrementalAlterConfigsOperationDelete},
+			followerThrottledReplicasConfig: {Operation: sarama.IncrementalAlterConfigsOperationDelete},
+		}
+		if err := kc.adminClient.IncrementalAlterConfig(sarama.TopicResource, topic, entries, false); err != nil {
+			return err
+		}
+	}
+	for _, brokerId := range brokerIds {
+		entries := map[string]sarama.IncrementalAlterConfigsEntry{
+			leaderThrottledRateConfig:   {Operation: sarama.IncrementalAlterConfigsOperationDelete},
+			followerThrottledRateConfig: {Operation: sarama.IncrementalAlterConfigsOperationDelete},
+		}
+		if err := kc.adminClient.IncrementalAlterConfig(sarama.BrokerResource, strconv.Itoa(int(brokerId)), entries, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveStaleReplicationThrottles removes throttles recorded in the plan which is discarded before its topics are
+// processed, for example, when brokers or balancing strategy are changed. Throttled replicas are removed only from topics
+// of the plan, throttled rates are removed from brokers of the plan, because the operator owns them during reassignment.
+func (kc *KafkaClient) RemoveStaleReplicationThrottles(plan *ReassignmentPlan) error {
+	brokers := map[int32]bool{}
+	var brokerIds []int32
+	for i := range plan.Topics {
+		topic := &plan.Topics[i]
+		if topic.ThrottledBrokerIds == nil {
+			continue
+		}
+		if err := kc.removeReplicationThrottle(topic.Topic, nil); err != nil {
+			return fmt.Errorf("cannot remove replication throttle for topic [%s]: %w", topic.Topic, err)
+		}
+		for _, brokerId := range topic.ThrottledBrokerIds {
+			if !brokers[brokerId] {
+				brokers[brokerId] = true
+				brokerIds = append(brokerIds, brokerId)
+			}
+		}
+		topic.ThrottledBrokerIds = nil
+	}
+	if len(brokerIds) == 0 {
+		return nil
+	}
+	sort.Slice(brokerIds, func(i, j int) bool {
+		return brokerIds[i] < brokerIds[j]
+	})
+	log.Info(fmt.Sprintf("Removing replication throttles left on brokers %v", brokerIds))
+	return kc.removeReplicationThrottle("", brokerIds)
+}
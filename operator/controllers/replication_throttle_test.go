@@ -0,0 +1,127 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeConfigsAdmin keeps dynamic configs of brokers and topics, other admin operations are not implemented
+type fakeConfigsAdmin struct {
+	sarama.ClusterAdmin
+	configs map[sarama.ConfigResourceType]map[string]map[string]string
+}
+
+func newFakeConfigsAdmin() *fakeConfigsAdmin {
+	return &fakeConfigsAdmin{configs: map[sarama.ConfigResourceType]map[string]map[string]string{
+		sarama.BrokerResource: {},
+		sarama.TopicResource:  {},
+	}}
+}
+
+func (a *fakeConfigsAdmin) IncrementalAlterConfig(resourceType sarama.ConfigResourceType, name string,
+	entries map[string]sarama.IncrementalAlterConfigsEntry, validateOnly bool) error {
+	configs := a.configs[resourceType][name]
+	if configs == nil {
+		configs = map[string]string{}
+		a.configs[resourceType][name] = configs
+	}
+	for key, entry := range entries {
+		if entry.Operation == sarama.IncrementalAlterConfigsOperationDelete {
+			delete(configs, key)
+		} else {
+			configs[key] = *entry.Value
+		}
+	}
+	if len(configs) == 0 {
+		delete(a.configs[resourceType], name)
+	}
+	return nil
+}
+
+func (a *fakeConfigsAdmin) DescribeConfig(resource sarama.ConfigResource) ([]sarama.ConfigEntry, error) {
+	source := sarama.SourceTopic
+	if resource.Type == sarama.BrokerResource {
+		source = sarama.SourceDynamicBroker
+	}
+	var entries []sarama.ConfigEntry
+	for _, name := range resource.ConfigNames {
+		if value, ok := a.configs[resource.Type][resource.Name][name]; ok {
+			entries = append(entries, sarama.ConfigEntry{Name: name, Value: value, Source: source})
+		}
+	}
+	return entries, nil
+}
+
+func TestGetThrottledReplicas(t *testing.T) {
+	leaderReplicas, followerReplicas, brokerIds := getThrottledReplicas(
+		[][]int32{{1, 2}, {2, 3}, {3, 1}},
+		[][]int32{{1, 4}, {2, 3}, {4, 1}})
+	assert.Equal(t, "0:1,0:2,2:3,2:1", leaderReplicas)
+	assert.Equal(t, "0:4,2:4", followerReplicas)
+	assert.Equal(t, []int32{1, 2, 3, 4}, brokerIds)
+
+	leaderReplicas, followerReplicas, brokerIds = getThrottledReplicas([][]int32{{1, 2}}, [][]int32{{1, 2}})
+	assert.Empty(t, leaderReplicas)
+	assert.Empty(t, followerReplicas)
+	assert.Empty(t, brokerIds)
+}
+
+func TestReplicationThrottle(t *testing.T) {
+	admin := newFakeConfigsAdmin()
+	kc := &KafkaClient{adminClient: admin, replicationThrottleBytesPerSec: 1048576,
+		brokerRacks: map[int32]string{1: "", 2: "", 3: ""}}
+
+	brokerIds, err := kc.setReplicationThrottle("orders", [][]int32{{1, 2}, {2, 3}}, [][]int32{{1, 3}, {2, 3}})
+	assert.NoError(t, err)
+	assert.Equal(t, []int32{1, 2, 3}, brokerIds)
+	assert.Equal(t, "1048576", admin.configs[sarama.BrokerResource]["3"][followerThrottledRateConfig])
+	assert.Equal(t, "0:1,0:2", admin.configs[sarama.TopicResource]["orders"][leaderThrottledReplicasConfig])
+	assert.Equal(t, "0:3", admin.configs[sarama.TopicResource]["orders"][followerThrottledReplicasConfig])
+
+	assert.NoError(t, kc.removeReplicationThrottle("orders", brokerIds))
+	assert.Empty(t, admin.configs[sarama.BrokerResource])
+	assert.Empty(t, admin.configs[sarama.TopicResource])
+
+	kc.replicationThrottleBytesPerSec = 0
+	brokerIds, err = kc.setReplicationThrottle("orders", [][]int32{{1, 2}}, [][]int32{{1, 3}})
+	assert.NoError(t, err)
+	assert.Empty(t, brokerIds)
+	assert.Empty(t, admin.configs[sarama.BrokerResource])
+}
+
+func TestRemoveStaleReplicationThrottles(t *testing.T) {
+	admin := newFakeConfigsAdmin()
+	kc := &KafkaClient{adminClient: admin, replicationThrottleBytesPerSec: 1048576,
+		brokerRacks: map[int32]string{1: "", 2: "", 3: "", 4: ""}}
+	// throttled rate of broker which is not recorded in the plan is kept
+	userRate := "2097152"
+	assert.NoError(t, admin.IncrementalAlterConfig(sarama.BrokerResource, "4", map[string]sarama.IncrementalAlterConfigsEntry{
+		leaderThrottledRateConfig: {Operation: sarama.IncrementalAlterConfigsOperationSet, Value: &userRate},
+	}, false))
+	brokerIds, err := kc.setReplicationThrottle("orders", [][]int32{{1, 2}}, [][]int32{{1, 3}})
+	assert.NoError(t, err)
+	plan := &ReassignmentPlan{Topics: []TopicReassignment{
+		{Topic: "orders", Status: TopicReassignmentInProgress, ThrottledBrokerIds: brokerIds},
+		{Topic: "payments", Status: TopicReassignmentPending},
+	}}
+	assert.NoError(t, kc.RemoveStaleReplicationThrottles(plan))
+	assert.Equal(t, map[string]map[string]string{"4": {leaderThrottledRateConfig: userRate}}, admin.configs[sarama.BrokerResource])
+	assert.Empty(t, admin.configs[sarama.TopicResource])
+	assert.Nil(t, plan.Topics[0].ThrottledBrokerIds)
+}
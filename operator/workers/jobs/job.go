@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	certmanagerv1 "github.com/Netcracker/qubership-kafka/operator/api/certmanager/v1"
 	qubershiporgv1 "github.com/Netcracker/qubership-kafka/operator/api/v1"
 	qubershiporgv7 "github.com/Netcracker/qubership-kafka/operator/api/v7"
 	"github.com/Netcracker/qubership-kafka/operator/cfg"
@@ -41,6 +42,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(qubershiporgv1.AddToScheme(scheme))
 	utilruntime.Must(qubershiporgv7.AddToScheme(scheme))
+	utilruntime.Must(certmanagerv1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -119,5 +121,9 @@ func duplicateScheme(apiGroup string) (*runtime.Scheme, error) {
 	secondarySchemeBuilderV7 := &sigsScheme.Builder{GroupVersion: secondaryGroupVersionV7}
 	secondarySchemeBuilderV7.Register(&qubershiporgv7.KafkaService{}, &qubershiporgv7.KafkaServiceList{})
 	err = secondarySchemeBuilderV7.AddToScheme(dblScheme)
+	if err != nil {
+		return nil, err
+	}
+	err = certmanagerv1.AddToScheme(dblScheme)
 	return dblScheme, err
 }